@@ -0,0 +1,39 @@
+package foo
+
+import (
+	"context"
+	"errors"
+)
+
+// Actor represents who made a change and on which request.
+type Actor struct {
+	UserID    string
+	RequestID string
+}
+
+// Key to use when setting the actor.
+type ctxKeyActor int
+
+// actorKey is the key that holds the actor in a context.
+const actorKey ctxKeyActor = iota
+
+// ContextWithActor sets actor to context.
+func ContextWithActor(parent context.Context, a Actor) context.Context {
+	return context.WithValue(parent, actorKey, a)
+}
+
+// ActorFromContext returns actor from the context, zero value when not present.
+func ActorFromContext(ctx context.Context) Actor {
+	a, _ := ctx.Value(actorKey).(Actor)
+	return a
+}
+
+// actorUserID returns the context actor user id of actions on user owned
+// data like follows and notifications.
+func actorUserID(ctx context.Context) (string, error) {
+	userID := ActorFromContext(ctx).UserID
+	if userID == "" {
+		return "", errors.New("requires an authenticated user")
+	}
+	return userID, nil
+}
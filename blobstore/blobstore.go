@@ -0,0 +1,46 @@
+// Package blobstore provides foo.BlobStore implementations on local
+// filesystem and Google Cloud Storage.
+package blobstore
+
+import (
+	"errors"
+	"strings"
+)
+
+// Blob store drivers.
+const (
+	DriverLocal = "local"
+	DriverGCS   = "gcs"
+)
+
+// ErrNotFound is returned when a blob key does not exist.
+var ErrNotFound = errors.New("blob not found")
+
+// Config represents blob store config.
+type Config struct {
+	Driver string
+
+	// LocalDir is the directory of local blobs and LocalURL is the public
+	// url where the Local handler is mounted.
+	LocalDir   string
+	LocalURL   string
+	SigningKey string
+
+	GCSBucket string
+	// GCSEmulatorHost is the fake-gcs-server url, e.g. http://localhost:4443.
+	GCSEmulatorHost string
+}
+
+// validKey reports whether key is a relative slash separated path that does
+// not escape its root.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) {
+		return false
+	}
+	for _, p := range strings.Split(key, "/") {
+		if p == "" || p == "." || p == ".." {
+			return false
+		}
+	}
+	return true
+}
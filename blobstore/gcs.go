@@ -0,0 +1,160 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+// emulatorProjectID is the project of buckets created on the emulator.
+const emulatorProjectID = "foo-local"
+
+// GCS represents a blob store on a Google Cloud Storage bucket.
+type GCS struct {
+	client *storage.Client
+	bucket *storage.BucketHandle
+	// emulator is the fake-gcs-server url signed urls are pointed to.
+	emulator *url.URL
+	signer   *storage.SignedURLOptions
+}
+
+// NewGCS creates new instance of GCS blob store using service account
+// credentials file or application default credentials when empty. When
+// emulatorHost is set it connects to fake-gcs-server and creates the bucket.
+func NewGCS(ctx context.Context, bucket, emulatorHost, credentialsFile string) (*GCS, error) {
+	if bucket == "" {
+		return nil, errors.New("bucket is required")
+	}
+
+	g := &GCS{}
+	var opts []option.ClientOption
+	if emulatorHost != "" {
+		u, err := url.Parse(strings.TrimSuffix(emulatorHost, "/"))
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid emulator host: %s", emulatorHost)
+		}
+		g.emulator = u
+		opts = append(opts, option.WithEndpoint(u.String()+"/storage/v1/"), option.WithoutAuthentication())
+		// Emulator does not verify signatures, signs with credentials when
+		// given or a throwaway key otherwise.
+		if g.signer, err = emulatorSigner(credentialsFile); err != nil {
+			return nil, err
+		}
+	} else if credentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credentialsFile))
+	}
+
+	c, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not create storage client: %s", err)
+	}
+	g.client, g.bucket = c, c.Bucket(bucket)
+
+	if g.emulator != nil {
+		err = g.bucket.Create(ctx, emulatorProjectID, nil)
+		var gerr *googleapi.Error
+		if err != nil && !(errors.As(err, &gerr) && gerr.Code == http.StatusConflict) {
+			c.Close()
+			return nil, fmt.Errorf("could not create emulator bucket: %s", err)
+		}
+	}
+	return g, nil
+}
+
+func (g *GCS) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	if !validKey(key) {
+		return fmt.Errorf("invalid blob key: %q", key)
+	}
+	w := g.bucket.Object(key).NewWriter(ctx)
+	w.ContentType = contentType
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (g *GCS) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	r, err := g.bucket.Object(key).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	return r, err
+}
+
+func (g *GCS) Delete(ctx context.Context, key string) error {
+	err := g.bucket.Object(key).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return err
+	}
+	return nil
+}
+
+// SignedURL returns a V4 signed url. Credentials are detected by the storage
+// client and requires a service account key or IAM signBlob permission.
+func (g *GCS) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	opts := &storage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(ttl),
+		Scheme:  storage.SigningSchemeV4,
+	}
+	if g.signer != nil {
+		opts.GoogleAccessID, opts.PrivateKey = g.signer.GoogleAccessID, g.signer.PrivateKey
+	}
+	s, err := g.bucket.SignedURL(key, opts)
+	if err != nil || g.emulator == nil {
+		return s, err
+	}
+
+	// Points path style url to the emulator that serves /bucket/object.
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	u.Scheme, u.Host = g.emulator.Scheme, g.emulator.Host
+	return u.String(), nil
+}
+
+// Close closes storage client.
+func (g *GCS) Close() error {
+	return g.client.Close()
+}
+
+// emulatorSigner returns signing identity from a service account credentials
+// file or a generated key.
+func emulatorSigner(credentialsFile string) (*storage.SignedURLOptions, error) {
+	if credentialsFile != "" {
+		b, err := os.ReadFile(credentialsFile)
+		if err == nil {
+			var sa struct {
+				ClientEmail string `json:"client_email"`
+				PrivateKey  string `json:"private_key"`
+			}
+			if err = json.Unmarshal(b, &sa); err == nil && sa.PrivateKey != "" {
+				return &storage.SignedURLOptions{GoogleAccessID: sa.ClientEmail, PrivateKey: []byte(sa.PrivateKey)}, nil
+			}
+		}
+	}
+
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	pk := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)})
+	return &storage.SignedURLOptions{GoogleAccessID: "emulator@" + emulatorProjectID + ".iam.gserviceaccount.com", PrivateKey: pk}, nil
+}
@@ -0,0 +1,52 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestGCS runs against fake-gcs-server when BLOB_STORE_GCS_EMULATOR_HOST is
+// set, e.g. docker compose up fake-gcs.
+func TestGCS(t *testing.T) {
+	host := os.Getenv("BLOB_STORE_GCS_EMULATOR_HOST")
+	if host == "" {
+		t.Skip("BLOB_STORE_GCS_EMULATOR_HOST is not set")
+	}
+	ctx := context.Background()
+	g, err := NewGCS(ctx, "foo-test", host, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+
+	const key = "fighters/1/media/2/original.txt"
+	if err = g.Put(ctx, key, strings.NewReader("hello"), "text/plain"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	signed, err := g.SignedURL(ctx, key, time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+	resp, err := http.Get(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(b) != "hello" {
+		t.Errorf("GET %s = %d %q, want hello", signed, resp.StatusCode, b)
+	}
+
+	if err = g.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err = g.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() deleted error = %v, want ErrNotFound", err)
+	}
+}
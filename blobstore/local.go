@@ -0,0 +1,149 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Local represents a blob store on local filesystem. Signed urls are served by
+// Local as http.Handler that verifies url signature and expiry.
+type Local struct {
+	dir     string
+	baseURL string
+	key     []byte
+	now     func() time.Time
+}
+
+// NewLocal creates new instance of local blob store on dir.
+func NewLocal(dir, baseURL, signingKey string) (*Local, error) {
+	if dir == "" {
+		return nil, errors.New("dir is required")
+	}
+	if signingKey == "" {
+		return nil, errors.New("signing key is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("could not create blob dir: %s", err)
+	}
+	return &Local{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		key:     []byte(signingKey),
+		now:     time.Now,
+	}, nil
+}
+
+func (l *Local) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes blob to a temporary file and renames it to key so readers never
+// see partial blobs.
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
+
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *Local) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	exp := strconv.FormatInt(l.now().Add(ttl).Unix(), 10)
+	q := url.Values{"expires": {exp}, "signature": {l.sign(key, exp)}}
+	return l.baseURL + "/" + key + "?" + q.Encode(), nil
+}
+
+func (l *Local) sign(key, expires string) string {
+	h := hmac.New(sha256.New, l.key)
+	h.Write([]byte(key + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// ServeHTTP serves blob of the request path when url signature is valid and
+// not expired. The handler expects LocalURL path prefix stripped.
+func (l *Local) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	exp := r.URL.Query().Get("expires")
+	sig := r.URL.Query().Get("signature")
+	ts, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || !hmac.Equal([]byte(sig), []byte(l.sign(key, exp))) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+	if l.now().Unix() > ts {
+		http.Error(w, "url expired", http.StatusForbidden)
+		return
+	}
+
+	rc, err := l.Get(r.Context(), key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer rc.Close()
+	f := rc.(*os.File)
+	fi, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.FormatInt(max(0, ts-l.now().Unix()), 10))
+	http.ServeContent(w, r, key, fi.ModTime(), f)
+}
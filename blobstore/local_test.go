@@ -0,0 +1,94 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	l, err := NewLocal(t.TempDir(), "http://localhost/blobs/", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const key = "fighters/1/media/2/original.txt"
+	if err = l.Put(ctx, key, strings.NewReader("hello"), "text/plain"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	rc, err := l.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	b, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(b) != "hello" {
+		t.Errorf("Get() = %q, want hello", b)
+	}
+
+	for _, k := range []string{"", "/abs", "a/../../b", "a//b", `a\b`} {
+		if err = l.Put(ctx, k, strings.NewReader("x"), ""); err == nil {
+			t.Errorf("Put(%q) want invalid key error", k)
+		}
+	}
+
+	if err = l.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err = l.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() deleted error = %v, want ErrNotFound", err)
+	}
+	if err = l.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() missing error = %v", err)
+	}
+}
+
+func TestLocal_ServeHTTP(t *testing.T) {
+	ctx := context.Background()
+	l, err := NewLocal(t.TempDir(), "http://localhost/blobs", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const key = "a/photo.jpg"
+	if err = l.Put(ctx, key, strings.NewReader("jpeg"), "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+	signed, err := l.SignedURL(ctx, key, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(signed)
+	u.Path = strings.TrimPrefix(u.Path, "/blobs")
+
+	tampered := *u
+	tampered.Path = "/a/other.jpg"
+	tests := []struct {
+		name string
+		url  *url.URL
+		now  time.Time
+		want int
+	}{
+		{"valid", u, time.Now(), http.StatusOK},
+		{"tampered", &tampered, time.Now(), http.StatusForbidden},
+		{"expired", u, time.Now().Add(2 * time.Minute), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l.now = func() time.Time { return tt.now }
+			w := httptest.NewRecorder()
+			l.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.url.String(), nil))
+			if w.Code != tt.want {
+				t.Fatalf("ServeHTTP() status = %d, want %d", w.Code, tt.want)
+			}
+			if w.Code == http.StatusOK && (w.Body.String() != "jpeg" || w.Header().Get("Content-Type") != "image/jpeg") {
+				t.Errorf("ServeHTTP() got %q %s", w.Body, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
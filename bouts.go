@@ -0,0 +1,183 @@
+package foo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+var (
+	ErrBoutNotFound = xerror.Error(xerror.CodeNotFound)
+	ErrBoutInvalid  = xerror.Error(xerror.CodeInvalid)
+)
+
+// Bout represents a fight between red and blue corner fighters.
+type Bout struct {
+	ID            uuid.UUID   `json:"id"`
+	RedFighterID  uuid.UUID   `json:"red_fighter_id"`
+	BlueFighterID uuid.UUID   `json:"blue_fighter_id"`
+	WeightClass   WeightClass `json:"weight_class"`
+	Date          Date        `json:"date"`
+	// EventID is the event where the bout is on the fight card.
+	EventID *uuid.UUID `json:"event_id,omitempty"`
+	// Result is the outcome of the red corner fighter, empty when the bout
+	// is not yet fought.
+	Result BoutResult `json:"result"`
+	Method BoutMethod `json:"method"`
+	// Round and TimeSeconds marks when the bout ended.
+	Round       int       `json:"round"`
+	TimeSeconds int       `json:"time_seconds"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// BoutResult represents an outcome of a bout.
+type BoutResult string
+
+const (
+	BoutResultWin       BoutResult = "win"
+	BoutResultLoss      BoutResult = "loss"
+	BoutResultDraw      BoutResult = "draw"
+	BoutResultNoContest BoutResult = "no_contest"
+)
+
+// Opposite returns the result from the other corner.
+func (r BoutResult) Opposite() BoutResult {
+	switch r {
+	case BoutResultWin:
+		return BoutResultLoss
+	case BoutResultLoss:
+		return BoutResultWin
+	}
+	return r
+}
+
+// BoutMethod represents how a bout ended.
+type BoutMethod string
+
+const (
+	BoutMethodKO  BoutMethod = "KO"
+	BoutMethodTKO BoutMethod = "TKO"
+	BoutMethodSUB BoutMethod = "SUB"
+	BoutMethodDEC BoutMethod = "DEC"
+)
+
+// bout limits.
+const (
+	maxRounds       = 5
+	roundLengthSecs = 300
+)
+
+// Completed reports whether bout has a result.
+func (b Bout) Completed() bool {
+	return b.Result != ""
+}
+
+// Involves reports whether fighter is on either corner of the bout.
+func (b Bout) Involves(fighterID uuid.UUID) bool {
+	return b.RedFighterID == fighterID || b.BlueFighterID == fighterID
+}
+
+// Opponent returns the other corner fighter id.
+func (b Bout) Opponent(fighterID uuid.UUID) uuid.UUID {
+	if b.RedFighterID == fighterID {
+		return b.BlueFighterID
+	}
+	return b.RedFighterID
+}
+
+// ResultFor returns bout result from the fighter perspective.
+func (b Bout) ResultFor(fighterID uuid.UUID) BoutResult {
+	if b.BlueFighterID == fighterID {
+		return b.Result.Opposite()
+	}
+	return b.Result
+}
+
+// Validate checks bout fields and returns all field errors found.
+func (b Bout) Validate() error {
+	var fe xerror.ValidationError
+	if b.RedFighterID == uuid.Nil {
+		fe = fe.Add("red_fighter_id", xerror.ViolationRequired, "is required")
+	}
+	if b.BlueFighterID == uuid.Nil {
+		fe = fe.Add("blue_fighter_id", xerror.ViolationRequired, "is required")
+	}
+	if b.RedFighterID != uuid.Nil && b.RedFighterID == b.BlueFighterID {
+		fe = fe.Add("blue_fighter_id", xerror.ViolationDuplicate, "must not be the same as red corner fighter")
+	}
+	if b.WeightClass != "" && !b.WeightClass.Valid() {
+		fe = fe.Add("weight_class", xerror.ViolationUnsupported, "is not a known weight class")
+	}
+	if b.Date.IsZero() {
+		fe = fe.Add("date", xerror.ViolationRequired, "is required")
+	}
+
+	switch b.Result {
+	case "":
+		if b.Method != "" || b.Round != 0 || b.TimeSeconds != 0 {
+			fe = fe.Add("result", xerror.ViolationRequired, "is required when method, round or time is set")
+		}
+	case BoutResultWin, BoutResultLoss, BoutResultDraw, BoutResultNoContest:
+		if b.Date.After(time.Now()) {
+			fe = fe.Add("result", xerror.ViolationConflict, "must not be set on a future bout")
+		}
+	default:
+		fe = fe.Add("result", xerror.ViolationUnsupported, "must be one of win, loss, draw or no_contest")
+	}
+
+	switch b.Method {
+	case "":
+		if b.Result == BoutResultWin || b.Result == BoutResultLoss {
+			fe = fe.Add("method", xerror.ViolationRequired, "is required on a win or loss")
+		}
+	case BoutMethodKO, BoutMethodTKO, BoutMethodSUB, BoutMethodDEC:
+	default:
+		fe = fe.Add("method", xerror.ViolationUnsupported, "must be one of KO, TKO, SUB or DEC")
+	}
+
+	if b.Result != "" {
+		if b.Round < 1 || b.Round > maxRounds {
+			fe = fe.Add("round", xerror.ViolationOutOfRange, fmt.Sprintf("must be between 1 and %d", maxRounds))
+		}
+		if b.TimeSeconds < 0 || b.TimeSeconds > roundLengthSecs {
+			fe = fe.Add("time_seconds", xerror.ViolationOutOfRange, fmt.Sprintf("must be between 0 and %d", roundLengthSecs))
+		}
+	}
+
+	if len(fe) != 0 {
+		return ErrBoutInvalid.X(fe)
+	}
+	return nil
+}
+
+// FighterBout represents a bout from a fighter perspective.
+type FighterBout struct {
+	Bout
+	OpponentID uuid.UUID `json:"opponent_id"`
+	// Outcome is the bout result of the fighter.
+	Outcome BoutResult `json:"outcome"`
+}
+
+// newFighterBout returns the bout from the fighter perspective.
+func newFighterBout(fighterID uuid.UUID, b Bout) FighterBout {
+	return FighterBout{Bout: b, OpponentID: b.Opponent(fighterID), Outcome: b.ResultFor(fighterID)}
+}
+
+// Record represents fighter professional win, loss and draw record.
+type Record struct {
+	Wins       int `json:"wins"`
+	Losses     int `json:"losses"`
+	Draws      int `json:"draws"`
+	NoContests int `json:"no_contests"`
+}
+
+// String returns record in W-L-D format with no contests when there is any.
+func (r Record) String() string {
+	if r.NoContests != 0 {
+		return fmt.Sprintf("%d-%d-%d (%d NC)", r.Wins, r.Losses, r.Draws, r.NoContests)
+	}
+	return fmt.Sprintf("%d-%d-%d", r.Wins, r.Losses, r.Draws)
+}
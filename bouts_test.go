@@ -0,0 +1,208 @@
+package foo_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
+)
+
+var (
+	redID  = uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140")
+	blueID = uuid.MustParse("0b5e7c2b-4a3f-4f7e-9d4c-1a0c2b7e5d11")
+)
+
+func TestBout_Validate(t *testing.T) {
+	past := foo.NewDate(time.Date(2023, 7, 8, 0, 0, 0, 0, time.UTC))
+	future := foo.NewDate(time.Now().AddDate(0, 1, 0))
+	tests := []struct {
+		name string
+		bout foo.Bout
+		want []string
+	}{
+		{
+			"scheduled",
+			foo.Bout{RedFighterID: redID, BlueFighterID: blueID, Date: future},
+			nil,
+		},
+		{
+			"completed",
+			foo.Bout{
+				RedFighterID:  redID,
+				BlueFighterID: blueID,
+				WeightClass:   foo.WeightClassLightweight,
+				Date:          past,
+				Result:        foo.BoutResultWin,
+				Method:        foo.BoutMethodSUB,
+				Round:         2,
+				TimeSeconds:   187,
+			},
+			nil,
+		},
+		{
+			"draw without method",
+			foo.Bout{RedFighterID: redID, BlueFighterID: blueID, Date: past, Result: foo.BoutResultDraw, Round: 3, TimeSeconds: 300},
+			nil,
+		},
+		{
+			"same fighter",
+			foo.Bout{RedFighterID: redID, BlueFighterID: redID, Date: past},
+			[]string{"blue_fighter_id"},
+		},
+		{
+			"result on future bout",
+			foo.Bout{RedFighterID: redID, BlueFighterID: blueID, Date: future, Result: foo.BoutResultWin, Method: foo.BoutMethodKO, Round: 1},
+			[]string{"result"},
+		},
+		{
+			"win without method and round",
+			foo.Bout{RedFighterID: redID, BlueFighterID: blueID, Date: past, Result: foo.BoutResultLoss},
+			[]string{"method", "round"},
+		},
+		{
+			"all invalid",
+			foo.Bout{WeightClass: "catchweight", Result: "forfeit", Method: "DQ", Round: 9, TimeSeconds: 900},
+			[]string{"red_fighter_id", "blue_fighter_id", "weight_class", "date", "result", "method", "round", "time_seconds"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bout.Validate()
+			var got []string
+			var fe xerror.ValidationError
+			if errors.As(err, &fe) {
+				for _, e := range fe {
+					got = append(got, e.Field)
+				}
+			} else if err != nil {
+				t.Fatalf("Validate() unexpected error type %T: %s", err, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_FighterBouts(t *testing.T) {
+	bout := foo.Bout{RedFighterID: redID, BlueFighterID: blueID, Result: foo.BoutResultWin, Method: foo.BoutMethodKO, Round: 1}
+	repo := &mockFighterRepo{
+		FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+			return &foo.Fighter{ID: id}, nil
+		},
+		FighterBoutsFn: func(ctx context.Context, fighterID uuid.UUID) ([]foo.Bout, error) {
+			return []foo.Bout{bout}, nil
+		},
+	}
+	tests := []struct {
+		name      string
+		fighterID uuid.UUID
+		want      []foo.FighterBout
+	}{
+		{
+			"red corner",
+			redID,
+			[]foo.FighterBout{{Bout: bout, OpponentID: blueID, Outcome: foo.BoutResultWin}},
+		},
+		{
+			"blue corner",
+			blueID,
+			[]foo.FighterBout{{Bout: bout, OpponentID: redID, Outcome: foo.BoutResultLoss}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			got, err := svc.FighterBouts(context.Background(), tt.fighterID.String())
+			if err != nil {
+				t.Fatalf("FighterBouts() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FighterBouts() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_CreateBout_MergedFighters(t *testing.T) {
+	redAlias := uuid.MustParse("5f0c1e44-8a9d-4c1b-9e35-2d7c6b8a1f20")
+	blueAlias := uuid.MustParse("9a7d3c12-6e4b-4f80-b1a2-3c5d7e9f0b14")
+	aliases := map[uuid.UUID]uuid.UUID{redAlias: redID, blueAlias: blueID}
+	tests := []struct {
+		name     string
+		red      uuid.UUID
+		blue     uuid.UUID
+		wantRed  uuid.UUID
+		wantBlue uuid.UUID
+		wantErr  []string
+	}{
+		{"merged ids", redAlias, blueAlias, redID, blueID, nil},
+		{"merged into the other corner", redID, redAlias, uuid.Nil, uuid.Nil, []string{"blue_fighter_id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *foo.Bout
+			repo := &mockFighterRepo{
+				FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+					if survivor, ok := aliases[id]; ok {
+						id = survivor
+					}
+					return &foo.Fighter{ID: id}, nil
+				},
+				CreateBoutFn: func(ctx context.Context, b *foo.Bout, mm ...foo.Message) error {
+					got = b
+					return nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			b := &foo.Bout{RedFighterID: tt.red, BlueFighterID: tt.blue, Date: foo.Date{Time: time.Now().AddDate(0, 0, 7)}}
+			_, err := svc.CreateBout(context.Background(), b)
+
+			var gotErr []string
+			var fe xerror.ValidationError
+			if errors.As(err, &fe) {
+				for _, e := range fe {
+					gotErr = append(gotErr, e.Field)
+				}
+			} else if err != nil {
+				t.Fatalf("CreateBout() unexpected error %s", err)
+			}
+			if !reflect.DeepEqual(gotErr, tt.wantErr) {
+				t.Fatalf("CreateBout() error fields = %v, want %v", gotErr, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if got != nil {
+					t.Errorf("CreateBout() stored bout between the same fighter")
+				}
+				return
+			}
+			if got.RedFighterID != tt.wantRed || got.BlueFighterID != tt.wantBlue {
+				t.Errorf("CreateBout() fighters = %v, %v, want %v, %v", got.RedFighterID, got.BlueFighterID, tt.wantRed, tt.wantBlue)
+			}
+		})
+	}
+}
+
+func TestRecord_String(t *testing.T) {
+	tests := []struct {
+		record foo.Record
+		want   string
+	}{
+		{foo.Record{Wins: 22, Losses: 3, Draws: 1}, "22-3-1"},
+		{foo.Record{Wins: 10, NoContests: 2}, "10-0-0 (2 NC)"},
+	}
+	for _, tt := range tests {
+		if got := tt.record.String(); got != tt.want {
+			t.Errorf("String() = %s, want %s", got, tt.want)
+		}
+	}
+}
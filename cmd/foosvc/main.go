@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
@@ -13,10 +15,11 @@ import (
 	"time"
 
 	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/blobstore"
 	"github.com/kudarap/foo/config"
 	"github.com/kudarap/foo/fakeauthenticator"
-	"github.com/kudarap/foo/fakeproducer"
 	"github.com/kudarap/foo/logging"
+	"github.com/kudarap/foo/notify"
 	"github.com/kudarap/foo/postgres"
 	"github.com/kudarap/foo/server"
 	"github.com/kudarap/foo/telemetry"
@@ -26,12 +29,16 @@ import (
 const (
 	modeServer = "server"
 	modeWorker = "worker"
+
+	fighterPurgeInterval         = time.Hour
+	webhookDeliveryPurgeInterval = time.Hour
 )
 
 type App struct {
 	config   *config.Config
 	server   *server.Server
 	worker   *worker.Worker
+	relay    *worker.Relay
 	logger   *slog.Logger
 	version  server.Version
 	closerFn func() error
@@ -46,17 +53,40 @@ func (a *App) Setup() error {
 	if err != nil {
 		return fmt.Errorf("could not setup firebase: %s", err)
 	}
+	blobs, blobHandler, err := a.setupBlobStore()
+	if err != nil {
+		return fmt.Errorf("could not setup blob store: %s", err)
+	}
 
-	svc := foo.NewService(postgresClient, a.logger)
+	webhooks := notify.NewWebhook(a.config.Notify.WebhookTimeout)
+	notifiers, err := a.setupNotifiers(postgresClient, webhooks)
+	if err != nil {
+		return fmt.Errorf("could not setup notifiers: %s", err)
+	}
+
+	svc := foo.NewService(postgresClient, blobs, notifiers, webhooks, a.logger)
 	service := telemetry.TraceFooService(svc)
 
 	tsi := telemetry.NewServerInstrumentation(a.config.Telemetry.ServiceName)
-	a.server = server.New(a.config.Server, service, fakeAuth, postgresClient, tsi, a.version, a.logger)
+	a.server = server.New(a.config.Server, service, fakeAuth, postgresClient, blobHandler, tsi, a.version, a.logger)
 
-	fp := fakeproducer.New(time.Second)
-	a.worker = worker.New(fp, a.config.WorkerQueueSize, a.logger)
+	a.worker = worker.New(postgresClient, a.config.WorkerQueueSize, a.logger)
 	a.worker.Use(worker.LoggingMiddleware(a.logger), telemetry.TraceWorker)
-	a.worker.HandleFunc("demo", worker.FakeFighterConsumer(a.logger))
+	a.worker.HandleFunc(foo.TopicBoutCompleted, worker.RankingsConsumer(service))
+	a.worker.HandleFunc(foo.TopicFightersPurge, worker.FighterPurger(service, a.config.FighterRetention))
+	a.worker.Schedule(foo.TopicFightersPurge, fighterPurgeInterval)
+	a.worker.HandleFunc(foo.TopicFightersImport, worker.FighterImporter(service))
+	a.worker.HandleFunc(foo.TopicMediaThumbnail, worker.MediaThumbnailer(service))
+	a.worker.HandleFunc(foo.TopicFighterStats, worker.FighterStatsRefresher(service))
+	a.worker.HandleFunc(foo.TopicNotificationsFanout, worker.NotificationFanout(service))
+	a.worker.HandleFunc(foo.TopicNotificationDeliver, worker.NotificationDeliverer(service))
+	for _, event := range foo.WebhookEvents {
+		a.worker.HandleFunc(event, worker.WebhookDispatcher(service))
+	}
+	a.worker.HandleFunc(foo.TopicWebhookDeliver, worker.WebhookDeliverer(service))
+	a.worker.HandleFunc(foo.TopicWebhookDeliveriesPurge, worker.WebhookDeliveryPurger(service, a.config.WebhookDeliveryRetention))
+	a.worker.Schedule(foo.TopicWebhookDeliveriesPurge, webhookDeliveryPurgeInterval)
+	a.relay = worker.NewRelay(postgresClient, a.config.RelayInterval, a.logger)
 
 	a.closerFn = func() error {
 		if err = postgresClient.Close(); err != nil {
@@ -65,17 +95,61 @@ func (a *App) Setup() error {
 		if err = a.server.Close(); err != nil {
 			return fmt.Errorf("could not close server: %s", err)
 		}
+		if c, ok := blobs.(io.Closer); ok {
+			if err = c.Close(); err != nil {
+				return fmt.Errorf("could not close blob store: %s", err)
+			}
+		}
 		return nil
 	}
 	return nil
 }
 
+// setupBlobStore returns configured blob store and the handler serving its
+// signed urls when blobs are kept locally.
+func (a *App) setupBlobStore() (foo.BlobStore, http.Handler, error) {
+	c := a.config.BlobStore
+	switch c.Driver {
+	case blobstore.DriverGCS:
+		g, err := blobstore.NewGCS(context.Background(), c.GCSBucket, c.GCSEmulatorHost, a.config.GoogleApplicationCredentials)
+		if err != nil {
+			return nil, nil, err
+		}
+		return g, nil, nil
+	case blobstore.DriverLocal, "":
+		l, err := blobstore.NewLocal(c.LocalDir, c.LocalURL, c.SigningKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		return l, l, nil
+	}
+	return nil, nil, fmt.Errorf("blob store driver not supported: %s", c.Driver)
+}
+
+// setupNotifiers returns notifiers of each notification channel, email is
+// only delivered when a mail server is configured.
+func (a *App) setupNotifiers(inbox notify.InboxStore, webhook *notify.Webhook) (map[foo.NotificationChannel]foo.Notifier, error) {
+	c := a.config.Notify
+	nn := map[foo.NotificationChannel]foo.Notifier{
+		foo.NotificationChannelInApp:   notify.NewInbox(inbox),
+		foo.NotificationChannelWebhook: webhook,
+	}
+	if c.SMTPAddr != "" {
+		s, err := notify.NewSMTP(c.SMTPAddr, c.SMTPUsername, c.SMTPPassword, c.SMTPFrom)
+		if err != nil {
+			return nil, err
+		}
+		nn[foo.NotificationChannelEmail] = s
+	}
+	return nn, nil
+}
+
 func (a *App) Run(mode string) error {
 	switch mode {
 	case modeServer:
 		return appRunner(a.server)
 	case modeWorker:
-		return appRunner(a.worker)
+		return appRunner(runners{a.relay, a.worker})
 	default:
 		return fmt.Errorf("app mode not supported: %s", mode)
 	}
@@ -106,7 +180,7 @@ func main() {
 	}
 	defer func() {
 		if err = shutdown(context.Background()); err != nil {
-			log.Error("failed to shutdown TracerProvider: %w", err)
+			log.Error("failed to shutdown TracerProvider", "err", err)
 		}
 	}()
 	log = telemetry.TraceLogger(log)
@@ -156,6 +230,26 @@ type runner interface {
 	Stop() error
 }
 
+// runners runs multiple runners in order and stops them in reverse order.
+type runners []runner
+
+func (rr runners) Run() error {
+	for _, r := range rr {
+		if err := r.Run(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rr runners) Stop() error {
+	var errs []error
+	for i := len(rr) - 1; i >= 0; i-- {
+		errs = append(errs, rr[i].Stop())
+	}
+	return errors.Join(errs...)
+}
+
 // version data will set during build time using go build -ldflags.
 var vTag, vCommit, vBuilt string
 
@@ -0,0 +1,29 @@
+package foo
+
+// FighterComparison represents two fighters side by side with the opponents
+// both have faced and the bouts between them.
+type FighterComparison struct {
+	A Fighter `json:"a"`
+	B Fighter `json:"b"`
+	// SharedOpponents are ordered by their latest bout against either fighter.
+	SharedOpponents []SharedOpponent `json:"shared_opponents"`
+	// DirectBouts are bouts between the fighters from A perspective, latest first.
+	DirectBouts []FighterBout `json:"direct_bouts"`
+}
+
+// SharedOpponent represents an opponent both compared fighters have faced and
+// their bouts against the opponent, latest first.
+type SharedOpponent struct {
+	Opponent Fighter       `json:"opponent"`
+	ABouts   []FighterBout `json:"a_bouts"`
+	BBouts   []FighterBout `json:"b_bouts"`
+}
+
+// latestBout returns date of the latest bout against the opponent.
+func (o SharedOpponent) latestBout() Date {
+	d := o.ABouts[0].Date
+	if o.BBouts[0].Date.After(d.Time) {
+		d = o.BBouts[0].Date
+	}
+	return d
+}
@@ -0,0 +1,108 @@
+package foo_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
+)
+
+func TestService_CompareFighters(t *testing.T) {
+	oldID := uuid.MustParse("5d2f8a1c-3b4e-4c6d-9e8f-7a1b2c3d4e5f")
+	newID := uuid.MustParse("9a1c3e5f-7b9d-4f1a-8c2e-4b6d8f0a2c4e")
+	deletedID := uuid.MustParse("3c5e7a9b-1d3f-4b5d-8e7f-9a1b3c5d7e9f")
+	day := func(d int) foo.Date { return foo.NewDate(time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC)) }
+
+	direct := foo.Bout{RedFighterID: blueID, BlueFighterID: redID, Date: day(9), Result: foo.BoutResultLoss}
+	redOld := foo.Bout{RedFighterID: redID, BlueFighterID: oldID, Date: day(2), Result: foo.BoutResultWin}
+	blueOld := foo.Bout{RedFighterID: oldID, BlueFighterID: blueID, Date: day(1), Result: foo.BoutResultWin}
+	redNew := foo.Bout{RedFighterID: newID, BlueFighterID: redID, Date: day(3), Result: foo.BoutResultDraw}
+	blueNew := foo.Bout{RedFighterID: blueID, BlueFighterID: newID, Date: day(5), Result: foo.BoutResultLoss}
+	redDeleted := foo.Bout{RedFighterID: redID, BlueFighterID: deletedID, Date: day(4)}
+	blueDeleted := foo.Bout{RedFighterID: blueID, BlueFighterID: deletedID, Date: day(4)}
+	bouts := map[uuid.UUID][]foo.Bout{
+		redID:  {direct, redDeleted, redNew, redOld},
+		blueID: {direct, blueNew, blueDeleted, blueOld},
+	}
+
+	repo := &mockFighterRepo{
+		FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+			return &foo.Fighter{ID: id}, nil
+		},
+		FighterBoutsFn: func(ctx context.Context, fighterID uuid.UUID) ([]foo.Bout, error) {
+			return bouts[fighterID], nil
+		},
+		FightersByIDFn: func(ctx context.Context, ids []uuid.UUID) ([]foo.Fighter, error) {
+			var ff []foo.Fighter
+			for _, id := range ids {
+				if id != deletedID {
+					ff = append(ff, foo.Fighter{ID: id})
+				}
+			}
+			return ff, nil
+		},
+	}
+	l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := foo.NewService(repo, nil, nil, nil, l)
+	got, err := svc.CompareFighters(context.Background(), redID.String(), blueID.String())
+	if err != nil {
+		t.Fatalf("CompareFighters() unexpected error %s", err)
+	}
+
+	if len(got.DirectBouts) != 1 || got.DirectBouts[0].Outcome != foo.BoutResultWin {
+		t.Errorf("CompareFighters() direct bouts = %+v, want a win of a", got.DirectBouts)
+	}
+	var gotOpponents []uuid.UUID
+	for _, o := range got.SharedOpponents {
+		gotOpponents = append(gotOpponents, o.Opponent.ID)
+	}
+	if want := []uuid.UUID{newID, oldID}; !reflect.DeepEqual(gotOpponents, want) {
+		t.Fatalf("CompareFighters() shared opponents = %v, want %v", gotOpponents, want)
+	}
+	old := got.SharedOpponents[1]
+	if old.ABouts[0].Outcome != foo.BoutResultWin || old.BBouts[0].Outcome != foo.BoutResultLoss {
+		t.Errorf("CompareFighters() shared opponent outcomes = %s, %s, want win, loss",
+			old.ABouts[0].Outcome, old.BBouts[0].Outcome)
+	}
+}
+
+func TestService_CompareFighters_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b       string
+		wantFields []string
+	}{
+		{"missing both", "", " ", []string{"a", "b"}},
+		{"same fighter", redID.String(), redID.String(), []string{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockFighterRepo{
+				FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+					return &foo.Fighter{ID: id}, nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			_, err := svc.CompareFighters(context.Background(), tt.a, tt.b)
+			var ve xerror.ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("CompareFighters() error = %v, want validation error", err)
+			}
+			var got []string
+			for _, v := range ve {
+				got = append(got, v.Field)
+			}
+			if !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("CompareFighters() fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
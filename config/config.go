@@ -3,7 +3,10 @@ package config
 import (
 	"errors"
 	"os"
+	"time"
 
+	"github.com/kudarap/foo/blobstore"
+	"github.com/kudarap/foo/notify"
 	"github.com/kudarap/foo/postgres"
 	"github.com/kudarap/foo/server"
 	"github.com/kudarap/foo/telemetry"
@@ -16,9 +19,14 @@ const DefaultFile = ".env"
 type Config struct {
 	Server                       server.Config
 	WorkerQueueSize              int
+	FighterRetention             time.Duration
+	WebhookDeliveryRetention     time.Duration
+	RelayInterval                time.Duration
 	Telemetry                    telemetry.Config
 	GoogleApplicationCredentials string
 	Postgres                     postgres.Config
+	BlobStore                    blobstore.Config
+	Notify                       notify.Config
 }
 
 // Load loads config from environment variables and file.
@@ -36,7 +44,10 @@ func Load(file string) (*Config, error) {
 			ReadTimeout:  viper.GetDuration("SERVER_READ_TIMEOUT"),
 			WriteTimeout: viper.GetDuration("SERVER_WRITE_TIMEOUT"),
 		},
-		WorkerQueueSize: viper.GetInt("WORKER_QUEUE_SIZE"),
+		WorkerQueueSize:          viper.GetInt("WORKER_QUEUE_SIZE"),
+		FighterRetention:         viper.GetDuration("FIGHTER_RETENTION"),
+		WebhookDeliveryRetention: viper.GetDuration("WEBHOOK_DELIVERY_RETENTION"),
+		RelayInterval:            viper.GetDuration("RELAY_INTERVAL"),
 		Telemetry: telemetry.Config{
 			Enabled:      viper.GetBool("TELEMETRY_ENABLED"),
 			CollectorURL: viper.GetString("TELEMETRY_COLLECTOR_URL"),
@@ -50,6 +61,21 @@ func Load(file string) (*Config, error) {
 			MaxConnLifetime: viper.GetDuration("POSTGRES_MAX_LIFE_TIME"),
 		},
 		GoogleApplicationCredentials: viper.GetString("GOOGLE_APPLICATION_CREDENTIALS"),
+		BlobStore: blobstore.Config{
+			Driver:          viper.GetString("BLOB_STORE_DRIVER"),
+			LocalDir:        viper.GetString("BLOB_STORE_LOCAL_DIR"),
+			LocalURL:        viper.GetString("BLOB_STORE_LOCAL_URL"),
+			SigningKey:      viper.GetString("BLOB_STORE_SIGNING_KEY"),
+			GCSBucket:       viper.GetString("BLOB_STORE_GCS_BUCKET"),
+			GCSEmulatorHost: viper.GetString("BLOB_STORE_GCS_EMULATOR_HOST"),
+		},
+		Notify: notify.Config{
+			SMTPAddr:       viper.GetString("NOTIFY_SMTP_ADDR"),
+			SMTPUsername:   viper.GetString("NOTIFY_SMTP_USERNAME"),
+			SMTPPassword:   viper.GetString("NOTIFY_SMTP_PASSWORD"),
+			SMTPFrom:       viper.GetString("NOTIFY_SMTP_FROM"),
+			WebhookTimeout: viper.GetDuration("NOTIFY_WEBHOOK_TIMEOUT"),
+		},
 	}
 	return c, nil
 }
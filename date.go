@@ -0,0 +1,29 @@
+package foo
+
+import "time"
+
+const dateLayout = "2006-01-02"
+
+// Date represents a calendar date without time encoded as YYYY-MM-DD.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns date of given time truncated to a day.
+func NewDate(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{time.Date(y, m, d, 0, 0, 0, 0, time.UTC)}
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.Format(dateLayout) + `"`), nil
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	t, err := time.Parse(`"`+dateLayout+`"`, string(b))
+	if err != nil {
+		return err
+	}
+	d.Time = t
+	return nil
+}
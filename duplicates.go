@@ -0,0 +1,33 @@
+package foo
+
+import (
+	"github.com/google/uuid"
+)
+
+// FighterDuplicate represents a likely duplicate pair of fighters.
+type FighterDuplicate struct {
+	Fighter   Fighter `json:"fighter"`
+	Duplicate Fighter `json:"duplicate"`
+	// NameSimilarity is the trigram similarity of fighter names from 0 to 1.
+	NameSimilarity  float64 `json:"name_similarity"`
+	SameDateOfBirth bool    `json:"same_date_of_birth"`
+	SameNationality bool    `json:"same_nationality"`
+}
+
+// FighterMerge represents fighter merge options, the duplicate fighter is
+// merged into the fighter and removed.
+type FighterMerge struct {
+	DuplicateID uuid.UUID `json:"duplicate_id"`
+}
+
+// NewFighterMergeChange returns a change entry of a duplicate fighter merged
+// into the fighter by the actor.
+func NewFighterMergeChange(f *Fighter, duplicateID uuid.UUID, a Actor) FighterChange {
+	return FighterChange{
+		FighterID: f.ID,
+		Action:    FighterActionMerge,
+		Diff:      map[string]FieldChange{"merged_fighter_id": {After: duplicateID}},
+		UserID:    a.UserID,
+		RequestID: a.RequestID,
+	}
+}
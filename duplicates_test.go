@@ -0,0 +1,92 @@
+package foo_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
+)
+
+func TestService_MergeFighter(t *testing.T) {
+	otherID := uuid.MustParse("5d2f8a1c-3b4e-4c6d-9e8f-7a1b2c3d4e5f")
+	eventID := uuid.MustParse("9a1c3e5f-7b9d-4f1a-8c2e-4b6d8f0a2c4e")
+	completed := foo.Bout{
+		RedFighterID: blueID, BlueFighterID: otherID, WeightClass: foo.WeightClassStrawweight,
+		Result: foo.BoutResultWin, Method: foo.BoutMethodKO, Round: 1,
+	}
+	tests := []struct {
+		name string
+		// params
+		duplicateID uuid.UUID
+		bouts       map[uuid.UUID][]foo.Bout
+		// returns
+		wantTopics []string
+		wantCode   string
+	}{
+		{
+			"merged",
+			blueID,
+			map[uuid.UUID][]foo.Bout{blueID: {completed, completed}},
+			[]string{foo.TopicBoutCompleted, foo.TopicFighterStats},
+			"",
+		},
+		{"same fighter", redID, nil, nil, xerror.CodeInvalid},
+		{"missing duplicate", uuid.Nil, nil, nil, xerror.CodeInvalid},
+		{"duplicate not found", otherID, nil, nil, xerror.CodeNotFound},
+		{
+			"fought each other",
+			blueID,
+			map[uuid.UUID][]foo.Bout{blueID: {{RedFighterID: redID, BlueFighterID: blueID}}},
+			nil,
+			xerror.CodeInvalid,
+		},
+		{
+			"same event",
+			blueID,
+			map[uuid.UUID][]foo.Bout{
+				redID:  {{RedFighterID: redID, BlueFighterID: otherID, EventID: &eventID}},
+				blueID: {{RedFighterID: blueID, BlueFighterID: otherID, EventID: &eventID}},
+			},
+			nil,
+			xerror.CodeInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTopics []string
+			repo := &mockFighterRepo{
+				FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+					if id == otherID {
+						return nil, foo.ErrFighterNotFound
+					}
+					return &foo.Fighter{ID: id}, nil
+				},
+				FighterBoutsFn: func(ctx context.Context, fighterID uuid.UUID) ([]foo.Bout, error) {
+					return tt.bouts[fighterID], nil
+				},
+				MergeFighterFn: func(ctx context.Context, id, duplicateID uuid.UUID, mm ...foo.Message) (*foo.Fighter, error) {
+					for _, m := range mm {
+						gotTopics = append(gotTopics, m.Topic)
+					}
+					return &foo.Fighter{ID: id}, nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			_, err := svc.MergeFighter(context.Background(), redID.String(), foo.FighterMerge{DuplicateID: tt.duplicateID})
+			var xerr xerror.XError
+			errors.As(err, &xerr)
+			if (err != nil) != (tt.wantCode != "") || xerr.Code != tt.wantCode {
+				t.Fatalf("MergeFighter() error = %v, wantCode %q", err, tt.wantCode)
+			}
+			if len(gotTopics) != len(tt.wantTopics) {
+				t.Errorf("MergeFighter() published %v, want %v", gotTopics, tt.wantTopics)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package foo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+var (
+	ErrEventNotFound = xerror.Error(xerror.CodeNotFound)
+	ErrEventInvalid  = xerror.Error(xerror.CodeInvalid)
+)
+
+// Event represents a fight night with its fight card.
+type Event struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Venue string    `json:"venue"`
+	Date  Date      `json:"date"`
+	// Card is the ordered fight card, main card first.
+	Card      []CardBout `json:"card,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// CardSegment represents a section of the fight card.
+type CardSegment string
+
+const (
+	CardSegmentMain    CardSegment = "main"
+	CardSegmentPrelims CardSegment = "prelims"
+)
+
+// CardBout represents a bout placement on an event fight card.
+type CardBout struct {
+	BoutID  uuid.UUID   `json:"bout_id"`
+	Segment CardSegment `json:"segment"`
+	// Position orders bouts within the segment starting from 1 as the top bout,
+	// defaults to the card order when not set.
+	Position int   `json:"position"`
+	Bout     *Bout `json:"bout,omitempty"`
+}
+
+// EventQuery represents event list options.
+type EventQuery struct {
+	From  *Date
+	To    *Date
+	Limit int
+}
+
+func (q EventQuery) setDefaults() EventQuery {
+	if q.Limit <= 0 {
+		q.Limit = defaultListLimit
+	}
+	if q.Limit > maxListLimit {
+		q.Limit = maxListLimit
+	}
+	return q
+}
+
+// normalizeCard defaults card segment to main card and assigns missing positions by
+// card order within each segment.
+func (e *Event) normalizeCard() {
+	next := map[CardSegment]int{}
+	for i := range e.Card {
+		c := &e.Card[i]
+		if c.Segment == "" {
+			c.Segment = CardSegmentMain
+		}
+		next[c.Segment]++
+		if c.Position == 0 {
+			c.Position = next[c.Segment]
+		}
+	}
+}
+
+// Validate checks event fields and returns all field errors found.
+func (e Event) Validate() error {
+	var fe xerror.ValidationError
+	if e.Name == "" {
+		fe = fe.Add("name", xerror.ViolationRequired, "is required")
+	} else if len(e.Name) > maxNameLength {
+		fe = fe.Add("name", xerror.ViolationTooLong, fmt.Sprintf("must not exceed %d characters", maxNameLength))
+	}
+	if len(e.Venue) > maxNameLength {
+		fe = fe.Add("venue", xerror.ViolationTooLong, fmt.Sprintf("must not exceed %d characters", maxNameLength))
+	}
+	if e.Date.IsZero() {
+		fe = fe.Add("date", xerror.ViolationRequired, "is required")
+	}
+
+	type slot struct {
+		segment  CardSegment
+		position int
+	}
+	slots := map[slot]bool{}
+	bouts := map[uuid.UUID]bool{}
+	for i, c := range e.Card {
+		field := fmt.Sprintf("card[%d]", i)
+		if c.BoutID == uuid.Nil {
+			fe = fe.Add(field+".bout_id", xerror.ViolationRequired, "is required")
+		} else if bouts[c.BoutID] {
+			fe = fe.Add(field+".bout_id", xerror.ViolationDuplicate, "must not be repeated on the card")
+		}
+		bouts[c.BoutID] = true
+
+		switch c.Segment {
+		case CardSegmentMain, CardSegmentPrelims:
+		default:
+			fe = fe.Add(field+".segment", xerror.ViolationUnsupported, "must be one of main or prelims")
+		}
+		s := slot{c.Segment, c.Position}
+		if c.Position < 1 {
+			fe = fe.Add(field+".position", xerror.ViolationOutOfRange, "must be greater than 0")
+		} else if slots[s] {
+			fe = fe.Add(field+".position", xerror.ViolationDuplicate, "must be unique within the segment")
+		}
+		slots[s] = true
+	}
+
+	if len(fe) != 0 {
+		return ErrEventInvalid.X(fe)
+	}
+	return nil
+}
+
+// checkSchedule checks card bouts are not scheduled on other event and a fighter
+// is only booked once on the event. Card bouts must be populated.
+func (e Event) checkSchedule() error {
+	var fe xerror.ValidationError
+	booked := map[uuid.UUID]uuid.UUID{}
+	for i, c := range e.Card {
+		field := fmt.Sprintf("card[%d].bout_id", i)
+		b := c.Bout
+		if b == nil {
+			continue
+		}
+		if b.EventID != nil && *b.EventID != e.ID {
+			fe = fe.Add(field, xerror.ViolationConflict, fmt.Sprintf("bout is already on event %s", b.EventID))
+			continue
+		}
+		for _, fid := range []uuid.UUID{b.RedFighterID, b.BlueFighterID} {
+			if other, ok := booked[fid]; ok {
+				fe = fe.Add(field, xerror.ViolationConflict, fmt.Sprintf("fighter %s is already booked on bout %s", fid, other))
+				continue
+			}
+			booked[fid] = b.ID
+		}
+	}
+
+	if len(fe) != 0 {
+		return ErrEventInvalid.X(fe)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package foo_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
+)
+
+func TestService_CreateEvent(t *testing.T) {
+	thirdID := uuid.MustParse("5f0f3c1e-8b7a-4d6c-a2e1-9c8b7a6d5e4f")
+	otherEventID := uuid.MustParse("9a1c3e5f-7b9d-4f1a-8c2e-4b6d8f0a2c4e")
+	bouts := map[uuid.UUID]*foo.Bout{
+		uuid.MustParse("00000000-0000-0000-0000-000000000001"): {RedFighterID: redID, BlueFighterID: blueID},
+		uuid.MustParse("00000000-0000-0000-0000-000000000002"): {RedFighterID: thirdID, BlueFighterID: blueID},
+		uuid.MustParse("00000000-0000-0000-0000-000000000003"): {RedFighterID: thirdID, BlueFighterID: redID, EventID: &otherEventID},
+		uuid.MustParse("00000000-0000-0000-0000-000000000004"): {RedFighterID: thirdID, BlueFighterID: uuid.New()},
+		uuid.MustParse("00000000-0000-0000-0000-000000000005"): {RedFighterID: uuid.New(), BlueFighterID: uuid.New()},
+	}
+	repo := &mockFighterRepo{
+		BoutFn: func(ctx context.Context, id uuid.UUID) (*foo.Bout, error) {
+			b, ok := bouts[id]
+			if !ok {
+				return nil, foo.ErrBoutNotFound
+			}
+			c := *b
+			c.ID = id
+			return &c, nil
+		},
+		CreateEventFn: func(ctx context.Context, e *foo.Event, mm ...foo.Message) error {
+			return nil
+		},
+	}
+	date := foo.NewDate(time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name      string
+		card      []foo.CardBout
+		wantOrder []int
+		wantErr   []string
+	}{
+		{
+			"ok",
+			[]foo.CardBout{
+				{BoutID: uuid.MustParse("00000000-0000-0000-0000-000000000001")},
+			},
+			[]int{1},
+			nil,
+		},
+		{
+			"positions by segment",
+			[]foo.CardBout{
+				{BoutID: uuid.MustParse("00000000-0000-0000-0000-000000000001")},
+				{BoutID: uuid.MustParse("00000000-0000-0000-0000-000000000004"), Segment: foo.CardSegmentPrelims},
+				{BoutID: uuid.MustParse("00000000-0000-0000-0000-000000000005"), Segment: foo.CardSegmentPrelims},
+			},
+			[]int{1, 1, 2},
+			nil,
+		},
+		{
+			"fighter booked twice",
+			[]foo.CardBout{
+				{BoutID: uuid.MustParse("00000000-0000-0000-0000-000000000001")},
+				{BoutID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Segment: foo.CardSegmentPrelims},
+			},
+			nil,
+			[]string{"card[1].bout_id"},
+		},
+		{
+			"bout on other event",
+			[]foo.CardBout{
+				{BoutID: uuid.MustParse("00000000-0000-0000-0000-000000000003")},
+			},
+			nil,
+			[]string{"card[0].bout_id"},
+		},
+		{
+			"repeated bout and position",
+			[]foo.CardBout{
+				{BoutID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Position: 1},
+				{BoutID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Position: 1},
+			},
+			nil,
+			[]string{"card[1].bout_id", "card[1].position"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			e := &foo.Event{Name: "Foo Fight Night", Date: date, Card: tt.card}
+			got, err := svc.CreateEvent(context.Background(), e)
+			var gotErr []string
+			var fe xerror.ValidationError
+			if errors.As(err, &fe) {
+				for _, e := range fe {
+					gotErr = append(gotErr, e.Field)
+				}
+			} else if err != nil {
+				t.Fatalf("CreateEvent() unexpected error %s", err)
+			}
+			if !reflect.DeepEqual(gotErr, tt.wantErr) {
+				t.Fatalf("CreateEvent() error fields = %v, want %v", gotErr, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var gotOrder []int
+			for _, c := range got.Card {
+				gotOrder = append(gotOrder, c.Position)
+			}
+			if !reflect.DeepEqual(gotOrder, tt.wantOrder) {
+				t.Errorf("CreateEvent() card positions = %v, want %v", gotOrder, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestService_UpdateEvent_MovedBouts(t *testing.T) {
+	pastID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	sameDayID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	scheduledID := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	past := foo.NewDate(time.Date(2022, 5, 7, 0, 0, 0, 0, time.UTC))
+	eventDate := foo.NewDate(time.Date(2027, 3, 6, 0, 0, 0, 0, time.UTC))
+	bouts := map[uuid.UUID]foo.Bout{
+		pastID:      {RedFighterID: redID, BlueFighterID: blueID, Date: past, Result: foo.BoutResultWin, WeightClass: foo.WeightClassMiddleweight},
+		sameDayID:   {RedFighterID: uuid.New(), BlueFighterID: uuid.New(), Date: eventDate, Result: foo.BoutResultDraw},
+		scheduledID: {RedFighterID: uuid.New(), BlueFighterID: uuid.New(), Date: past},
+	}
+	tests := []struct {
+		name      string
+		card      []uuid.UUID
+		wantErr   []string
+		wantTopic []string
+	}{
+		{"redated bout with result", []uuid.UUID{pastID}, []string{"card[0].bout_id"}, nil},
+		{"bout with result on event date", []uuid.UUID{sameDayID}, nil, nil},
+		{"redated scheduled bout", []uuid.UUID{scheduledID}, nil, []string{foo.TopicNotificationsFanout}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTopics []string
+			repo := &mockFighterRepo{
+				BoutFn: func(ctx context.Context, id uuid.UUID) (*foo.Bout, error) {
+					b := bouts[id]
+					b.ID = id
+					return &b, nil
+				},
+				UpdateEventFn: func(ctx context.Context, e *foo.Event, mm ...foo.Message) error {
+					for _, m := range mm {
+						gotTopics = append(gotTopics, m.Topic)
+					}
+					return nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			e := &foo.Event{Name: "Foo Fight Night", Date: eventDate}
+			for _, id := range tt.card {
+				e.Card = append(e.Card, foo.CardBout{BoutID: id})
+			}
+			_, err := svc.UpdateEvent(context.Background(), uuid.NewString(), e)
+			var gotErr []string
+			var fe xerror.ValidationError
+			if errors.As(err, &fe) {
+				for _, v := range fe {
+					gotErr = append(gotErr, v.Field)
+				}
+			} else if err != nil {
+				t.Fatalf("UpdateEvent() unexpected error %s", err)
+			}
+			if !reflect.DeepEqual(gotErr, tt.wantErr) {
+				t.Errorf("UpdateEvent() error fields = %v, want %v", gotErr, tt.wantErr)
+			}
+			if !reflect.DeepEqual(gotTopics, tt.wantTopic) {
+				t.Errorf("UpdateEvent() published %v, want %v", gotTopics, tt.wantTopic)
+			}
+		})
+	}
+}
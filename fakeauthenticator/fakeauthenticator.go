@@ -17,7 +17,8 @@ func (c *Client) VerifyToken(ctx context.Context, token string) (claims map[stri
 // NewClient creates new instance of firebase client.
 func NewClient(credentialFile string) (*Client, error) {
 	fakeClaims := map[string]interface{}{
-		"user_id": "fake_temporary_id",
+		"user_id":   "fake_temporary_id",
+		"tenant_id": "default",
 	}
 	return &Client{fakeClaims}, nil
 }
@@ -1,14 +1,271 @@
 package foo
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	guuid "github.com/google/uuid"
 	"github.com/kudarap/foo/xerror"
 )
 
-var ErrFighterNotFound = xerror.Error("not_found")
+var (
+	ErrFighterNotFound = xerror.Error(xerror.CodeNotFound)
+	ErrFighterInvalid  = xerror.Error(xerror.CodeInvalid)
+	// ErrFighterConflict is returned on writes with a stale fighter version.
+	ErrFighterConflict = xerror.Error(xerror.CodeConflict)
+)
 
 type Fighter struct {
-	ID        guuid.UUID
-	FirstName string
-	LastName  string
+	ID guuid.UUID `json:"id"`
+	// Slug is the canonical url name of the fighter generated from its name,
+	// previous slugs remain as aliases.
+	Slug        string      `json:"slug"`
+	FirstName   string      `json:"first_name"`
+	LastName    string      `json:"last_name"`
+	Nickname    string      `json:"nickname"`
+	DateOfBirth *Date       `json:"date_of_birth"`
+	Nationality string      `json:"nationality"`
+	Stance      Stance      `json:"stance"`
+	HeightCM    int         `json:"height_cm"`
+	ReachCM     int         `json:"reach_cm"`
+	WeightClass WeightClass `json:"weight_class"`
+	// Record is computed from completed bouts.
+	Record    Record    `json:"record"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt is set when fighter is deleted and kept until purged.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Version increments on every write, writes with a non-zero version only
+	// succeed when it matches the current version.
+	Version int `json:"version"`
+}
+
+// Stance represents fighter fighting stance.
+type Stance string
+
+const (
+	StanceOrthodox Stance = "orthodox"
+	StanceSouthpaw Stance = "southpaw"
+	StanceSwitch   Stance = "switch"
+)
+
+// WeightClass represents a fighter division.
+type WeightClass string
+
+const (
+	WeightClassStrawweight      WeightClass = "strawweight"
+	WeightClassFlyweight        WeightClass = "flyweight"
+	WeightClassBantamweight     WeightClass = "bantamweight"
+	WeightClassFeatherweight    WeightClass = "featherweight"
+	WeightClassLightweight      WeightClass = "lightweight"
+	WeightClassWelterweight     WeightClass = "welterweight"
+	WeightClassMiddleweight     WeightClass = "middleweight"
+	WeightClassLightHeavyweight WeightClass = "light_heavyweight"
+	WeightClassHeavyweight      WeightClass = "heavyweight"
+)
+
+// Valid reports whether weight class is a known division.
+func (w WeightClass) Valid() bool {
+	switch w {
+	case WeightClassStrawweight, WeightClassFlyweight, WeightClassBantamweight,
+		WeightClassFeatherweight, WeightClassLightweight, WeightClassWelterweight,
+		WeightClassMiddleweight, WeightClassLightHeavyweight, WeightClassHeavyweight:
+		return true
+	}
+	return false
+}
+
+// fighter field limits.
+const (
+	maxNameLength = 100
+	minBodyCM     = 100
+	maxBodyCM     = 250
+)
+
+// normalize trims text fields and upper cases nationality code.
+func (f *Fighter) normalize() {
+	f.FirstName = strings.TrimSpace(f.FirstName)
+	f.LastName = strings.TrimSpace(f.LastName)
+	f.Nickname = strings.TrimSpace(f.Nickname)
+	f.Nationality = strings.ToUpper(strings.TrimSpace(f.Nationality))
+}
+
+// Validate checks fighter fields and returns all field errors found.
+func (f Fighter) Validate() error {
+	var fe xerror.ValidationError
+	if f.FirstName == "" {
+		fe = fe.Add("first_name", xerror.ViolationRequired, "is required")
+	} else if utf8.RuneCountInString(f.FirstName) > maxNameLength {
+		fe = fe.Add("first_name", xerror.ViolationTooLong, fmt.Sprintf("must not exceed %d characters", maxNameLength))
+	}
+	if f.LastName == "" {
+		fe = fe.Add("last_name", xerror.ViolationRequired, "is required")
+	} else if utf8.RuneCountInString(f.LastName) > maxNameLength {
+		fe = fe.Add("last_name", xerror.ViolationTooLong, fmt.Sprintf("must not exceed %d characters", maxNameLength))
+	}
+	if utf8.RuneCountInString(f.Nickname) > maxNameLength {
+		fe = fe.Add("nickname", xerror.ViolationTooLong, fmt.Sprintf("must not exceed %d characters", maxNameLength))
+	}
+	if f.DateOfBirth != nil {
+		if f.DateOfBirth.After(time.Now()) {
+			fe = fe.Add("date_of_birth", xerror.ViolationOutOfRange, "must not be in the future")
+		} else if f.DateOfBirth.Year() < 1900 {
+			fe = fe.Add("date_of_birth", xerror.ViolationOutOfRange, "must not be before 1900")
+		}
+	}
+	if f.Nationality != "" {
+		if _, ok := countries[f.Nationality]; !ok {
+			fe = fe.Add("nationality", xerror.ViolationUnsupported, "must be an ISO 3166-1 alpha-2 country code")
+		}
+	}
+	switch f.Stance {
+	case "", StanceOrthodox, StanceSouthpaw, StanceSwitch:
+	default:
+		fe = fe.Add("stance", xerror.ViolationUnsupported, "must be one of orthodox, southpaw or switch")
+	}
+	if f.HeightCM != 0 && (f.HeightCM < minBodyCM || f.HeightCM > maxBodyCM) {
+		fe = fe.Add("height_cm", xerror.ViolationOutOfRange, fmt.Sprintf("must be between %d and %d", minBodyCM, maxBodyCM))
+	}
+	if f.ReachCM != 0 && (f.ReachCM < minBodyCM || f.ReachCM > maxBodyCM) {
+		fe = fe.Add("reach_cm", xerror.ViolationOutOfRange, fmt.Sprintf("must be between %d and %d", minBodyCM, maxBodyCM))
+	}
+	if f.WeightClass != "" && !f.WeightClass.Valid() {
+		fe = fe.Add("weight_class", xerror.ViolationUnsupported, "is not a known weight class")
+	}
+
+	if len(fe) != 0 {
+		return ErrFighterInvalid.X(fe)
+	}
+	return nil
+}
+
+// FighterPurge represents the outcome of a deleted fighters purge.
+type FighterPurge struct {
+	Purged int64
+	// KeptWithBouts counts expired fighters left deleted because they have bouts.
+	KeptWithBouts int64
+	// BlobKeys are blob store keys of media removed with purged fighters.
+	BlobKeys []string
+}
+
+// FighterSearchResult represents a fighter search match ranked by relevance.
+type FighterSearchResult struct {
+	Fighter
+	Rank float64 `json:"rank"`
+	// Snippet is the fighter name with matched words wrapped in <mark> tags.
+	Snippet string `json:"snippet"`
+}
+
+// Fighter list sort fields, prefix with "-" for descending order.
+const (
+	FighterSortLastName  = "last_name"
+	FighterSortFirstName = "first_name"
+	FighterSortCreatedAt = "created_at"
+)
+
+// default and max list limits.
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// FighterQuery represents fighter list options.
+type FighterQuery struct {
+	// Limit is the page size, defaults to 20 and capped to 100.
+	Limit int
+	// Cursor is the opaque position of the next page from a previous result.
+	Cursor string
+	// Sort is one of the fighter sort fields, defaults to last_name.
+	Sort string
+	// Name filters fighters that first or last name starts with it.
+	Name string
+	// Deleted lists deleted fighters instead.
+	Deleted bool
+}
+
+// SortField returns sort field without direction prefix.
+func (q FighterQuery) SortField() string {
+	return strings.TrimPrefix(q.Sort, "-")
+}
+
+// SortDesc reports whether the list sorts on descending order.
+func (q FighterQuery) SortDesc() bool {
+	return strings.HasPrefix(q.Sort, "-")
+}
+
+func (q FighterQuery) setDefaults() FighterQuery {
+	if q.Limit <= 0 {
+		q.Limit = defaultListLimit
+	}
+	if q.Limit > maxListLimit {
+		q.Limit = maxListLimit
+	}
+	if q.Sort == "" {
+		q.Sort = FighterSortLastName
+	}
+	q.Name = strings.TrimSpace(q.Name)
+	return q
+}
+
+func (q FighterQuery) validate() error {
+	switch q.SortField() {
+	case FighterSortLastName, FighterSortFirstName, FighterSortCreatedAt:
+	default:
+		return ErrFighterInvalid.X(xerror.NewValidationError("sort", xerror.ViolationUnsupported,
+			"must be one of last_name, first_name or created_at"))
+	}
+	return nil
+}
+
+// FighterPage represents a page of fighters.
+type FighterPage struct {
+	Data       []Fighter `json:"data"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+// FighterCursor represents keyset position of a fighter on a sorted list.
+type FighterCursor struct {
+	Sort  string     `json:"s"`
+	Value string     `json:"v"`
+	ID    guuid.UUID `json:"id"`
+}
+
+// newFighterCursor creates a cursor positioned after the fighter.
+func newFighterCursor(f Fighter, sort string) FighterCursor {
+	c := FighterCursor{Sort: sort, ID: f.ID}
+	switch strings.TrimPrefix(sort, "-") {
+	case FighterSortFirstName:
+		c.Value = f.FirstName
+	case FighterSortCreatedAt:
+		c.Value = f.CreatedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		c.Value = f.LastName
+	}
+	return c
+}
+
+// Encode returns url safe opaque representation of the cursor.
+func (c FighterCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeFighterCursor parses opaque cursor and checks it matches the list sorting.
+func decodeFighterCursor(s, sort string) (*FighterCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrFighterInvalid.X(xerror.NewValidationError("cursor", xerror.ViolationMalformed, "is malformed"))
+	}
+	var c FighterCursor
+	if err = json.Unmarshal(b, &c); err != nil {
+		return nil, ErrFighterInvalid.X(xerror.NewValidationError("cursor", xerror.ViolationMalformed, "is malformed"))
+	}
+	if c.Sort != sort {
+		return nil, ErrFighterInvalid.X(xerror.NewValidationError("cursor", xerror.ViolationConflict, "does not match sort"))
+	}
+	return &c, nil
 }
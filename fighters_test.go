@@ -1,14 +1,19 @@
 package foo_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
 )
 
 func TestService_FighterByID(t *testing.T) {
@@ -26,7 +31,7 @@ func TestService_FighterByID(t *testing.T) {
 		{
 			"place-holder-test",
 			&mockFighterRepo{
-				FighterFn: func(ctx context.Context, id uuid.UUID) (*foo.Fighter, error) {
+				FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
 					return &foo.Fighter{
 						ID:        uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140"),
 						FirstName: "justine",
@@ -41,6 +46,26 @@ func TestService_FighterByID(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"slug",
+			&mockFighterRepo{
+				FighterBySlugFn: func(ctx context.Context, slug string) (*foo.Fighter, error) {
+					return &foo.Fighter{Slug: slug}, nil
+				}},
+			"Dave-Grohl",
+			&foo.Fighter{Slug: "dave-grohl"},
+			false,
+		},
+		{
+			"unknown slug",
+			&mockFighterRepo{
+				FighterBySlugFn: func(ctx context.Context, slug string) (*foo.Fighter, error) {
+					return nil, foo.ErrFighterNotFound
+				}},
+			"not-a-fighter",
+			nil,
+			true,
+		},
 		// TODO: Add test cases.
 		// TODO: Add negative test cases.
 		// TODO: Add fatal repo test cases.
@@ -48,7 +73,7 @@ func TestService_FighterByID(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
-			svc := foo.NewService(tt.repo, l)
+			svc := foo.NewService(tt.repo, nil, nil, nil, l)
 			ctx := context.Background()
 			got, err := svc.FighterByID(ctx, tt.fighterUUID)
 			if (err != nil) != tt.wantErr {
@@ -62,10 +87,921 @@ func TestService_FighterByID(t *testing.T) {
 	}
 }
 
+func TestService_FighterAsOf(t *testing.T) {
+	id := uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140")
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &mockFighterRepo{
+		FighterBySlugFn: func(ctx context.Context, slug string) (*foo.Fighter, error) {
+			if slug != "dave-grohl" {
+				return nil, foo.ErrFighterNotFound
+			}
+			return &foo.Fighter{ID: id, Slug: slug}, nil
+		},
+		FighterAsOfFn: func(ctx context.Context, fid uuid.UUID, t time.Time) (*foo.Fighter, error) {
+			if !t.Equal(at) {
+				return nil, foo.ErrFighterNotFound
+			}
+			return &foo.Fighter{ID: fid, Slug: "david-grohl", WeightClass: foo.WeightClassStrawweight}, nil
+		},
+	}
+	tests := []struct {
+		name     string
+		ref      string
+		at       time.Time
+		want     *foo.Fighter
+		wantCode string
+	}{
+		{"id", id.String(), at, &foo.Fighter{ID: id, Slug: "david-grohl", WeightClass: foo.WeightClassStrawweight}, ""},
+		{"current slug", "Dave-Grohl", at, &foo.Fighter{ID: id, Slug: "david-grohl", WeightClass: foo.WeightClassStrawweight}, ""},
+		{"unknown slug", "not-a-fighter", at, nil, xerror.CodeNotFound},
+		{"not yet created", id.String(), at.AddDate(-1, 0, 0), nil, xerror.CodeNotFound},
+		{"future", id.String(), time.Now().Add(time.Hour), nil, xerror.CodeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			got, err := svc.FighterAsOf(context.Background(), tt.ref, tt.at)
+			var xerr xerror.XError
+			errors.As(err, &xerr)
+			if (err != nil) != (tt.wantCode != "") || xerr.Code != tt.wantCode {
+				t.Fatalf("FighterAsOf() error = %v, wantCode %q", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FighterAsOf() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_CreateFighter(t *testing.T) {
+	tests := []struct {
+		name string
+		// dependencies
+		repo *mockFighterRepo
+		// params
+		fighter *foo.Fighter
+		// returns
+		want    *foo.Fighter
+		wantErr bool
+	}{
+		{
+			"ok",
+			&mockFighterRepo{
+				CreateFighterFn: func(ctx context.Context, f *foo.Fighter) error {
+					f.ID = uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140")
+					return nil
+				}},
+			&foo.Fighter{FirstName: "justine", LastName: "jimenez"},
+			&foo.Fighter{
+				ID:        uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140"),
+				FirstName: "justine",
+				LastName:  "jimenez",
+			},
+			false,
+		},
+		{
+			"missing last name",
+			&mockFighterRepo{},
+			&foo.Fighter{FirstName: "justine"},
+			nil,
+			true,
+		},
+		{
+			"repo failed",
+			&mockFighterRepo{
+				CreateFighterFn: func(ctx context.Context, f *foo.Fighter) error {
+					return errors.New("connection failed")
+				}},
+			&foo.Fighter{FirstName: "justine", LastName: "jimenez"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(tt.repo, nil, nil, nil, l)
+			ctx := context.Background()
+			got, err := svc.CreateFighter(ctx, tt.fighter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateFighter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateFighter() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_UpdateFighter(t *testing.T) {
+	id := uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140")
+	tests := []struct {
+		name string
+		// dependencies
+		repo *mockFighterRepo
+		// params
+		id      string
+		fighter *foo.Fighter
+		// returns
+		want     *foo.Fighter
+		wantCode string
+	}{
+		{
+			"ok",
+			&mockFighterRepo{
+				UpdateFighterFn: func(ctx context.Context, f *foo.Fighter) error {
+					return nil
+				}},
+			id.String(),
+			&foo.Fighter{FirstName: "justine", LastName: "jimenez"},
+			&foo.Fighter{ID: id, FirstName: "justine", LastName: "jimenez"},
+			"",
+		},
+		{
+			"malformed id",
+			&mockFighterRepo{},
+			"justine",
+			&foo.Fighter{FirstName: "justine", LastName: "jimenez"},
+			nil,
+			xerror.CodeInvalid,
+		},
+		{
+			"missing last name",
+			&mockFighterRepo{},
+			id.String(),
+			&foo.Fighter{FirstName: "justine"},
+			nil,
+			xerror.CodeInvalid,
+		},
+		{
+			"not found",
+			&mockFighterRepo{
+				UpdateFighterFn: func(ctx context.Context, f *foo.Fighter) error {
+					return foo.ErrFighterNotFound
+				}},
+			id.String(),
+			&foo.Fighter{FirstName: "justine", LastName: "jimenez"},
+			nil,
+			xerror.CodeNotFound,
+		},
+		{
+			"stale version",
+			&mockFighterRepo{
+				UpdateFighterFn: func(ctx context.Context, f *foo.Fighter) error {
+					if f.Version != 3 {
+						return foo.ErrFighterConflict
+					}
+					return nil
+				}},
+			id.String(),
+			&foo.Fighter{FirstName: "justine", LastName: "jimenez", Version: 2},
+			nil,
+			xerror.CodeConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(tt.repo, nil, nil, nil, l)
+			ctx := context.Background()
+			got, err := svc.UpdateFighter(ctx, tt.id, tt.fighter)
+			var xerr xerror.XError
+			errors.As(err, &xerr)
+			if (err != nil) != (tt.wantCode != "") || xerr.Code != tt.wantCode {
+				t.Errorf("UpdateFighter() error = %v, wantCode %q", err, tt.wantCode)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UpdateFighter() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_DeleteFighter(t *testing.T) {
+	id := uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140")
+	tests := []struct {
+		name string
+		// dependencies
+		repo *mockFighterRepo
+		// params
+		id      string
+		version int
+		// returns
+		wantCode string
+	}{
+		{
+			"ok",
+			&mockFighterRepo{
+				DeleteFighterFn: func(ctx context.Context, id uuid.UUID, version int) error {
+					return nil
+				}},
+			id.String(),
+			0,
+			"",
+		},
+		{
+			"malformed id",
+			&mockFighterRepo{},
+			"justine",
+			0,
+			xerror.CodeInvalid,
+		},
+		{
+			"not found",
+			&mockFighterRepo{
+				DeleteFighterFn: func(ctx context.Context, id uuid.UUID, version int) error {
+					return foo.ErrFighterNotFound
+				}},
+			id.String(),
+			0,
+			xerror.CodeNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(tt.repo, nil, nil, nil, l)
+			ctx := context.Background()
+			err := svc.DeleteFighter(ctx, tt.id, tt.version)
+			var xerr xerror.XError
+			errors.As(err, &xerr)
+			if (err != nil) != (tt.wantCode != "") || xerr.Code != tt.wantCode {
+				t.Errorf("DeleteFighter() error = %v, wantCode %q", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestService_Fighters(t *testing.T) {
+	f1 := foo.Fighter{ID: uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140"), FirstName: "dave", LastName: "grohl"}
+	f2 := foo.Fighter{ID: uuid.MustParse("0b5e7c2b-4a3f-4f7e-9d4c-1a0c2b7e5d11"), FirstName: "taylor", LastName: "hawkins"}
+	f3 := foo.Fighter{ID: uuid.MustParse("5f0f3c1e-8b7a-4d6c-a2e1-9c8b7a6d5e4f"), FirstName: "nate", LastName: "mendel"}
+	tests := []struct {
+		name string
+		// dependencies
+		repo *mockFighterRepo
+		// params
+		query foo.FighterQuery
+		// returns
+		want    *foo.FighterPage
+		wantErr bool
+	}{
+		{
+			"last page",
+			&mockFighterRepo{
+				FightersFn: func(ctx context.Context, q foo.FighterQuery, after *foo.FighterCursor) ([]foo.Fighter, error) {
+					return []foo.Fighter{f1, f2}, nil
+				}},
+			foo.FighterQuery{Limit: 2},
+			&foo.FighterPage{Data: []foo.Fighter{f1, f2}},
+			false,
+		},
+		{
+			"has next page",
+			&mockFighterRepo{
+				FightersFn: func(ctx context.Context, q foo.FighterQuery, after *foo.FighterCursor) ([]foo.Fighter, error) {
+					return []foo.Fighter{f1, f2, f3}, nil
+				}},
+			foo.FighterQuery{Limit: 2},
+			&foo.FighterPage{
+				Data:       []foo.Fighter{f1, f2},
+				NextCursor: foo.FighterCursor{Sort: foo.FighterSortLastName, Value: "hawkins", ID: f2.ID}.Encode(),
+			},
+			false,
+		},
+		{
+			"empty",
+			&mockFighterRepo{
+				FightersFn: func(ctx context.Context, q foo.FighterQuery, after *foo.FighterCursor) ([]foo.Fighter, error) {
+					return nil, nil
+				}},
+			foo.FighterQuery{},
+			&foo.FighterPage{Data: []foo.Fighter{}},
+			false,
+		},
+		{
+			"invalid sort",
+			&mockFighterRepo{},
+			foo.FighterQuery{Sort: "height"},
+			nil,
+			true,
+		},
+		{
+			"cursor sort mismatch",
+			&mockFighterRepo{},
+			foo.FighterQuery{
+				Sort:   foo.FighterSortCreatedAt,
+				Cursor: foo.FighterCursor{Sort: foo.FighterSortLastName, Value: "hawkins", ID: f2.ID}.Encode(),
+			},
+			nil,
+			true,
+		},
+		{
+			"malformed cursor",
+			&mockFighterRepo{},
+			foo.FighterQuery{Cursor: "!!!"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(tt.repo, nil, nil, nil, l)
+			ctx := context.Background()
+			got, err := svc.Fighters(ctx, tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Fighters() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fighters() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_SearchFighters(t *testing.T) {
+	r1 := foo.FighterSearchResult{
+		Fighter: foo.Fighter{ID: uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140"), FirstName: "dave", LastName: "grohl"},
+		Rank:    0.6,
+		Snippet: "<mark>dave</mark> grohl",
+	}
+	tests := []struct {
+		name string
+		// dependencies
+		results []foo.FighterSearchResult
+		// params
+		q     string
+		limit int
+		// returns
+		wantQ     string
+		wantLimit int
+		want      []foo.FighterSearchResult
+		wantCode  string
+	}{
+		{"match", []foo.FighterSearchResult{r1}, " dave ", 5, "dave", 5, []foo.FighterSearchResult{r1}, ""},
+		{"no match", nil, "kurt", 0, "kurt", 20, []foo.FighterSearchResult{}, ""},
+		{"limit clamped", nil, "kurt", 1000, "kurt", 100, []foo.FighterSearchResult{}, ""},
+		{"blank query", nil, "  ", 5, "", 0, nil, xerror.CodeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQ string
+			var gotLimit int
+			repo := &mockFighterRepo{
+				SearchFightersFn: func(ctx context.Context, q string, limit int) ([]foo.FighterSearchResult, error) {
+					gotQ, gotLimit = q, limit
+					return tt.results, nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			got, err := svc.SearchFighters(context.Background(), tt.q, tt.limit)
+			var xerr xerror.XError
+			errors.As(err, &xerr)
+			if (err != nil) != (tt.wantCode != "") || xerr.Code != tt.wantCode {
+				t.Fatalf("SearchFighters() error = %v, wantCode %q", err, tt.wantCode)
+			}
+			if gotQ != tt.wantQ || gotLimit != tt.wantLimit {
+				t.Errorf("SearchFighters() repo got q = %q limit = %d, want %q %d", gotQ, gotLimit, tt.wantQ, tt.wantLimit)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchFighters() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_RestoreFighter(t *testing.T) {
+	f1 := foo.Fighter{ID: uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140"), FirstName: "dave", LastName: "grohl"}
+	tests := []struct {
+		name string
+		// dependencies
+		repo *mockFighterRepo
+		// params
+		fighterUUID string
+		// returns
+		want    *foo.Fighter
+		wantErr error
+	}{
+		{
+			"restored",
+			&mockFighterRepo{
+				RestoreFighterFn: func(ctx context.Context, id uuid.UUID) (*foo.Fighter, error) {
+					return &f1, nil
+				}},
+			f1.ID.String(),
+			&f1,
+			nil,
+		},
+		{
+			"not deleted",
+			&mockFighterRepo{
+				RestoreFighterFn: func(ctx context.Context, id uuid.UUID) (*foo.Fighter, error) {
+					return nil, foo.ErrFighterNotFound
+				}},
+			f1.ID.String(),
+			nil,
+			foo.ErrFighterNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(tt.repo, nil, nil, nil, l)
+			ctx := context.Background()
+			got, err := svc.RestoreFighter(ctx, tt.fighterUUID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RestoreFighter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RestoreFighter() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_MalformedFighterID(t *testing.T) {
+	l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := foo.NewService(&mockFighterRepo{}, nil, nil, nil, l)
+	_, err := svc.RestoreFighter(context.Background(), "not-a-uuid")
+
+	var ve xerror.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("RestoreFighter() error = %v, want validation error", err)
+	}
+	want := xerror.NewValidationError("id", xerror.ViolationMalformed, "must be a uuid")
+	if !reflect.DeepEqual(ve, want) {
+		t.Errorf("RestoreFighter() violations = %v, want %v", ve, want)
+	}
+}
+
+func TestService_PurgeFighters(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention time.Duration
+		purge     foo.FighterPurge
+		want      time.Duration
+		wantLog   bool
+	}{
+		{"default retention", 0, foo.FighterPurge{Purged: 3}, foo.DefaultFighterRetention, false},
+		{"configured retention", 24 * time.Hour, foo.FighterPurge{Purged: 3}, 24 * time.Hour, false},
+		{"kept with bouts", 0, foo.FighterPurge{Purged: 3, KeptWithBouts: 2}, foo.DefaultFighterRetention, true},
+		{"media blobs", 0, foo.FighterPurge{Purged: 3, BlobKeys: []string{"a.png", "a_thumb.jpg"}}, foo.DefaultFighterRetention, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before time.Time
+			repo := &mockFighterRepo{
+				PurgeFightersFn: func(ctx context.Context, b time.Time) (*foo.FighterPurge, error) {
+					before = b
+					p := tt.purge
+					return &p, nil
+				}}
+			var logs bytes.Buffer
+			l := slog.New(slog.NewTextHandler(&logs, nil))
+			blobs := memBlobStore{"a.png": nil, "a_thumb.jpg": nil, "b.png": nil}
+			svc := foo.NewService(repo, blobs, nil, nil, l)
+			n, err := svc.PurgeFighters(context.Background(), tt.retention)
+			if err != nil || n != 3 {
+				t.Fatalf("PurgeFighters() = %d, %v, want 3", n, err)
+			}
+			if got := time.Since(before); got < tt.want || got > tt.want+time.Minute {
+				t.Errorf("PurgeFighters() purged before %v ago, want %v", got, tt.want)
+			}
+			if got := strings.Contains(logs.String(), "kept from purge"+`" fighters=2`); got != tt.wantLog {
+				t.Errorf("PurgeFighters() logged kept fighters = %v, want %v: %s", got, tt.wantLog, logs.String())
+			}
+			if got, want := len(blobs), 3-len(tt.purge.BlobKeys); got != want {
+				t.Errorf("PurgeFighters() left %d blobs, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestFighter_Validate(t *testing.T) {
+	dob := foo.NewDate(time.Date(1969, 1, 14, 0, 0, 0, 0, time.UTC))
+	future := foo.NewDate(time.Now().AddDate(1, 0, 0))
+	tests := []struct {
+		name    string
+		fighter foo.Fighter
+		want    []string
+	}{
+		{
+			"ok",
+			foo.Fighter{
+				FirstName:   "dave",
+				LastName:    "grohl",
+				Nickname:    "the drummer",
+				DateOfBirth: &dob,
+				Nationality: "US",
+				Stance:      foo.StanceOrthodox,
+				HeightCM:    183,
+				ReachCM:     185,
+				WeightClass: foo.WeightClassMiddleweight,
+			},
+			nil,
+		},
+		{
+			"minimal",
+			foo.Fighter{FirstName: "dave", LastName: "grohl"},
+			nil,
+		},
+		{
+			"multibyte names",
+			foo.Fighter{FirstName: strings.Repeat("Ж", 100), LastName: strings.Repeat("李", 100), Nickname: strings.Repeat("é", 100)},
+			nil,
+		},
+		{
+			"too long",
+			foo.Fighter{FirstName: strings.Repeat("Ж", 101), LastName: "grohl", Nickname: strings.Repeat("a", 101)},
+			[]string{"first_name", "nickname"},
+		},
+		{
+			"all invalid",
+			foo.Fighter{
+				DateOfBirth: &future,
+				Nationality: "XX",
+				Stance:      "crane",
+				HeightCM:    20,
+				ReachCM:     400,
+				WeightClass: "catchweight",
+			},
+			[]string{"first_name", "last_name", "date_of_birth", "nationality", "stance", "height_cm", "reach_cm", "weight_class"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fighter.Validate()
+			var got []string
+			var fe xerror.ValidationError
+			if errors.As(err, &fe) {
+				for _, e := range fe {
+					got = append(got, e.Field)
+				}
+			} else if err != nil {
+				t.Fatalf("Validate() unexpected error type %T: %s", err, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 type mockFighterRepo struct {
-	FighterFn func(ctx context.Context, id uuid.UUID) (*foo.Fighter, error)
+	FighterFn           func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error)
+	FighterBySlugFn     func(ctx context.Context, slug string) (*foo.Fighter, error)
+	FighterAsOfFn       func(ctx context.Context, id uuid.UUID, at time.Time) (*foo.Fighter, error)
+	FightersByIDFn      func(ctx context.Context, ids []uuid.UUID) ([]foo.Fighter, error)
+	FightersFn          func(ctx context.Context, q foo.FighterQuery, after *foo.FighterCursor) ([]foo.Fighter, error)
+	SearchFightersFn    func(ctx context.Context, q string, limit int) ([]foo.FighterSearchResult, error)
+	CreateFighterFn     func(ctx context.Context, f *foo.Fighter) error
+	UpdateFighterFn     func(ctx context.Context, f *foo.Fighter) error
+	DeleteFighterFn     func(ctx context.Context, id uuid.UUID, version int) error
+	RestoreFighterFn    func(ctx context.Context, id uuid.UUID) (*foo.Fighter, error)
+	PurgeFightersFn     func(ctx context.Context, before time.Time) (*foo.FighterPurge, error)
+	FighterHistoryFn    func(ctx context.Context, fighterID uuid.UUID) ([]foo.FighterChange, error)
+	FighterDuplicatesFn func(ctx context.Context, limit int) ([]foo.FighterDuplicate, error)
+	MergeFighterFn      func(ctx context.Context, id, duplicateID uuid.UUID, mm ...foo.Message) (*foo.Fighter, error)
+
+	CreateMediaFn          func(ctx context.Context, m *foo.Media, mm ...foo.Message) error
+	MediaFn                func(ctx context.Context, id uuid.UUID) (*foo.Media, error)
+	FighterMediaFn         func(ctx context.Context, fighterID uuid.UUID) ([]foo.Media, error)
+	UpdateMediaThumbnailFn func(ctx context.Context, id uuid.UUID, key string) error
+
+	CreateFollowFn func(ctx context.Context, userID string, f *foo.Follow) error
+	DeleteFollowFn func(ctx context.Context, userID string, fighterID uuid.UUID) error
+	FollowsFn      func(ctx context.Context, userID string) ([]foo.Follow, error)
+
+	FollowerIDsFn                   func(ctx context.Context, fighterIDs []uuid.UUID) ([]string, error)
+	PublishFn                       func(ctx context.Context, mm ...foo.Message) error
+	NotificationPreferencesFn       func(ctx context.Context, userID string) (*foo.NotificationPreferences, error)
+	UpdateNotificationPreferencesFn func(ctx context.Context, userID string, p *foo.NotificationPreferences) error
+	NotificationsFn                 func(ctx context.Context, userID string, limit int) ([]foo.Notification, error)
+	ReadNotificationFn              func(ctx context.Context, userID string, id uuid.UUID) error
+
+	CreateWebhookFn          func(ctx context.Context, w *foo.Webhook) error
+	WebhookFn                func(ctx context.Context, id uuid.UUID) (*foo.Webhook, error)
+	WebhooksFn               func(ctx context.Context) ([]foo.Webhook, error)
+	WebhooksByEventFn        func(ctx context.Context, event string) ([]foo.Webhook, error)
+	DeleteWebhookFn          func(ctx context.Context, id uuid.UUID) error
+	EnableWebhookFn          func(ctx context.Context, id uuid.UUID) (*foo.Webhook, error)
+	AddWebhookDeliveryFn     func(ctx context.Context, d *foo.WebhookDelivery, disableAfter int) (*foo.Webhook, error)
+	WebhookDeliveriesFn      func(ctx context.Context, webhookID uuid.UUID, limit int) ([]foo.WebhookDelivery, error)
+	PurgeWebhookDeliveriesFn func(ctx context.Context, before time.Time) (int64, error)
+
+	FighterStatsFn        func(ctx context.Context, fighterID uuid.UUID) (*foo.FighterStats, error)
+	UpdateFighterStatsFn  func(ctx context.Context, st *foo.FighterStats) error
+	TeamFn                func(ctx context.Context, id uuid.UUID) (*foo.Team, error)
+	TeamsFn               func(ctx context.Context, q foo.TeamQuery) ([]foo.Team, error)
+	CreateTeamFn          func(ctx context.Context, t *foo.Team) error
+	UpdateTeamFn          func(ctx context.Context, t *foo.Team) error
+	DeleteTeamFn          func(ctx context.Context, id uuid.UUID) error
+	TeamAffiliationsFn    func(ctx context.Context, teamID uuid.UUID) ([]foo.Affiliation, error)
+	FighterAffiliationsFn func(ctx context.Context, fighterID uuid.UUID) ([]foo.Affiliation, error)
+	CreateAffiliationFn   func(ctx context.Context, a *foo.Affiliation) error
+	UpdateAffiliationFn   func(ctx context.Context, a *foo.Affiliation) error
+	DeleteAffiliationFn   func(ctx context.Context, fighterID, id uuid.UUID) error
+
+	BoutFn           func(ctx context.Context, id uuid.UUID) (*foo.Bout, error)
+	FighterBoutsFn   func(ctx context.Context, fighterID uuid.UUID) ([]foo.Bout, error)
+	CompletedBoutsFn func(ctx context.Context, wc foo.WeightClass) ([]foo.Bout, error)
+	CreateBoutFn     func(ctx context.Context, b *foo.Bout, mm ...foo.Message) error
+	UpdateBoutFn     func(ctx context.Context, b *foo.Bout, mm ...foo.Message) error
+	DeleteBoutFn     func(ctx context.Context, id uuid.UUID, mm ...foo.Message) error
+
+	EventFn       func(ctx context.Context, id uuid.UUID) (*foo.Event, error)
+	EventsFn      func(ctx context.Context, q foo.EventQuery) ([]foo.Event, error)
+	CreateEventFn func(ctx context.Context, e *foo.Event, mm ...foo.Message) error
+	UpdateEventFn func(ctx context.Context, e *foo.Event, mm ...foo.Message) error
+	DeleteEventFn func(ctx context.Context, id uuid.UUID) error
+
+	RatingsFn              func(ctx context.Context, wc foo.WeightClass, limit int) ([]foo.Rating, error)
+	FighterRatingHistoryFn func(ctx context.Context, fighterID uuid.UUID) ([]foo.RatingChange, error)
+	ReplaceRatingsFn       func(ctx context.Context, wc foo.WeightClass, rr []foo.Rating, hh []foo.RatingChange) error
+
+	CreateFighterImportFn       func(ctx context.Context, imp *foo.FighterImport, data []byte, mm ...foo.Message) error
+	FighterImportFn             func(ctx context.Context, id uuid.UUID) (*foo.FighterImport, error)
+	FighterImportDataFn         func(ctx context.Context, id uuid.UUID) ([]byte, error)
+	UpdateFighterImportStatusFn func(ctx context.Context, imp *foo.FighterImport) error
+	ImportFightersFn            func(ctx context.Context, importID uuid.UUID, rows []foo.FighterImportRow, errs []foo.ImportRowError, processed int) error
+	ExportFightersFn            func(ctx context.Context, q foo.FighterQuery, fn func(foo.Fighter) error) error
+}
+
+func (m *mockFighterRepo) Fighter(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+	return m.FighterFn(ctx, id, includeDeleted)
+}
+
+func (m *mockFighterRepo) FighterBySlug(ctx context.Context, slug string) (*foo.Fighter, error) {
+	return m.FighterBySlugFn(ctx, slug)
+}
+
+func (m *mockFighterRepo) FighterAsOf(ctx context.Context, id uuid.UUID, at time.Time) (*foo.Fighter, error) {
+	return m.FighterAsOfFn(ctx, id, at)
+}
+
+func (m *mockFighterRepo) CreateFollow(ctx context.Context, userID string, f *foo.Follow) error {
+	return m.CreateFollowFn(ctx, userID, f)
+}
+
+func (m *mockFighterRepo) DeleteFollow(ctx context.Context, userID string, fighterID uuid.UUID) error {
+	return m.DeleteFollowFn(ctx, userID, fighterID)
+}
+
+func (m *mockFighterRepo) Follows(ctx context.Context, userID string) ([]foo.Follow, error) {
+	return m.FollowsFn(ctx, userID)
+}
+
+func (m *mockFighterRepo) FightersByID(ctx context.Context, ids []uuid.UUID) ([]foo.Fighter, error) {
+	return m.FightersByIDFn(ctx, ids)
+}
+
+func (m *mockFighterRepo) FollowerIDs(ctx context.Context, fighterIDs []uuid.UUID) ([]string, error) {
+	return m.FollowerIDsFn(ctx, fighterIDs)
+}
+
+func (m *mockFighterRepo) Publish(ctx context.Context, mm ...foo.Message) error {
+	return m.PublishFn(ctx, mm...)
+}
+
+func (m *mockFighterRepo) NotificationPreferences(ctx context.Context, userID string) (*foo.NotificationPreferences, error) {
+	return m.NotificationPreferencesFn(ctx, userID)
+}
+
+func (m *mockFighterRepo) UpdateNotificationPreferences(ctx context.Context, userID string, p *foo.NotificationPreferences) error {
+	return m.UpdateNotificationPreferencesFn(ctx, userID, p)
+}
+
+func (m *mockFighterRepo) Notifications(ctx context.Context, userID string, limit int) ([]foo.Notification, error) {
+	return m.NotificationsFn(ctx, userID, limit)
+}
+
+func (m *mockFighterRepo) ReadNotification(ctx context.Context, userID string, id uuid.UUID) error {
+	return m.ReadNotificationFn(ctx, userID, id)
+}
+
+func (m *mockFighterRepo) CreateWebhook(ctx context.Context, w *foo.Webhook) error {
+	return m.CreateWebhookFn(ctx, w)
+}
+
+func (m *mockFighterRepo) Webhook(ctx context.Context, id uuid.UUID) (*foo.Webhook, error) {
+	return m.WebhookFn(ctx, id)
+}
+
+func (m *mockFighterRepo) Webhooks(ctx context.Context) ([]foo.Webhook, error) {
+	return m.WebhooksFn(ctx)
+}
+
+func (m *mockFighterRepo) WebhooksByEvent(ctx context.Context, event string) ([]foo.Webhook, error) {
+	return m.WebhooksByEventFn(ctx, event)
+}
+
+func (m *mockFighterRepo) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	return m.DeleteWebhookFn(ctx, id)
+}
+
+func (m *mockFighterRepo) EnableWebhook(ctx context.Context, id uuid.UUID) (*foo.Webhook, error) {
+	return m.EnableWebhookFn(ctx, id)
+}
+
+func (m *mockFighterRepo) AddWebhookDelivery(ctx context.Context, d *foo.WebhookDelivery, disableAfter int) (*foo.Webhook, error) {
+	return m.AddWebhookDeliveryFn(ctx, d, disableAfter)
+}
+
+func (m *mockFighterRepo) WebhookDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]foo.WebhookDelivery, error) {
+	return m.WebhookDeliveriesFn(ctx, webhookID, limit)
+}
+
+func (m *mockFighterRepo) PurgeWebhookDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	return m.PurgeWebhookDeliveriesFn(ctx, before)
+}
+
+func (m *mockFighterRepo) Fighters(ctx context.Context, q foo.FighterQuery, after *foo.FighterCursor) ([]foo.Fighter, error) {
+	return m.FightersFn(ctx, q, after)
+}
+
+func (m *mockFighterRepo) SearchFighters(ctx context.Context, q string, limit int) ([]foo.FighterSearchResult, error) {
+	return m.SearchFightersFn(ctx, q, limit)
+}
+
+func (m *mockFighterRepo) CreateFighter(ctx context.Context, f *foo.Fighter) error {
+	return m.CreateFighterFn(ctx, f)
+}
+
+func (m *mockFighterRepo) UpdateFighter(ctx context.Context, f *foo.Fighter) error {
+	return m.UpdateFighterFn(ctx, f)
+}
+
+func (m *mockFighterRepo) DeleteFighter(ctx context.Context, id uuid.UUID, version int) error {
+	return m.DeleteFighterFn(ctx, id, version)
+}
+
+func (m *mockFighterRepo) RestoreFighter(ctx context.Context, id uuid.UUID) (*foo.Fighter, error) {
+	return m.RestoreFighterFn(ctx, id)
+}
+
+func (m *mockFighterRepo) FighterHistory(ctx context.Context, fighterID uuid.UUID) ([]foo.FighterChange, error) {
+	return m.FighterHistoryFn(ctx, fighterID)
+}
+
+func (m *mockFighterRepo) FighterDuplicates(ctx context.Context, limit int) ([]foo.FighterDuplicate, error) {
+	return m.FighterDuplicatesFn(ctx, limit)
+}
+
+func (m *mockFighterRepo) MergeFighter(ctx context.Context, id, duplicateID uuid.UUID, mm ...foo.Message) (*foo.Fighter, error) {
+	return m.MergeFighterFn(ctx, id, duplicateID, mm...)
+}
+
+func (m *mockFighterRepo) CreateMedia(ctx context.Context, media *foo.Media, mm ...foo.Message) error {
+	return m.CreateMediaFn(ctx, media, mm...)
+}
+
+func (m *mockFighterRepo) Media(ctx context.Context, id uuid.UUID) (*foo.Media, error) {
+	return m.MediaFn(ctx, id)
+}
+
+func (m *mockFighterRepo) FighterMedia(ctx context.Context, fighterID uuid.UUID) ([]foo.Media, error) {
+	return m.FighterMediaFn(ctx, fighterID)
+}
+
+func (m *mockFighterRepo) UpdateMediaThumbnail(ctx context.Context, id uuid.UUID, key string) error {
+	return m.UpdateMediaThumbnailFn(ctx, id, key)
+}
+
+func (m *mockFighterRepo) PurgeFighters(ctx context.Context, before time.Time) (*foo.FighterPurge, error) {
+	return m.PurgeFightersFn(ctx, before)
+}
+
+func (m *mockFighterRepo) Bout(ctx context.Context, id uuid.UUID) (*foo.Bout, error) {
+	return m.BoutFn(ctx, id)
+}
+
+func (m *mockFighterRepo) FighterBouts(ctx context.Context, fighterID uuid.UUID) ([]foo.Bout, error) {
+	return m.FighterBoutsFn(ctx, fighterID)
+}
+
+func (m *mockFighterRepo) CompletedBouts(ctx context.Context, wc foo.WeightClass) ([]foo.Bout, error) {
+	return m.CompletedBoutsFn(ctx, wc)
+}
+
+func (m *mockFighterRepo) CreateBout(ctx context.Context, b *foo.Bout, mm ...foo.Message) error {
+	return m.CreateBoutFn(ctx, b, mm...)
+}
+
+func (m *mockFighterRepo) UpdateBout(ctx context.Context, b *foo.Bout, mm ...foo.Message) error {
+	return m.UpdateBoutFn(ctx, b, mm...)
+}
+
+func (m *mockFighterRepo) DeleteBout(ctx context.Context, id uuid.UUID, mm ...foo.Message) error {
+	return m.DeleteBoutFn(ctx, id, mm...)
+}
+
+func (m *mockFighterRepo) Event(ctx context.Context, id uuid.UUID) (*foo.Event, error) {
+	return m.EventFn(ctx, id)
+}
+
+func (m *mockFighterRepo) Events(ctx context.Context, q foo.EventQuery) ([]foo.Event, error) {
+	return m.EventsFn(ctx, q)
+}
+
+func (m *mockFighterRepo) CreateEvent(ctx context.Context, e *foo.Event, mm ...foo.Message) error {
+	return m.CreateEventFn(ctx, e, mm...)
+}
+
+func (m *mockFighterRepo) UpdateEvent(ctx context.Context, e *foo.Event, mm ...foo.Message) error {
+	return m.UpdateEventFn(ctx, e, mm...)
+}
+
+func (m *mockFighterRepo) DeleteEvent(ctx context.Context, id uuid.UUID) error {
+	return m.DeleteEventFn(ctx, id)
+}
+
+func (m *mockFighterRepo) Ratings(ctx context.Context, wc foo.WeightClass, limit int) ([]foo.Rating, error) {
+	return m.RatingsFn(ctx, wc, limit)
+}
+
+func (m *mockFighterRepo) FighterRatingHistory(ctx context.Context, fighterID uuid.UUID) ([]foo.RatingChange, error) {
+	return m.FighterRatingHistoryFn(ctx, fighterID)
+}
+
+func (m *mockFighterRepo) ReplaceRatings(ctx context.Context, wc foo.WeightClass, rr []foo.Rating, hh []foo.RatingChange) error {
+	return m.ReplaceRatingsFn(ctx, wc, rr, hh)
+}
+
+func (m *mockFighterRepo) CreateFighterImport(ctx context.Context, imp *foo.FighterImport, data []byte, mm ...foo.Message) error {
+	return m.CreateFighterImportFn(ctx, imp, data, mm...)
+}
+
+func (m *mockFighterRepo) FighterImport(ctx context.Context, id uuid.UUID) (*foo.FighterImport, error) {
+	return m.FighterImportFn(ctx, id)
+}
+
+func (m *mockFighterRepo) FighterImportData(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	return m.FighterImportDataFn(ctx, id)
+}
+
+func (m *mockFighterRepo) UpdateFighterImportStatus(ctx context.Context, imp *foo.FighterImport) error {
+	return m.UpdateFighterImportStatusFn(ctx, imp)
+}
+
+func (m *mockFighterRepo) ImportFighters(ctx context.Context, importID uuid.UUID, rows []foo.FighterImportRow, errs []foo.ImportRowError, processed int) error {
+	return m.ImportFightersFn(ctx, importID, rows, errs, processed)
+}
+
+func (m *mockFighterRepo) ExportFighters(ctx context.Context, q foo.FighterQuery, fn func(foo.Fighter) error) error {
+	return m.ExportFightersFn(ctx, q, fn)
+}
+
+func (m *mockFighterRepo) FighterStats(ctx context.Context, fighterID uuid.UUID) (*foo.FighterStats, error) {
+	return m.FighterStatsFn(ctx, fighterID)
+}
+
+func (m *mockFighterRepo) UpdateFighterStats(ctx context.Context, st *foo.FighterStats) error {
+	return m.UpdateFighterStatsFn(ctx, st)
+}
+
+func (m *mockFighterRepo) Team(ctx context.Context, id uuid.UUID) (*foo.Team, error) {
+	return m.TeamFn(ctx, id)
+}
+
+func (m *mockFighterRepo) Teams(ctx context.Context, q foo.TeamQuery) ([]foo.Team, error) {
+	return m.TeamsFn(ctx, q)
+}
+
+func (m *mockFighterRepo) CreateTeam(ctx context.Context, t *foo.Team) error {
+	return m.CreateTeamFn(ctx, t)
+}
+
+func (m *mockFighterRepo) UpdateTeam(ctx context.Context, t *foo.Team) error {
+	return m.UpdateTeamFn(ctx, t)
+}
+
+func (m *mockFighterRepo) DeleteTeam(ctx context.Context, id uuid.UUID) error {
+	return m.DeleteTeamFn(ctx, id)
+}
+
+func (m *mockFighterRepo) TeamAffiliations(ctx context.Context, teamID uuid.UUID) ([]foo.Affiliation, error) {
+	return m.TeamAffiliationsFn(ctx, teamID)
+}
+
+func (m *mockFighterRepo) FighterAffiliations(ctx context.Context, fighterID uuid.UUID) ([]foo.Affiliation, error) {
+	return m.FighterAffiliationsFn(ctx, fighterID)
+}
+
+func (m *mockFighterRepo) CreateAffiliation(ctx context.Context, a *foo.Affiliation) error {
+	return m.CreateAffiliationFn(ctx, a)
+}
+
+func (m *mockFighterRepo) UpdateAffiliation(ctx context.Context, a *foo.Affiliation) error {
+	return m.UpdateAffiliationFn(ctx, a)
 }
 
-func (m *mockFighterRepo) Fighter(ctx context.Context, id uuid.UUID) (*foo.Fighter, error) {
-	return m.FighterFn(ctx, id)
+func (m *mockFighterRepo) DeleteAffiliation(ctx context.Context, fighterID, id uuid.UUID) error {
+	return m.DeleteAffiliationFn(ctx, fighterID, id)
 }
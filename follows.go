@@ -0,0 +1,15 @@
+package foo
+
+import (
+	"time"
+
+	"github.com/kudarap/foo/xerror"
+)
+
+var ErrFollowInvalid = xerror.Error(xerror.CodeInvalid)
+
+// Follow represents a fighter on a user watchlist.
+type Follow struct {
+	Fighter   Fighter   `json:"fighter"`
+	CreatedAt time.Time `json:"created_at"`
+}
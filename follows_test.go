@@ -0,0 +1,101 @@
+package foo_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
+)
+
+func TestService_FollowFighter(t *testing.T) {
+	followedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		userID   string
+		fighter  string
+		wantCode string
+	}{
+		{"followed", "u1", redID.String(), ""},
+		{"anonymous", "", redID.String(), xerror.CodeInvalid},
+		{"unknown fighter", "u1", blueID.String(), xerror.CodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUserID string
+			repo := &mockFighterRepo{
+				FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+					if id != redID {
+						return nil, foo.ErrFighterNotFound
+					}
+					return &foo.Fighter{ID: id}, nil
+				},
+				CreateFollowFn: func(ctx context.Context, userID string, f *foo.Follow) error {
+					gotUserID = userID
+					f.CreatedAt = followedAt
+					return nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			ctx := foo.ContextWithActor(context.Background(), foo.Actor{UserID: tt.userID})
+			got, err := svc.FollowFighter(ctx, tt.fighter)
+			var xerr xerror.XError
+			errors.As(err, &xerr)
+			if (err != nil) != (tt.wantCode != "") || xerr.Code != tt.wantCode {
+				t.Fatalf("FollowFighter() error = %v, wantCode %q", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if gotUserID != tt.userID || got.Fighter.ID != redID || !got.CreatedAt.Equal(followedAt) {
+				t.Errorf("FollowFighter() got = %+v by %q", got, gotUserID)
+			}
+		})
+	}
+}
+
+func TestService_UnfollowFighter(t *testing.T) {
+	mergedID := uuid.MustParse("5f0c1e44-8a9d-4c1b-9e35-2d7c6b8a1f20")
+	var deleted []uuid.UUID
+	repo := &mockFighterRepo{
+		FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+			if id == mergedID {
+				return &foo.Fighter{ID: redID}, nil
+			}
+			return &foo.Fighter{ID: id}, nil
+		},
+		FighterBySlugFn: func(ctx context.Context, slug string) (*foo.Fighter, error) {
+			if slug != "dave-grohl" {
+				return nil, foo.ErrFighterNotFound
+			}
+			return &foo.Fighter{ID: redID, Slug: slug}, nil
+		},
+		DeleteFollowFn: func(ctx context.Context, userID string, fighterID uuid.UUID) error {
+			deleted = append(deleted, fighterID)
+			return nil
+		},
+	}
+	l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := foo.NewService(repo, nil, nil, nil, l)
+	ctx := foo.ContextWithActor(context.Background(), foo.Actor{UserID: "u1"})
+
+	for _, ref := range []string{redID.String(), "dave-grohl", mergedID.String()} {
+		if err := svc.UnfollowFighter(ctx, ref); err != nil {
+			t.Fatalf("UnfollowFighter(%s) unexpected error %s", ref, err)
+		}
+	}
+	if err := svc.UnfollowFighter(ctx, "taylor-hawkins"); !errors.Is(err, foo.ErrFighterNotFound) {
+		t.Fatalf("UnfollowFighter() error = %v, want %v", err, foo.ErrFighterNotFound)
+	}
+	want := []uuid.UUID{redID, redID, redID}
+	if !reflect.DeepEqual(deleted, want) {
+		t.Errorf("UnfollowFighter() deleted = %v, want %v", deleted, want)
+	}
+}
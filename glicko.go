@@ -0,0 +1,114 @@
+package foo
+
+import "math"
+
+// Glicko-2 system constants, see http://www.glicko.net/glicko/glicko2.pdf
+const (
+	glickoDefaultRating     = 1500.0
+	glickoDefaultDeviation  = 350.0
+	glickoDefaultVolatility = 0.06
+	// glickoTau constrains volatility change over time.
+	glickoTau = 0.5
+	// glickoScale converts ratings between Glicko and Glicko-2 scale.
+	glickoScale   = 173.7178
+	glickoEpsilon = 0.000001
+)
+
+// glickoRating represents a Glicko-2 rating on the Glicko scale.
+type glickoRating struct {
+	rating     float64
+	deviation  float64
+	volatility float64
+}
+
+func newGlickoRating() glickoRating {
+	return glickoRating{glickoDefaultRating, glickoDefaultDeviation, glickoDefaultVolatility}
+}
+
+// glickoResult represents a game result against an opponent within a rating period.
+type glickoResult struct {
+	opponent glickoRating
+	// score is 1 for a win, 0.5 for a draw and 0 for a loss.
+	score float64
+}
+
+// update returns the new rating after a rating period of results. Opponent ratings
+// must be their ratings before the rating period.
+func (g glickoRating) update(results []glickoResult) glickoRating {
+	mu := (g.rating - glickoDefaultRating) / glickoScale
+	phi := g.deviation / glickoScale
+	sigma := g.volatility
+
+	// Player that did not compete only increases deviation, up to the deviation
+	// of an unrated player.
+	if len(results) == 0 {
+		phi = math.Sqrt(phi*phi + sigma*sigma)
+		return glickoRating{g.rating, math.Min(phi*glickoScale, glickoDefaultDeviation), sigma}
+	}
+
+	var vInv, deltaSum float64
+	for _, r := range results {
+		muj := (r.opponent.rating - glickoDefaultRating) / glickoScale
+		phij := r.opponent.deviation / glickoScale
+		gj := glickoG(phij)
+		e := glickoE(mu, muj, phij)
+		vInv += gj * gj * e * (1 - e)
+		deltaSum += gj * (r.score - e)
+	}
+	v := 1 / vInv
+	delta := v * deltaSum
+
+	sigma = glickoVolatility(delta, phi, v, sigma)
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	mu = mu + phi*phi*deltaSum
+
+	return glickoRating{
+		rating:     mu*glickoScale + glickoDefaultRating,
+		deviation:  phi * glickoScale,
+		volatility: sigma,
+	}
+}
+
+func glickoG(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+func glickoE(mu, muj, phij float64) float64 {
+	return 1 / (1 + math.Exp(-glickoG(phij)*(mu-muj)))
+}
+
+// glickoVolatility computes new volatility using the Illinois algorithm.
+func glickoVolatility(delta, phi, v, sigma float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(glickoTau*glickoTau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*glickoTau) < 0 {
+			k++
+		}
+		B = a - k*glickoTau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > glickoEpsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA = fA / 2
+		}
+		B, fB = C, fC
+	}
+	return math.Exp(A / 2)
+}
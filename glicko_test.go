@@ -0,0 +1,101 @@
+package foo
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestGlickoRating_Update(t *testing.T) {
+	// Example from Glickman's Glicko-2 paper.
+	player := glickoRating{1500, 200, 0.06}
+	got := player.update([]glickoResult{
+		{glickoRating{1400, 30, 0.06}, 1},
+		{glickoRating{1550, 100, 0.06}, 0},
+		{glickoRating{1700, 300, 0.06}, 0},
+	})
+	want := glickoRating{1464.06, 151.52, 0.05999}
+	if math.Abs(got.rating-want.rating) > 0.01 ||
+		math.Abs(got.deviation-want.deviation) > 0.01 ||
+		math.Abs(got.volatility-want.volatility) > 0.00001 {
+		t.Errorf("update() = %+v, want %+v", got, want)
+	}
+}
+
+func TestComputeRatings(t *testing.T) {
+	a := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	b := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	c := uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	day := func(d int) Date { return NewDate(time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)) }
+	bouts := []Bout{
+		{ID: uuid.New(), RedFighterID: a, BlueFighterID: b, WeightClass: WeightClassLightweight, Date: day(1), Result: BoutResultWin},
+		{ID: uuid.New(), RedFighterID: c, BlueFighterID: b, WeightClass: WeightClassLightweight, Date: day(2), Result: BoutResultLoss},
+		{ID: uuid.New(), RedFighterID: a, BlueFighterID: c, WeightClass: WeightClassLightweight, Date: day(3), Result: BoutResultNoContest},
+		{ID: uuid.New(), RedFighterID: a, BlueFighterID: c, WeightClass: WeightClassHeavyweight, Date: day(4), Result: BoutResultWin},
+		{ID: uuid.New(), RedFighterID: a, BlueFighterID: c, WeightClass: WeightClassLightweight, Date: day(5)},
+	}
+
+	ratings, history := computeRatings(WeightClassLightweight, bouts)
+	var order []uuid.UUID
+	for _, r := range ratings {
+		order = append(order, r.FighterID)
+	}
+	if want := []uuid.UUID{a, b, c}; !reflect.DeepEqual(order, want) {
+		t.Errorf("computeRatings() rank order = %v, want %v", order, want)
+	}
+	if len(history) != 4 {
+		t.Errorf("computeRatings() history = %d entries, want 4", len(history))
+	}
+	if ratings[1].Bouts != 2 {
+		t.Errorf("computeRatings() bouts = %d, want 2", ratings[1].Bouts)
+	}
+
+	// Replays the same regardless of input order.
+	reversed := make([]Bout, len(bouts))
+	for i, bt := range bouts {
+		reversed[len(bouts)-1-i] = bt
+	}
+	ratings2, history2 := computeRatings(WeightClassLightweight, reversed)
+	if !reflect.DeepEqual(ratings, ratings2) || !reflect.DeepEqual(history, history2) {
+		t.Errorf("computeRatings() is not deterministic")
+	}
+}
+
+func TestComputeRatings_Inactive(t *testing.T) {
+	a := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	b := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	c := uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	d := uuid.MustParse("00000000-0000-0000-0000-00000000000d")
+	day := func(n int) Date { return NewDate(time.Date(2024, 1, n, 0, 0, 0, 0, time.UTC)) }
+	bouts := []Bout{
+		{ID: uuid.New(), RedFighterID: a, BlueFighterID: b, WeightClass: WeightClassLightweight, Date: day(1), Result: BoutResultWin},
+	}
+	for n := 2; n <= 6; n++ {
+		bouts = append(bouts, Bout{ID: uuid.New(), RedFighterID: c, BlueFighterID: d, WeightClass: WeightClassLightweight, Date: day(n), Result: BoutResultDraw})
+	}
+
+	ratings, history := computeRatings(WeightClassLightweight, bouts)
+	var after RatingChange
+	for _, h := range history {
+		if h.FighterID == a {
+			after = h
+		}
+	}
+	for _, r := range ratings {
+		if r.FighterID != a {
+			continue
+		}
+		if r.Deviation <= after.Deviation || r.Deviation > glickoDefaultDeviation {
+			t.Errorf("computeRatings() inactive deviation = %.2f, want above %.2f", r.Deviation, after.Deviation)
+		}
+		if r.Rating != after.Rating || r.Bouts != 1 || !r.LastBout.Equal(day(1).Time) {
+			t.Errorf("computeRatings() inactive rating = %+v, want rating and bouts kept", r)
+		}
+	}
+	if len(history) != 12 {
+		t.Errorf("computeRatings() history = %d entries, want 12", len(history))
+	}
+}
@@ -3,6 +3,7 @@ module github.com/kudarap/foo
 go 1.21
 
 require (
+	cloud.google.com/go/storage v1.30.1
 	firebase.google.com/go/v4 v4.12.0
 	github.com/dbdoyc/opentelemetry-logs-go v0.0.1
 	github.com/golang-migrate/migrate/v4 v4.16.2
@@ -18,6 +19,7 @@ require (
 	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.17.0
 	go.opentelemetry.io/otel/sdk v1.17.0
 	go.opentelemetry.io/otel/trace v1.17.0
+	golang.org/x/text v0.12.0
 	google.golang.org/api v0.136.0
 	google.golang.org/grpc v1.57.0
 )
@@ -29,7 +31,6 @@ require (
 	cloud.google.com/go/firestore v1.11.0 // indirect
 	cloud.google.com/go/iam v1.1.1 // indirect
 	cloud.google.com/go/longrunning v0.5.1 // indirect
-	cloud.google.com/go/storage v1.30.1 // indirect
 	github.com/MicahParks/keyfunc v1.9.0 // indirect
 	github.com/cenkalti/backoff/v4 v4.2.1 // indirect
 	github.com/felixge/httpsnoop v1.0.3 // indirect
@@ -68,7 +69,6 @@ require (
 	golang.org/x/oauth2 v0.11.0 // indirect
 	golang.org/x/sync v0.3.0 // indirect
 	golang.org/x/sys v0.11.0 // indirect
-	golang.org/x/text v0.12.0 // indirect
 	golang.org/x/time v0.3.0 // indirect
 	golang.org/x/xerrors v0.0.0-20220907171357-04be3eba64a2 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
@@ -0,0 +1,82 @@
+package foo
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FighterAction represents a kind of fighter change.
+type FighterAction string
+
+const (
+	FighterActionCreate  FighterAction = "create"
+	FighterActionUpdate  FighterAction = "update"
+	FighterActionDelete  FighterAction = "delete"
+	FighterActionRestore FighterAction = "restore"
+	FighterActionMerge   FighterAction = "merge"
+)
+
+// FighterChange represents an entry of fighter change history.
+type FighterChange struct {
+	ID        int64         `json:"id"`
+	FighterID uuid.UUID     `json:"fighter_id"`
+	Action    FighterAction `json:"action"`
+	// Diff holds changed fields keyed by their json name.
+	Diff      map[string]FieldChange `json:"diff"`
+	UserID    string                 `json:"user_id"`
+	RequestID string                 `json:"request_id"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// FieldChange represents a field value before and after a change.
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// fighterDiffIgnored lists fighter fields that changes on every write or
+// computed and left out of the diff.
+var fighterDiffIgnored = map[string]bool{
+	"record":     true,
+	"updated_at": true,
+	"version":    true,
+}
+
+// NewFighterChange returns a change entry of a fighter write made by the actor,
+// before is nil on create.
+func NewFighterChange(action FighterAction, before, after *Fighter, a Actor) FighterChange {
+	prev, next := fighterFields(before), fighterFields(after)
+	diff := map[string]FieldChange{}
+	for k, v := range next {
+		if fighterDiffIgnored[k] || reflect.DeepEqual(prev[k], v) {
+			continue
+		}
+		diff[k] = FieldChange{Before: prev[k], After: v}
+	}
+	for k, v := range prev {
+		if _, ok := next[k]; !ok && !fighterDiffIgnored[k] {
+			diff[k] = FieldChange{Before: v}
+		}
+	}
+	return FighterChange{
+		FighterID: after.ID,
+		Action:    action,
+		Diff:      diff,
+		UserID:    a.UserID,
+		RequestID: a.RequestID,
+	}
+}
+
+// fighterFields returns fighter json fields.
+func fighterFields(f *Fighter) map[string]interface{} {
+	m := map[string]interface{}{}
+	if f == nil {
+		return m
+	}
+	b, _ := json.Marshal(f)
+	_ = json.Unmarshal(b, &m)
+	return m
+}
@@ -0,0 +1,113 @@
+package foo_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+)
+
+func TestNewFighterChange(t *testing.T) {
+	id := uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140")
+	actor := foo.Actor{UserID: "user-1", RequestID: "req-1"}
+	before := &foo.Fighter{ID: id, FirstName: "dave", LastName: "grohl", Version: 1}
+	after := &foo.Fighter{ID: id, FirstName: "david", LastName: "grohl", Nickname: "the drummer",
+		Version: 2, UpdatedAt: time.Now(), Record: foo.Record{Wins: 1}}
+
+	tests := []struct {
+		name   string
+		action foo.FighterAction
+		before *foo.Fighter
+		after  *foo.Fighter
+		want   map[string]foo.FieldChange
+	}{
+		{
+			"update",
+			foo.FighterActionUpdate,
+			before,
+			after,
+			map[string]foo.FieldChange{
+				"first_name": {Before: "dave", After: "david"},
+				"nickname":   {Before: "", After: "the drummer"},
+			},
+		},
+		{
+			"no changes",
+			foo.FighterActionUpdate,
+			before,
+			before,
+			map[string]foo.FieldChange{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := foo.NewFighterChange(tt.action, tt.before, tt.after, actor)
+			if !reflect.DeepEqual(got.Diff, tt.want) {
+				t.Errorf("NewFighterChange() diff = %v, want %v", got.Diff, tt.want)
+			}
+			if got.FighterID != id || got.Action != tt.action || got.UserID != "user-1" || got.RequestID != "req-1" {
+				t.Errorf("NewFighterChange() = %+v", got)
+			}
+		})
+	}
+
+	created := foo.NewFighterChange(foo.FighterActionCreate, nil, before, actor)
+	if ch := created.Diff["first_name"]; ch.Before != nil || ch.After != "dave" {
+		t.Errorf("NewFighterChange() create first_name = %+v", ch)
+	}
+}
+
+func TestService_FighterHistory(t *testing.T) {
+	change := foo.FighterChange{ID: 1, FighterID: redID, Action: foo.FighterActionCreate}
+	repo := &mockFighterRepo{
+		FighterBySlugFn: func(ctx context.Context, slug string) (*foo.Fighter, error) {
+			if slug != "dave-grohl" {
+				return nil, foo.ErrFighterNotFound
+			}
+			return &foo.Fighter{ID: redID, Slug: slug}, nil
+		},
+		FighterHistoryFn: func(ctx context.Context, fighterID uuid.UUID) ([]foo.FighterChange, error) {
+			if fighterID != redID {
+				return nil, nil
+			}
+			return []foo.FighterChange{change}, nil
+		},
+		FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+			if id != blueID || !includeDeleted {
+				return nil, foo.ErrFighterNotFound
+			}
+			return &foo.Fighter{ID: id}, nil
+		},
+	}
+	tests := []struct {
+		name    string
+		ref     string
+		want    []foo.FighterChange
+		wantErr error
+	}{
+		{"id", redID.String(), []foo.FighterChange{change}, nil},
+		{"slug", "Dave-Grohl", []foo.FighterChange{change}, nil},
+		{"deleted fighter without history", blueID.String(), []foo.FighterChange{}, nil},
+		{"unknown slug", "taylor-hawkins", nil, foo.ErrFighterNotFound},
+		{"unknown id", uuid.NewString(), nil, foo.ErrFighterNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			got, err := svc.FighterHistory(context.Background(), tt.ref)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FighterHistory() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FighterHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package foo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+var (
+	ErrFighterImportNotFound = xerror.Error(xerror.CodeNotFound)
+	ErrFighterImportInvalid  = xerror.Error(xerror.CodeInvalid)
+)
+
+// FighterImportFormat represents a fighter import file format.
+type FighterImportFormat string
+
+const (
+	FighterImportCSV   FighterImportFormat = "csv"
+	FighterImportJSONL FighterImportFormat = "jsonl"
+)
+
+// FighterImportStatus represents fighter import progress state.
+type FighterImportStatus string
+
+const (
+	FighterImportPending    FighterImportStatus = "pending"
+	FighterImportProcessing FighterImportStatus = "processing"
+	FighterImportDone       FighterImportStatus = "done"
+	FighterImportFailed     FighterImportStatus = "failed"
+)
+
+// MaxFighterImportSize is the max size of fighter import file in bytes.
+const MaxFighterImportSize = 32 << 20
+
+// fighterImportBatchSize is the number of rows upserted per transaction.
+const fighterImportBatchSize = 100
+
+// FighterImport represents a bulk fighter import processed by the worker.
+type FighterImport struct {
+	ID     uuid.UUID           `json:"id"`
+	Format FighterImportFormat `json:"format"`
+	Status FighterImportStatus `json:"status"`
+	// Message describes why the import failed.
+	Message       string           `json:"message,omitempty"`
+	TotalRows     int              `json:"total_rows"`
+	ProcessedRows int              `json:"processed_rows"`
+	CreatedRows   int              `json:"created_rows"`
+	UpdatedRows   int              `json:"updated_rows"`
+	FailedRows    int              `json:"failed_rows"`
+	Errors        []ImportRowError `json:"errors"`
+	UserID        string           `json:"user_id"`
+	RequestID     string           `json:"request_id"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// ImportRowError represents why an import row was not imported. Row is the
+// line number on the import file.
+type ImportRowError struct {
+	Row     int                    `json:"row"`
+	Fields  xerror.ValidationError `json:"fields,omitempty"`
+	Message string                 `json:"message,omitempty"`
+}
+
+// FighterImportRow represents a valid fighter row to be upserted, fighter
+// with id updates the existing fighter or creates it with the id.
+type FighterImportRow struct {
+	Row     int
+	Fighter Fighter
+}
+
+// importRow represents a parsed import row and its decode error.
+type importRow struct {
+	row     int
+	fighter Fighter
+	err     error
+}
+
+// parseFighterImport decodes fighter rows of an import file.
+func parseFighterImport(format FighterImportFormat, data []byte) ([]importRow, error) {
+	switch format {
+	case FighterImportCSV:
+		return parseFighterCSV(data)
+	case FighterImportJSONL:
+		return parseFighterJSONL(data)
+	}
+	return nil, fmt.Errorf("unsupported import format: %s", format)
+}
+
+// fighterCSVColumns lists known fighter import csv header columns.
+var fighterCSVColumns = map[string]bool{
+	"id": true, "first_name": true, "last_name": true, "nickname": true, "date_of_birth": true,
+	"nationality": true, "stance": true, "height_cm": true, "reach_cm": true, "weight_class": true,
+}
+
+func parseFighterCSV(data []byte) ([]importRow, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read csv header: %s", err)
+	}
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		if !fighterCSVColumns[h] {
+			return nil, fmt.Errorf("unknown csv column: %s", h)
+		}
+		header[i] = h
+	}
+
+	var rows []importRow
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var pe *csv.ParseError
+			if !errors.As(err, &pe) {
+				return nil, err
+			}
+			rows = append(rows, importRow{row: pe.StartLine, err: err})
+			continue
+		}
+		line, _ := r.FieldPos(0)
+		if len(rec) != len(header) {
+			rows = append(rows, importRow{row: line,
+				err: fmt.Errorf("expected %d columns, got %d", len(header), len(rec))})
+			continue
+		}
+		row := importRow{row: line}
+		row.fighter, row.err = fighterFromCSV(header, rec)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// fighterFromCSV decodes fighter from csv record of header columns.
+func fighterFromCSV(header, rec []string) (Fighter, error) {
+	var f Fighter
+	var fe xerror.ValidationError
+	for i, col := range header {
+		v := strings.TrimSpace(rec[i])
+		if v == "" {
+			continue
+		}
+		switch col {
+		case "id":
+			id, err := uuid.Parse(v)
+			if err != nil {
+				fe = fe.Add(col, xerror.ViolationMalformed, "must be a uuid")
+			}
+			f.ID = id
+		case "first_name":
+			f.FirstName = v
+		case "last_name":
+			f.LastName = v
+		case "nickname":
+			f.Nickname = v
+		case "date_of_birth":
+			t, err := time.Parse(dateLayout, v)
+			if err != nil {
+				fe = fe.Add(col, xerror.ViolationMalformed, "must be a YYYY-MM-DD date")
+				continue
+			}
+			f.DateOfBirth = &Date{t}
+		case "nationality":
+			f.Nationality = v
+		case "stance":
+			f.Stance = Stance(v)
+		case "height_cm", "reach_cm":
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				fe = fe.Add(col, xerror.ViolationMalformed, "must be a whole number")
+			}
+			if col == "height_cm" {
+				f.HeightCM = n
+			} else {
+				f.ReachCM = n
+			}
+		case "weight_class":
+			f.WeightClass = WeightClass(v)
+		}
+	}
+	if len(fe) != 0 {
+		return f, fe
+	}
+	return f, nil
+}
+
+func parseFighterJSONL(data []byte) ([]importRow, error) {
+	var rows []importRow
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(nil, MaxFighterImportSize)
+	for line := 1; s.Scan(); line++ {
+		b := bytes.TrimSpace(s.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		row := importRow{row: line}
+		row.err = json.Unmarshal(b, &row.fighter)
+		rows = append(rows, row)
+	}
+	return rows, s.Err()
+}
+
+// validateImportRows splits rows to valid fighter rows and row errors.
+func validateImportRows(rows []importRow) ([]FighterImportRow, []ImportRowError) {
+	var valid []FighterImportRow
+	var errs []ImportRowError
+	for _, r := range rows {
+		err := r.err
+		if err == nil {
+			r.fighter.normalize()
+			r.fighter.Version = 0
+			err = r.fighter.Validate()
+		}
+		if err == nil {
+			valid = append(valid, FighterImportRow{Row: r.row, Fighter: r.fighter})
+			continue
+		}
+
+		re := ImportRowError{Row: r.row}
+		var fe xerror.ValidationError
+		if errors.As(err, &fe) {
+			re.Fields = fe
+		} else {
+			re.Message = err.Error()
+		}
+		errs = append(errs, re)
+	}
+	return valid, errs
+}
@@ -0,0 +1,146 @@
+package foo_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
+)
+
+func TestService_ProcessFighterImport(t *testing.T) {
+	fighterID := uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140")
+	tests := []struct {
+		name   string
+		format foo.FighterImportFormat
+		data   string
+		// returns
+		wantStatus foo.FighterImportStatus
+		wantRows   []int
+		wantErrs   []foo.ImportRowError
+	}{
+		{
+			"csv",
+			foo.FighterImportCSV,
+			"first_name,last_name,height_cm,id\n" +
+				"dave,grohl,180,\n" +
+				"taylor,,abc,\n" +
+				"nate,mendel,,b41c7709-04e3-4c48-b233-34e6838d9140\n" +
+				"pat,smear\n",
+			foo.FighterImportDone,
+			[]int{2, 4},
+			[]foo.ImportRowError{
+				{Row: 3, Fields: xerror.ValidationError{{Field: "height_cm", Code: xerror.ViolationMalformed, Message: "must be a whole number"}}},
+				{Row: 5, Message: "expected 4 columns, got 2"},
+			},
+		},
+		{
+			"jsonl",
+			foo.FighterImportJSONL,
+			`{"first_name":"dave","last_name":"grohl"}` + "\n\n" +
+				`{"first_name":"taylor"}` + "\n",
+			foo.FighterImportDone,
+			[]int{1},
+			[]foo.ImportRowError{
+				{Row: 3, Fields: xerror.ValidationError{{Field: "last_name", Code: xerror.ViolationRequired, Message: "is required"}}},
+			},
+		},
+		{
+			"unknown csv column",
+			foo.FighterImportCSV,
+			"first_name,surname\ndave,grohl\n",
+			foo.FighterImportFailed,
+			nil,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotStatus foo.FighterImportStatus
+			var gotRows []int
+			var gotErrs []foo.ImportRowError
+			var gotFighterID uuid.UUID
+			repo := &mockFighterRepo{
+				FighterImportFn: func(ctx context.Context, id uuid.UUID) (*foo.FighterImport, error) {
+					return &foo.FighterImport{ID: id, Format: tt.format, Status: foo.FighterImportPending}, nil
+				},
+				FighterImportDataFn: func(ctx context.Context, id uuid.UUID) ([]byte, error) {
+					return []byte(tt.data), nil
+				},
+				UpdateFighterImportStatusFn: func(ctx context.Context, imp *foo.FighterImport) error {
+					gotStatus = imp.Status
+					return nil
+				},
+				ImportFightersFn: func(ctx context.Context, importID uuid.UUID, rows []foo.FighterImportRow, errs []foo.ImportRowError, processed int) error {
+					for _, r := range rows {
+						gotRows = append(gotRows, r.Row)
+						if r.Fighter.ID != uuid.Nil {
+							gotFighterID = r.Fighter.ID
+						}
+					}
+					gotErrs = append(gotErrs, errs...)
+					return nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			if err := svc.ProcessFighterImport(context.Background(), uuid.New()); err != nil {
+				t.Fatalf("ProcessFighterImport() error = %v", err)
+			}
+			if gotStatus != tt.wantStatus {
+				t.Errorf("ProcessFighterImport() status = %v, want %v", gotStatus, tt.wantStatus)
+			}
+			if !reflect.DeepEqual(gotRows, tt.wantRows) {
+				t.Errorf("ProcessFighterImport() rows = %v, want %v", gotRows, tt.wantRows)
+			}
+			if !reflect.DeepEqual(gotErrs, tt.wantErrs) {
+				t.Errorf("ProcessFighterImport() errs = %+v, want %+v", gotErrs, tt.wantErrs)
+			}
+			if tt.format == foo.FighterImportCSV && tt.wantRows != nil && gotFighterID != fighterID {
+				t.Errorf("ProcessFighterImport() fighter id = %v, want %v", gotFighterID, fighterID)
+			}
+		})
+	}
+}
+
+func TestService_FailFighterImport(t *testing.T) {
+	tests := []struct {
+		name   string
+		status foo.FighterImportStatus
+		// returns
+		wantStatus  foo.FighterImportStatus
+		wantMessage string
+	}{
+		{"processing", foo.FighterImportProcessing, foo.FighterImportFailed, "connection reset"},
+		{"pending", foo.FighterImportPending, foo.FighterImportFailed, "connection reset"},
+		{"done", foo.FighterImportDone, "", ""},
+		{"failed", foo.FighterImportFailed, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotStatus foo.FighterImportStatus
+			var gotMessage string
+			repo := &mockFighterRepo{
+				FighterImportFn: func(ctx context.Context, id uuid.UUID) (*foo.FighterImport, error) {
+					return &foo.FighterImport{ID: id, Status: tt.status}, nil
+				},
+				UpdateFighterImportStatusFn: func(ctx context.Context, imp *foo.FighterImport) error {
+					gotStatus, gotMessage = imp.Status, imp.Message
+					return nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			if err := svc.FailFighterImport(context.Background(), uuid.New(), "connection reset"); err != nil {
+				t.Fatalf("FailFighterImport() error = %v", err)
+			}
+			if gotStatus != tt.wantStatus || gotMessage != tt.wantMessage {
+				t.Errorf("FailFighterImport() status = %q %q, want %q %q", gotStatus, gotMessage, tt.wantStatus, tt.wantMessage)
+			}
+		})
+	}
+}
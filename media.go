@@ -0,0 +1,156 @@
+package foo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"time"
+
+	// Registers decoders of thumbnail source images.
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+var (
+	ErrMediaNotFound = xerror.Error(xerror.CodeNotFound)
+	ErrMediaInvalid  = xerror.Error(xerror.CodeInvalid)
+)
+
+// BlobStore represents a storage of media files by key.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a download url of the key that expires after ttl.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// MediaKind represents a fighter media usage.
+type MediaKind string
+
+const (
+	MediaKindProfilePhoto MediaKind = "profile_photo"
+	MediaKindPhoto        MediaKind = "photo"
+	MediaKindVideo        MediaKind = "video"
+)
+
+const (
+	// MaxMediaSize is the max size of a media file in bytes.
+	MaxMediaSize = 50 << 20
+	// MediaURLTTL is how long signed media urls stay valid.
+	MediaURLTTL = 15 * time.Minute
+
+	thumbnailSize = 320
+	// maxThumbnailSourcePixels guards the worker from decoding huge images.
+	maxThumbnailSourcePixels = 50_000_000
+)
+
+// mediaTypes maps allowed media content types to their file extension and
+// kinds they can be uploaded as.
+var mediaTypes = map[string]struct {
+	ext   string
+	kinds []MediaKind
+}{
+	"image/jpeg": {".jpg", []MediaKind{MediaKindPhoto, MediaKindProfilePhoto}},
+	"image/png":  {".png", []MediaKind{MediaKindPhoto, MediaKindProfilePhoto}},
+	"image/gif":  {".gif", []MediaKind{MediaKindPhoto, MediaKindProfilePhoto}},
+	"image/webp": {".webp", []MediaKind{MediaKindPhoto, MediaKindProfilePhoto}},
+	"video/mp4":  {".mp4", []MediaKind{MediaKindVideo}},
+	"video/webm": {".webm", []MediaKind{MediaKindVideo}},
+}
+
+// Media represents a file attached to a fighter. Files are kept on a blob
+// store and served using signed urls.
+type Media struct {
+	ID          uuid.UUID `json:"id"`
+	FighterID   uuid.UUID `json:"fighter_id"`
+	Kind        MediaKind `json:"kind"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Filename    string    `json:"filename"`
+	// BlobKey and ThumbnailKey are blob store keys of the file and its thumbnail.
+	BlobKey      string `json:"-"`
+	ThumbnailKey string `json:"-"`
+	// URL and ThumbnailURL are signed download urls valid until URLExpiresAt.
+	URL          string     `json:"url,omitempty"`
+	ThumbnailURL string     `json:"thumbnail_url,omitempty"`
+	URLExpiresAt *time.Time `json:"url_expires_at,omitempty"`
+	UserID       string     `json:"user_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// HasThumbnail reports whether the media content type can be thumbnailed.
+func (m Media) HasThumbnail() bool {
+	switch m.ContentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+// mediaKey returns blob store key of a fighter media file.
+func mediaKey(fighterID, mediaID uuid.UUID, name string) string {
+	return "fighters/" + fighterID.String() + "/media/" + mediaID.String() + "/" + name
+}
+
+// makeThumbnail decodes an image and encodes it as jpeg that fits a square
+// of size pixels keeping its aspect ratio.
+func makeThumbnail(r io.Reader, size int) ([]byte, error) {
+	var buf bytes.Buffer
+	cfg, _, err := image.DecodeConfig(io.TeeReader(r, &buf))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width*cfg.Height > maxThumbnailSourcePixels {
+		return nil, fmt.Errorf("image is too large: %dx%d", cfg.Width, cfg.Height)
+	}
+	src, _, err := image.Decode(io.MultiReader(&buf, r))
+	if err != nil {
+		return nil, err
+	}
+
+	w, h := cfg.Width, cfg.Height
+	if w > size || h > size {
+		if w >= h {
+			w, h = size, max(1, h*size/w)
+		} else {
+			w, h = max(1, w*size/h), size
+		}
+	}
+	var out bytes.Buffer
+	if err = jpeg.Encode(&out, scaleDown(src, w, h), &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// scaleDown resizes src to w by h averaging source pixels of each target pixel.
+func scaleDown(src image.Image, w, h int) image.Image {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0 := b.Min.Y + y*b.Dy()/h
+		y1 := max(y0+1, b.Min.Y+(y+1)*b.Dy()/h)
+		for x := 0; x < w; x++ {
+			x0 := b.Min.X + x*b.Dx()/w
+			x1 := max(x0+1, b.Min.X+(x+1)*b.Dx()/w)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a, n = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
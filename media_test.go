@@ -0,0 +1,150 @@
+package foo_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
+)
+
+func TestService_CreateFighterMedia(t *testing.T) {
+	tests := []struct {
+		name string
+		// params
+		kind foo.MediaKind
+		body []byte
+		// returns
+		wantKind        foo.MediaKind
+		wantContentType string
+		wantThumbnail   bool
+		wantCode        string
+	}{
+		{"photo", "", pngImage(t, 2, 2), foo.MediaKindPhoto, "image/png", true, ""},
+		{"profile photo", foo.MediaKindProfilePhoto, pngImage(t, 2, 2), foo.MediaKindProfilePhoto, "image/png", true, ""},
+		{"video as photo", foo.MediaKindPhoto, append([]byte("\x00\x00\x00\x18ftypmp42"), make([]byte, 16)...), "", "", false, xerror.CodeInvalid},
+		{"unsupported type", "", []byte("<html><body>hi</body></html>"), "", "", false, xerror.CodeInvalid},
+		{"empty", "", nil, "", "", false, xerror.CodeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var published []foo.Message
+			repo := &mockFighterRepo{
+				FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+					return &foo.Fighter{ID: id}, nil
+				},
+				CreateMediaFn: func(ctx context.Context, m *foo.Media, mm ...foo.Message) error {
+					published = mm
+					return nil
+				},
+			}
+			blobs := memBlobStore{}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, blobs, nil, nil, l)
+			got, err := svc.CreateFighterMedia(context.Background(), redID.String(), tt.kind, "../me.png", bytes.NewReader(tt.body))
+			var xerr xerror.XError
+			errors.As(err, &xerr)
+			if (err != nil) != (tt.wantCode != "") || xerr.Code != tt.wantCode {
+				t.Fatalf("CreateFighterMedia() error = %v, wantCode %q", err, tt.wantCode)
+			}
+			if err != nil {
+				if len(blobs) != 0 {
+					t.Errorf("CreateFighterMedia() left blobs %v", blobs)
+				}
+				return
+			}
+			if got.Kind != tt.wantKind || got.ContentType != tt.wantContentType || got.Size != int64(len(tt.body)) {
+				t.Errorf("CreateFighterMedia() got = %+v", got)
+			}
+			if got.Filename != "me.png" {
+				t.Errorf("CreateFighterMedia() filename = %q, want me.png", got.Filename)
+			}
+			if !bytes.Equal(blobs[got.BlobKey], tt.body) || !strings.HasPrefix(got.URL, "mem://"+got.BlobKey) {
+				t.Errorf("CreateFighterMedia() blob %q not stored or signed, url %q", got.BlobKey, got.URL)
+			}
+			if gotThumb := len(published) == 1 && published[0].Topic == foo.TopicMediaThumbnail; gotThumb != tt.wantThumbnail {
+				t.Errorf("CreateFighterMedia() published %v, want thumbnail %v", published, tt.wantThumbnail)
+			}
+		})
+	}
+}
+
+func TestService_GenerateMediaThumbnail(t *testing.T) {
+	media := foo.Media{ID: uuid.New(), FighterID: redID, ContentType: "image/png", BlobKey: "original.png"}
+	blobs := memBlobStore{media.BlobKey: pngImage(t, 800, 400)}
+	var gotKey string
+	repo := &mockFighterRepo{
+		MediaFn: func(ctx context.Context, id uuid.UUID) (*foo.Media, error) {
+			return &media, nil
+		},
+		UpdateMediaThumbnailFn: func(ctx context.Context, id uuid.UUID, key string) error {
+			gotKey = key
+			return nil
+		},
+	}
+	l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := foo.NewService(repo, blobs, nil, nil, l)
+	if err := svc.GenerateMediaThumbnail(context.Background(), media.ID); err != nil {
+		t.Fatalf("GenerateMediaThumbnail() error = %v", err)
+	}
+
+	thumb, err := jpeg.Decode(bytes.NewReader(blobs[gotKey]))
+	if err != nil {
+		t.Fatalf("GenerateMediaThumbnail() thumbnail %q not a jpeg: %v", gotKey, err)
+	}
+	if b := thumb.Bounds(); b.Dx() != 320 || b.Dy() != 160 {
+		t.Errorf("GenerateMediaThumbnail() thumbnail size = %v, want 320x160", b.Size())
+	}
+}
+
+func pngImage(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// memBlobStore is an in-memory blob store.
+type memBlobStore map[string][]byte
+
+func (m memBlobStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	b, err := io.ReadAll(r)
+	m[key] = b
+	return err
+}
+
+func (m memBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	b, ok := m[key]
+	if !ok {
+		return nil, errors.New("blob not found")
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (m memBlobStore) Delete(ctx context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+func (m memBlobStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return "mem://" + key + "?ttl=" + ttl.String(), nil
+}
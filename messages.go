@@ -0,0 +1,114 @@
+package foo
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Worker job topics.
+const (
+	TopicBoutCompleted  = "bout.completed"
+	TopicFightersPurge  = "fighters.purge"
+	TopicFightersImport = "fighters.import"
+	TopicMediaThumbnail = "media.thumbnail"
+	TopicFighterStats   = "fighters.stats"
+
+	// Follower notification topics.
+	TopicNotificationsFanout = "notifications.fanout"
+	TopicNotificationDeliver = "notification.deliver"
+
+	// Outbound webhook topics.
+	TopicWebhookDeliver         = "webhook.deliver"
+	TopicWebhookDeliveriesPurge = "webhook_deliveries.purge"
+
+	// Fighter domain event topics.
+	TopicFighterCreated  = "fighter.created"
+	TopicFighterUpdated  = "fighter.updated"
+	TopicFighterDeleted  = "fighter.deleted"
+	TopicFighterRestored = "fighter.restored"
+	TopicFighterMerged   = "fighter.merged"
+)
+
+// Message represents a job published to a worker topic along with a
+// repository write. Messages are written to the outbox on the same transaction
+// and relayed to worker topics at least once.
+type Message struct {
+	Topic   string
+	Payload json.RawMessage
+	// NotBefore delays the job until the given time, zero is right away.
+	NotBefore time.Time
+}
+
+// newMessage encodes payload as a message to a topic.
+func newMessage(topic string, payload interface{}) Message {
+	b, _ := json.Marshal(payload)
+	return Message{Topic: topic, Payload: b}
+}
+
+// BoutCompleted represents a bout result change that affects weight class rankings.
+type BoutCompleted struct {
+	BoutID      uuid.UUID   `json:"bout_id"`
+	WeightClass WeightClass `json:"weight_class"`
+}
+
+// FighterImportCreated represents a fighter import queued for processing.
+type FighterImportCreated struct {
+	ImportID uuid.UUID `json:"import_id"`
+}
+
+// MediaUploaded represents an uploaded media waiting for its thumbnail.
+type MediaUploaded struct {
+	MediaID uuid.UUID `json:"media_id"`
+}
+
+// FighterStatsStale represents fighters whose completed bouts changed, their
+// stats and stats of their opponents are refreshed.
+type FighterStatsStale struct {
+	FighterIDs []uuid.UUID `json:"fighter_ids"`
+}
+
+// BoutNotice represents a bout change that followers of its fighters are
+// notified about.
+type BoutNotice struct {
+	BoutID uuid.UUID        `json:"bout_id"`
+	Kind   NotificationKind `json:"kind"`
+}
+
+// NotificationDelivery represents a notification waiting to be delivered,
+// empty channels are the user preferred channels. Attempt counts retries of
+// failed channels.
+type NotificationDelivery struct {
+	Notification Notification          `json:"notification"`
+	Channels     []NotificationChannel `json:"channels,omitempty"`
+	Attempt      int                   `json:"attempt,omitempty"`
+}
+
+// WebhookDispatch represents an event waiting to be posted to a webhook,
+// Attempt counts retries.
+type WebhookDispatch struct {
+	WebhookID uuid.UUID    `json:"webhook_id"`
+	Event     WebhookEvent `json:"event"`
+	Attempt   int          `json:"attempt,omitempty"`
+}
+
+// fighterActionTopics maps fighter change action to its domain event topic.
+var fighterActionTopics = map[FighterAction]string{
+	FighterActionCreate:  TopicFighterCreated,
+	FighterActionUpdate:  TopicFighterUpdated,
+	FighterActionDelete:  TopicFighterDeleted,
+	FighterActionRestore: TopicFighterRestored,
+	FighterActionMerge:   TopicFighterMerged,
+}
+
+// FighterEvent represents a fighter domain event with the fighter after the change.
+type FighterEvent struct {
+	Change  FighterChange `json:"change"`
+	Fighter Fighter       `json:"fighter"`
+}
+
+// NewFighterEvent returns domain event message of a fighter change.
+func NewFighterEvent(ch FighterChange, f Fighter) Message {
+	return newMessage(fighterActionTopics[ch.Action], FighterEvent{Change: ch, Fighter: f})
+}
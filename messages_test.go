@@ -0,0 +1,38 @@
+package foo_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+)
+
+func TestNewFighterEvent(t *testing.T) {
+	f := foo.Fighter{ID: uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140"), FirstName: "dave", LastName: "grohl"}
+	tests := []struct {
+		action foo.FighterAction
+		want   string
+	}{
+		{foo.FighterActionCreate, foo.TopicFighterCreated},
+		{foo.FighterActionUpdate, foo.TopicFighterUpdated},
+		{foo.FighterActionDelete, foo.TopicFighterDeleted},
+		{foo.FighterActionRestore, foo.TopicFighterRestored},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.action), func(t *testing.T) {
+			ch := foo.NewFighterChange(tt.action, nil, &f, foo.Actor{UserID: "user-1"})
+			m := foo.NewFighterEvent(ch, f)
+			if m.Topic != tt.want {
+				t.Errorf("NewFighterEvent() topic = %s, want %s", m.Topic, tt.want)
+			}
+			var e foo.FighterEvent
+			if err := json.Unmarshal(m.Payload, &e); err != nil {
+				t.Fatalf("NewFighterEvent() payload error = %v", err)
+			}
+			if e.Fighter.ID != f.ID || e.Change.Action != tt.action || e.Change.UserID != "user-1" {
+				t.Errorf("NewFighterEvent() payload = %+v", e)
+			}
+		})
+	}
+}
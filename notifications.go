@@ -0,0 +1,222 @@
+package foo
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+var (
+	ErrNotificationNotFound = xerror.Error(xerror.CodeNotFound)
+	ErrNotificationInvalid  = xerror.Error(xerror.CodeInvalid)
+)
+
+// Notifier delivers a notification to a user through a channel using the
+// user channel preferences.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification, p NotificationPreferences) error
+}
+
+// NotificationChannel represents how a notification reaches a user.
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail   NotificationChannel = "email"
+	NotificationChannelWebhook NotificationChannel = "webhook"
+	NotificationChannelInApp   NotificationChannel = "in_app"
+)
+
+// Valid reports whether channel is a known channel.
+func (c NotificationChannel) Valid() bool {
+	switch c {
+	case NotificationChannelEmail, NotificationChannelWebhook, NotificationChannelInApp:
+		return true
+	}
+	return false
+}
+
+// notification delivery settings.
+const (
+	// notificationMaxAttempts is how many times a failing channel is tried.
+	notificationMaxAttempts = 5
+	// notificationRetryDelay is the delay of first retry, doubled on each attempt.
+	notificationRetryDelay = time.Minute
+)
+
+// NotificationKind represents what a notification is about.
+type NotificationKind string
+
+const (
+	NotificationBoutScheduled NotificationKind = "bout_scheduled"
+	NotificationBoutResult    NotificationKind = "bout_result"
+)
+
+// Notification represents a message to a user about a followed fighter.
+type Notification struct {
+	ID        uuid.UUID        `json:"id"`
+	UserID    string           `json:"user_id"`
+	Kind      NotificationKind `json:"kind"`
+	BoutID    uuid.UUID        `json:"bout_id"`
+	Title     string           `json:"title"`
+	Body      string           `json:"body"`
+	CreatedAt time.Time        `json:"created_at"`
+	// ReadAt is set when the user reads the notification on the in-app inbox.
+	ReadAt *time.Time `json:"read_at,omitempty"`
+}
+
+// NotificationPreferences represents where and when a user wants to be notified.
+type NotificationPreferences struct {
+	Channels   []NotificationChannel `json:"channels"`
+	Email      string                `json:"email"`
+	WebhookURL string                `json:"webhook_url"`
+	// QuietHours holds email and webhook notifications until they end, in-app
+	// notifications are always delivered.
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// DefaultNotificationPreferences returns preferences of users who have not set any.
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{Channels: []NotificationChannel{NotificationChannelInApp}}
+}
+
+// Validate checks preferences fields and returns all field errors found.
+func (p NotificationPreferences) Validate() error {
+	var fe xerror.ValidationError
+	for i, c := range p.Channels {
+		field := fmt.Sprintf("channels[%d]", i)
+		if !c.Valid() {
+			fe = fe.Add(field, xerror.ViolationUnsupported, "must be one of email, webhook or in_app")
+		} else if slices.Index(p.Channels, c) != i {
+			fe = fe.Add(field, xerror.ViolationDuplicate, "must not be repeated")
+		}
+	}
+	if p.Email != "" {
+		if a, err := mail.ParseAddress(p.Email); err != nil || a.Address != p.Email {
+			fe = fe.Add("email", xerror.ViolationMalformed, "must be an email address")
+		}
+	} else if p.Enabled(NotificationChannelEmail) {
+		fe = fe.Add("email", xerror.ViolationRequired, "is required when email channel is enabled")
+	}
+	if p.WebhookURL != "" {
+		if !httpURL(p.WebhookURL) {
+			fe = fe.Add("webhook_url", xerror.ViolationMalformed, "must be an http or https url")
+		}
+	} else if p.Enabled(NotificationChannelWebhook) {
+		fe = fe.Add("webhook_url", xerror.ViolationRequired, "is required when webhook channel is enabled")
+	}
+	if q := p.QuietHours; q != nil {
+		start, serr := parseClock(q.Start)
+		if serr != nil {
+			fe = fe.Add("quiet_hours.start", xerror.ViolationMalformed, "must be a HH:MM time")
+		}
+		end, eerr := parseClock(q.End)
+		if eerr != nil {
+			fe = fe.Add("quiet_hours.end", xerror.ViolationMalformed, "must be a HH:MM time")
+		}
+		if serr == nil && eerr == nil && start == end {
+			fe = fe.Add("quiet_hours.end", xerror.ViolationConflict, "must not be the same as start")
+		}
+		if _, err := time.LoadLocation(q.TimeZone); err != nil {
+			fe = fe.Add("quiet_hours.time_zone", xerror.ViolationUnsupported, "must be an IANA time zone")
+		}
+	}
+
+	if len(fe) != 0 {
+		return ErrNotificationInvalid.X(fe)
+	}
+	return nil
+}
+
+// Enabled reports whether the channel is enabled.
+func (p NotificationPreferences) Enabled(c NotificationChannel) bool {
+	return slices.Contains(p.Channels, c)
+}
+
+// QuietHours represents a daily period on a time zone when a user does not
+// want to be disturbed. Period that ends before it starts spans midnight.
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// TimeZone is an IANA time zone name, empty is UTC.
+	TimeZone string `json:"time_zone"`
+}
+
+// Until returns when the quiet hours that t falls into ends, ok is false when
+// t is outside quiet hours.
+func (q QuietHours) Until(t time.Time) (end time.Time, ok bool) {
+	loc, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	startMin, serr := parseClock(q.Start)
+	endMin, eerr := parseClock(q.End)
+	if serr != nil || eerr != nil || startMin == endMin {
+		return time.Time{}, false
+	}
+
+	lt := t.In(loc)
+	m := lt.Hour()*60 + lt.Minute()
+	if startMin < endMin {
+		ok = m >= startMin && m < endMin
+	} else {
+		ok = m >= startMin || m < endMin
+	}
+	if !ok {
+		return time.Time{}, false
+	}
+	end = time.Date(lt.Year(), lt.Month(), lt.Day(), endMin/60, endMin%60, 0, 0, loc)
+	if !end.After(lt) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end, true
+}
+
+// parseClock returns minutes of the day of a HH:MM time.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// newBoutNotification returns a notification to a follower about a bout
+// between red and blue fighters. Its id is derived from the bout change so
+// redelivered fanouts do not notify twice.
+func newBoutNotification(userID string, kind NotificationKind, b Bout, red, blue Fighter) Notification {
+	n := Notification{
+		ID:     uuid.NewSHA1(b.ID, []byte(userID+"/"+string(kind)+"/"+b.UpdatedAt.Format(time.RFC3339Nano))),
+		UserID: userID,
+		Kind:   kind,
+		BoutID: b.ID,
+	}
+	rn, bn := red.FirstName+" "+red.LastName, blue.FirstName+" "+blue.LastName
+	switch kind {
+	case NotificationBoutScheduled:
+		n.Title = fmt.Sprintf("Bout scheduled: %s vs %s", rn, bn)
+		n.Body = fmt.Sprintf("%s faces %s on %s.", rn, bn, b.Date.Format(dateLayout))
+	case NotificationBoutResult:
+		n.Title = fmt.Sprintf("Bout result: %s vs %s", rn, bn)
+		switch b.Result {
+		case BoutResultWin:
+			n.Body = fmt.Sprintf("%s defeated %s", rn, bn)
+		case BoutResultLoss:
+			n.Body = fmt.Sprintf("%s defeated %s", bn, rn)
+		case BoutResultDraw:
+			n.Body = fmt.Sprintf("%s and %s fought to a draw", rn, bn)
+		default:
+			n.Body = fmt.Sprintf("%s and %s ended in a no contest", rn, bn)
+		}
+		if b.Method != "" && b.Round > 0 {
+			n.Body += fmt.Sprintf(" by %s in round %d", b.Method, b.Round)
+		}
+		n.Body += "."
+	}
+	return n
+}
@@ -0,0 +1,280 @@
+package foo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
+)
+
+func TestQuietHours_Until(t *testing.T) {
+	manila, _ := time.LoadLocation("Asia/Manila")
+	at := func(d, h, m int) time.Time { return time.Date(2024, 3, d, h, m, 0, 0, manila) }
+	night := foo.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Asia/Manila"}
+	lunch := foo.QuietHours{Start: "12:00", End: "13:00", TimeZone: "Asia/Manila"}
+	tests := []struct {
+		name   string
+		q      foo.QuietHours
+		t      time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{"before midnight", night, at(1, 23, 0), at(2, 7, 0), true},
+		{"after midnight", night, at(2, 3, 30), at(2, 7, 0), true},
+		{"on end", night, at(2, 7, 0), time.Time{}, false},
+		{"outside", night, at(2, 12, 0), time.Time{}, false},
+		{"same day", lunch, at(2, 12, 30), at(2, 13, 0), true},
+		{"other time zone", lunch, at(2, 12, 30).UTC(), at(2, 13, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.q.Until(tt.t)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("Until() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNotificationPreferences_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		p          foo.NotificationPreferences
+		wantFields []string
+	}{
+		{"default", foo.DefaultNotificationPreferences(), nil},
+		{"all channels", foo.NotificationPreferences{
+			Channels:   []foo.NotificationChannel{"email", "webhook", "in_app"},
+			Email:      "fan@foo.test",
+			WebhookURL: "https://hooks.foo.test/notify",
+			QuietHours: &foo.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Dublin"},
+		}, nil},
+		{"unknown and repeated channels", foo.NotificationPreferences{
+			Channels: []foo.NotificationChannel{"sms", "in_app", "in_app"},
+		}, []string{"channels[0]", "channels[2]"}},
+		{"missing addresses", foo.NotificationPreferences{
+			Channels: []foo.NotificationChannel{"email", "webhook"},
+		}, []string{"email", "webhook_url"}},
+		{"malformed addresses", foo.NotificationPreferences{
+			Email:      "Fan <fan@foo.test>",
+			WebhookURL: "ftp://hooks.foo.test",
+		}, []string{"email", "webhook_url"}},
+		{"malformed quiet hours", foo.NotificationPreferences{
+			QuietHours: &foo.QuietHours{Start: "10pm", End: "25:00", TimeZone: "Mars/Olympus"},
+		}, []string{"quiet_hours.start", "quiet_hours.end", "quiet_hours.time_zone"}},
+		{"empty quiet hours", foo.NotificationPreferences{
+			QuietHours: &foo.QuietHours{Start: "08:00", End: "08:00"},
+		}, []string{"quiet_hours.end"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.p.Validate()
+			var got []string
+			var ve xerror.ValidationError
+			if errors.As(err, &ve) {
+				for _, v := range ve {
+					got = append(got, v.Field)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestService_UpdateBout_Notice(t *testing.T) {
+	past := foo.NewDate(time.Now().AddDate(0, -1, 0))
+	scheduled := foo.Bout{RedFighterID: redID, BlueFighterID: blueID, Date: past}
+	won := scheduled
+	won.Result, won.Method, won.Round = foo.BoutResultWin, foo.BoutMethodKO, 1
+	rescheduled := scheduled
+	rescheduled.Date = foo.NewDate(time.Now().AddDate(0, 1, 0))
+	tests := []struct {
+		name      string
+		cur, next foo.Bout
+		want      foo.NotificationKind
+	}{
+		{"result", scheduled, won, foo.NotificationBoutResult},
+		{"same result", won, won, ""},
+		{"rescheduled", scheduled, rescheduled, foo.NotificationBoutScheduled},
+		{"unchanged", scheduled, scheduled, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got foo.NotificationKind
+			repo := &mockFighterRepo{
+				FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+					return &foo.Fighter{ID: id}, nil
+				},
+				BoutFn: func(ctx context.Context, id uuid.UUID) (*foo.Bout, error) {
+					cur := tt.cur
+					return &cur, nil
+				},
+				UpdateBoutFn: func(ctx context.Context, b *foo.Bout, mm ...foo.Message) error {
+					for _, m := range mm {
+						if m.Topic == foo.TopicNotificationsFanout {
+							var bn foo.BoutNotice
+							json.Unmarshal(m.Payload, &bn)
+							got = bn.Kind
+						}
+					}
+					return nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			next := tt.next
+			if _, err := svc.UpdateBout(context.Background(), uuid.NewString(), &next); err != nil {
+				t.Fatalf("UpdateBout() unexpected error %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("UpdateBout() notice = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_NotifyBoutFollowers(t *testing.T) {
+	b := foo.Bout{ID: uuid.New(), RedFighterID: redID, BlueFighterID: blueID, Result: foo.BoutResultLoss,
+		Method: foo.BoutMethodSUB, Round: 2}
+	var got []foo.NotificationDelivery
+	repo := &mockFighterRepo{
+		BoutFn: func(ctx context.Context, id uuid.UUID) (*foo.Bout, error) {
+			return &b, nil
+		},
+		FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+			if id == redID {
+				return &foo.Fighter{ID: id, FirstName: "Red", LastName: "Corner"}, nil
+			}
+			return &foo.Fighter{ID: id, FirstName: "Blue", LastName: "Corner"}, nil
+		},
+		FollowerIDsFn: func(ctx context.Context, fighterIDs []uuid.UUID) ([]string, error) {
+			return []string{"u1", "u2"}, nil
+		},
+		PublishFn: func(ctx context.Context, mm ...foo.Message) error {
+			for _, m := range mm {
+				var d foo.NotificationDelivery
+				json.Unmarshal(m.Payload, &d)
+				got = append(got, d)
+			}
+			return nil
+		},
+	}
+	l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := foo.NewService(repo, nil, nil, nil, l)
+	notice := foo.BoutNotice{BoutID: b.ID, Kind: foo.NotificationBoutResult}
+	for i := 0; i < 2; i++ {
+		if err := svc.NotifyBoutFollowers(context.Background(), notice); err != nil {
+			t.Fatalf("NotifyBoutFollowers() unexpected error %s", err)
+		}
+	}
+
+	if len(got) != 4 {
+		t.Fatalf("NotifyBoutFollowers() published %d deliveries, want 2 per run", len(got))
+	}
+	if n := got[0].Notification; n.UserID != "u1" || n.Body != "Blue Corner defeated Red Corner by SUB in round 2." {
+		t.Errorf("NotifyBoutFollowers() notification = %+v", n)
+	}
+	if got[0].Notification.ID == got[1].Notification.ID || got[0].Notification.ID != got[2].Notification.ID {
+		t.Error("NotifyBoutFollowers() notification ids must be unique per user and stable across runs")
+	}
+}
+
+func TestService_DeliverNotification(t *testing.T) {
+	now := time.Now().UTC()
+	quiet := &foo.QuietHours{Start: now.Add(-time.Hour).Format("15:04"), End: now.Add(time.Hour).Format("15:04")}
+	all := []foo.NotificationChannel{"email", "webhook", "in_app"}
+	tests := []struct {
+		name        string
+		prefs       *foo.NotificationPreferences
+		attempt     int
+		webhookErr  error
+		wantSent    []foo.NotificationChannel
+		wantHeld    []foo.NotificationChannel
+		wantRetried []foo.NotificationChannel
+	}{
+		{"default preferences", nil, 0, nil, []foo.NotificationChannel{"in_app"}, nil, nil},
+		{"all channels", &foo.NotificationPreferences{Channels: all}, 0, nil, all, nil, nil},
+		{"quiet hours", &foo.NotificationPreferences{Channels: all, QuietHours: quiet}, 0, nil,
+			[]foo.NotificationChannel{"in_app"}, []foo.NotificationChannel{"email", "webhook"}, nil},
+		{"failed webhook", &foo.NotificationPreferences{Channels: all}, 0, errors.New("timeout"),
+			[]foo.NotificationChannel{"email", "in_app"}, nil, []foo.NotificationChannel{"webhook"}},
+		{"attempts exhausted", &foo.NotificationPreferences{Channels: all}, 4, errors.New("timeout"),
+			[]foo.NotificationChannel{"email", "in_app"}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSent []foo.NotificationChannel
+			notifier := func(c foo.NotificationChannel, err error) foo.Notifier {
+				return notifierFunc(func(ctx context.Context, n foo.Notification, p foo.NotificationPreferences) error {
+					if err == nil {
+						gotSent = append(gotSent, c)
+					}
+					return err
+				})
+			}
+			nn := map[foo.NotificationChannel]foo.Notifier{
+				foo.NotificationChannelEmail:   notifier(foo.NotificationChannelEmail, nil),
+				foo.NotificationChannelWebhook: notifier(foo.NotificationChannelWebhook, tt.webhookErr),
+				foo.NotificationChannelInApp:   notifier(foo.NotificationChannelInApp, nil),
+			}
+			var gotHeld, gotRetried []foo.NotificationChannel
+			repo := &mockFighterRepo{
+				NotificationPreferencesFn: func(ctx context.Context, userID string) (*foo.NotificationPreferences, error) {
+					if tt.prefs == nil {
+						return nil, foo.ErrNotificationNotFound
+					}
+					return tt.prefs, nil
+				},
+				PublishFn: func(ctx context.Context, mm ...foo.Message) error {
+					for _, m := range mm {
+						var d foo.NotificationDelivery
+						json.Unmarshal(m.Payload, &d)
+						if d.Attempt > tt.attempt {
+							if !m.NotBefore.After(now) {
+								t.Errorf("retry not before = %v, want after %v", m.NotBefore, now)
+							}
+							gotRetried = d.Channels
+						} else {
+							if !m.NotBefore.After(now) {
+								t.Errorf("held not before = %v, want quiet hours end", m.NotBefore)
+							}
+							gotHeld = d.Channels
+						}
+					}
+					return nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nn, nil, l)
+			d := foo.NotificationDelivery{Notification: foo.Notification{ID: uuid.New(), UserID: "u1"}, Attempt: tt.attempt}
+			if err := svc.DeliverNotification(context.Background(), d); err != nil {
+				t.Fatalf("DeliverNotification() unexpected error %s", err)
+			}
+			if !reflect.DeepEqual(gotSent, tt.wantSent) {
+				t.Errorf("DeliverNotification() sent = %v, want %v", gotSent, tt.wantSent)
+			}
+			if !reflect.DeepEqual(gotHeld, tt.wantHeld) {
+				t.Errorf("DeliverNotification() held = %v, want %v", gotHeld, tt.wantHeld)
+			}
+			if !reflect.DeepEqual(gotRetried, tt.wantRetried) {
+				t.Errorf("DeliverNotification() retried = %v, want %v", gotRetried, tt.wantRetried)
+			}
+		})
+	}
+}
+
+type notifierFunc func(ctx context.Context, n foo.Notification, p foo.NotificationPreferences) error
+
+func (f notifierFunc) Notify(ctx context.Context, n foo.Notification, p foo.NotificationPreferences) error {
+	return f(ctx, n, p)
+}
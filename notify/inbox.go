@@ -0,0 +1,26 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/kudarap/foo"
+)
+
+// InboxStore represents a storage of user in-app notifications.
+type InboxStore interface {
+	AddNotification(ctx context.Context, n foo.Notification) error
+}
+
+// Inbox represents a notifier that adds notifications to the user in-app inbox.
+type Inbox struct {
+	store InboxStore
+}
+
+// NewInbox creates new instance of in-app inbox notifier on store.
+func NewInbox(s InboxStore) *Inbox {
+	return &Inbox{store: s}
+}
+
+func (i *Inbox) Notify(ctx context.Context, n foo.Notification, _ foo.NotificationPreferences) error {
+	return i.store.AddNotification(ctx, n)
+}
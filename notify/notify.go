@@ -0,0 +1,20 @@
+// Package notify provides foo.Notifier implementations over email, webhook
+// and the in-app inbox.
+package notify
+
+import "time"
+
+// Config represents notifiers config.
+type Config struct {
+	// SMTPAddr is the host:port of the mail server, email notifications are
+	// disabled when empty. Username is optional for servers without auth.
+	SMTPAddr     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	WebhookTimeout time.Duration
+}
+
+// defaultTimeout is used when a notifier timeout is not set.
+const defaultTimeout = 10 * time.Second
@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"github.com/kudarap/foo"
+)
+
+// SMTP represents a notifier that emails notifications through a mail server,
+// STARTTLS is used when the server supports it.
+type SMTP struct {
+	addr string
+	host string
+	// from is the message header sender and sender is its envelope address.
+	from    string
+	sender  string
+	auth    smtp.Auth
+	timeout time.Duration
+}
+
+// NewSMTP creates new instance of email notifier on mail server addr.
+func NewSMTP(addr, username, password, from string) (*SMTP, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse smtp addr: %s", err)
+	}
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse from address: %s", err)
+	}
+	s := &SMTP{addr: addr, host: host, from: sender.String(), sender: sender.Address, timeout: defaultTimeout}
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s, nil
+}
+
+func (s *SMTP) Notify(ctx context.Context, n foo.Notification, p foo.NotificationPreferences) error {
+	if p.Email == "" {
+		return errors.New("email address is not set")
+	}
+
+	d := net.Dialer{Timeout: s.timeout}
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("could not dial smtp: %s", err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(s.timeout)
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("could not start smtp session: %s", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("could not start tls: %s", err)
+		}
+	}
+	if s.auth != nil {
+		if err = c.Auth(s.auth); err != nil {
+			return fmt.Errorf("could not authenticate: %s", err)
+		}
+	}
+	if err = c.Mail(s.sender); err != nil {
+		return fmt.Errorf("could not set sender: %s", err)
+	}
+	if err = c.Rcpt(p.Email); err != nil {
+		return fmt.Errorf("could not set recipient: %s", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("could not start message: %s", err)
+	}
+	if _, err = w.Write(s.message(n, p.Email)); err != nil {
+		return fmt.Errorf("could not write message: %s", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("could not send message: %s", err)
+	}
+	return c.Quit()
+}
+
+// message returns a plain text email of the notification.
+func (s *SMTP) message(n foo.Notification, to string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", n.Title))
+	fmt.Fprintf(&b, "Date: %s\r\n", n.CreatedAt.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <%s@%s>\r\n", n.ID, s.host)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(n.Body)
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
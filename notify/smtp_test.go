@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+)
+
+// fakeSMTP is an in-process mail server that accepts messages without auth
+// and records the last envelope and data it received.
+type fakeSMTP struct {
+	ln   net.Listener
+	got  chan fakeMail
+	code string
+}
+
+type fakeMail struct {
+	from, to, data string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSMTP{ln: ln, got: make(chan fakeMail, 1), code: "250"}
+	t.Cleanup(func() { ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTP) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.session(conn)
+	}
+}
+
+func (s *fakeSMTP) session(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost fake smtp")
+	var m fakeMail
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250 localhost")
+		case "MAIL":
+			m.from = strings.TrimPrefix(line, "MAIL FROM:")
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			m.to = strings.TrimPrefix(line, "RCPT TO:")
+			tp.PrintfLine(s.code + " recipient")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			b, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			m.data = string(b)
+			tp.PrintfLine("250 OK")
+			s.got <- m
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func TestSMTP_Notify(t *testing.T) {
+	srv := newFakeSMTP(t)
+	s, err := NewSMTP(srv.ln.Addr().String(), "", "", "Foo Alerts <alerts@foo.test>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := foo.Notification{
+		ID:        uuid.New(),
+		Title:     "Bout result: Jon Jones vs Daniel Cormier",
+		Body:      "Jon Jones defeated Daniel Cormier.",
+		CreatedAt: time.Now(),
+	}
+	p := foo.NotificationPreferences{Email: "fan@foo.test"}
+	if err = s.Notify(context.Background(), n, p); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	m := <-srv.got
+	if m.from != "<alerts@foo.test>" || m.to != "<fan@foo.test>" {
+		t.Errorf("Notify() envelope = %s -> %s, want alerts@foo.test -> fan@foo.test", m.from, m.to)
+	}
+	r := textproto.NewReader(bufio.NewReader(strings.NewReader(m.data)))
+	h, err := r.ReadMIMEHeader()
+	if err != nil {
+		t.Fatalf("could not read message header: %s", err)
+	}
+	if h.Get("Subject") != n.Title {
+		t.Errorf("Notify() subject = %q, want %q", h.Get("Subject"), n.Title)
+	}
+	if !strings.Contains(m.data, n.Body) {
+		t.Errorf("Notify() data = %q, want body %q", m.data, n.Body)
+	}
+}
+
+func TestSMTP_Notify_Rejected(t *testing.T) {
+	srv := newFakeSMTP(t)
+	srv.code = "550"
+	s, err := NewSMTP(srv.ln.Addr().String(), "", "", "alerts@foo.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := foo.NotificationPreferences{Email: "unknown@foo.test"}
+	if err = s.Notify(context.Background(), foo.Notification{}, p); err == nil {
+		t.Error("Notify() want error on rejected recipient")
+	}
+	if err = s.Notify(context.Background(), foo.Notification{}, foo.NotificationPreferences{}); err == nil {
+		t.Error("Notify() want error without email address")
+	}
+}
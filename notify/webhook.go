@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/kudarap/foo"
+)
+
+// Webhook represents a notifier that posts notifications as JSON to the user
+// webhook url, non-2xx responses are delivery failures. It also posts
+// foo.Webhook subscription events.
+type Webhook struct {
+	client *http.Client
+}
+
+// NewWebhook creates new instance of webhook notifier. It refuses to connect
+// to non-public addresses so user urls cannot reach internal services.
+func NewWebhook(timeout time.Duration) *Webhook {
+	return newWebhook(timeout, dialPublic)
+}
+
+// newWebhook creates webhook notifier whose connections are checked by
+// control, nil allows any address.
+func newWebhook(timeout time.Duration, control func(network, address string, c syscall.RawConn) error) *Webhook {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = (&net.Dialer{Control: control}).DialContext
+	return &Webhook{client: &http.Client{
+		Transport: t,
+		Timeout:   timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// dialPublic rejects connections to non-public addresses. It runs after name
+// resolution so hosts resolving to internal addresses are rejected as well.
+func dialPublic(network, address string, _ syscall.RawConn) error {
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !foo.PublicIP(ap.Addr()) {
+		return fmt.Errorf("address %s is not public", ap.Addr())
+	}
+	return nil
+}
+
+func (w *Webhook) Notify(ctx context.Context, n foo.Notification, p foo.NotificationPreferences) error {
+	if p.WebhookURL == "" {
+		return errors.New("webhook url is not set")
+	}
+	b, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	h := http.Header{}
+	h.Set("Content-Type", "application/json")
+	status, err := w.Post(ctx, p.WebhookURL, h, b)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status > 299 {
+		return fmt.Errorf("webhook responded with status %d", status)
+	}
+	return nil
+}
+
+// Post posts body to url and returns the response status code. Redirects are
+// not followed so receivers cannot bounce signed requests elsewhere.
+func (w *Webhook) Post(ctx context.Context, url string, header http.Header, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("could not create request: %s", err)
+	}
+	req.Header = header.Clone()
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("could not post webhook: %s", err)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 1<<10))
+	return res.StatusCode, nil
+}
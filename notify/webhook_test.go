@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+)
+
+func TestWebhook_Notify(t *testing.T) {
+	var got foo.Notification
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("webhook request = %s %s, want POST application/json", r.Method, r.Header.Get("Content-Type"))
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	w := newWebhook(0, nil)
+	n := foo.Notification{ID: uuid.New(), Kind: foo.NotificationBoutScheduled, Title: "Bout scheduled"}
+	p := foo.NotificationPreferences{WebhookURL: srv.URL}
+	if err := w.Notify(context.Background(), n, p); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if got.ID != n.ID || got.Title != n.Title {
+		t.Errorf("Notify() posted %+v, want %+v", got, n)
+	}
+
+	status = http.StatusInternalServerError
+	if err := w.Notify(context.Background(), n, p); err == nil {
+		t.Error("Notify() want error on non-2xx response")
+	}
+}
+
+func TestWebhook_Post(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/moved" {
+			http.Redirect(w, r, "/elsewhere", http.StatusTemporaryRedirect)
+			return
+		}
+		if r.Header.Get(foo.WebhookHeaderSignature) != "sha256=abc" {
+			t.Errorf("webhook signature header = %q, want sha256=abc", r.Header.Get(foo.WebhookHeaderSignature))
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	w := newWebhook(0, nil)
+	h := http.Header{}
+	h.Set(foo.WebhookHeaderSignature, "sha256=abc")
+	status, err := w.Post(context.Background(), srv.URL, h, []byte(`{}`))
+	if err != nil || status != http.StatusAccepted {
+		t.Errorf("Post() = %d, %v, want %d", status, err, http.StatusAccepted)
+	}
+	status, err = w.Post(context.Background(), srv.URL+"/moved", h, []byte(`{}`))
+	if err != nil || status != http.StatusTemporaryRedirect {
+		t.Errorf("Post() redirect = %d, %v, want %d not followed", status, err, http.StatusTemporaryRedirect)
+	}
+}
+
+func TestNewWebhook_privateAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("webhook posted to loopback address")
+	}))
+	defer srv.Close()
+
+	if _, err := NewWebhook(0).Post(context.Background(), srv.URL, http.Header{}, []byte(`{}`)); err == nil {
+		t.Error("Post() want error on loopback address")
+	}
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kudarap/foo"
+)
+
+const boutColumns = `id, red_fighter_id, blue_fighter_id, weight_class, date, event_id, result, method,
+	round, time_seconds, created_at, updated_at`
+
+// scanBout scans bout columns from a row followed by extra destinations.
+func scanBout(row pgx.Row, b *foo.Bout, extra ...interface{}) error {
+	var date pgtype.Date
+	dest := []interface{}{
+		&b.ID, &b.RedFighterID, &b.BlueFighterID, &b.WeightClass, &date, &b.EventID, &b.Result, &b.Method,
+		&b.Round, &b.TimeSeconds, &b.CreatedAt, &b.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	b.Date = foo.Date{Time: date.Time}
+	return nil
+}
+
+func (c *Client) Bout(ctx context.Context, id uuid.UUID) (*foo.Bout, error) {
+	var b foo.Bout
+	row := c.db.QueryRow(ctx, `SELECT `+boutColumns+` FROM bouts WHERE id=$1`, id.String())
+	if err := scanBout(row, &b); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrBoutNotFound
+		}
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Client) FighterBouts(ctx context.Context, fighterID uuid.UUID) ([]foo.Bout, error) {
+	rows, err := c.db.Query(ctx, `
+		SELECT `+boutColumns+` FROM bouts
+		WHERE red_fighter_id=$1 OR blue_fighter_id=$1
+		ORDER BY date DESC, created_at DESC`, fighterID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bb []foo.Bout
+	for rows.Next() {
+		var b foo.Bout
+		if err = scanBout(rows, &b); err != nil {
+			return nil, err
+		}
+		bb = append(bb, b)
+	}
+	return bb, rows.Err()
+}
+
+func (c *Client) CompletedBouts(ctx context.Context, wc foo.WeightClass) ([]foo.Bout, error) {
+	rows, err := c.db.Query(ctx, `
+		SELECT `+boutColumns+` FROM bouts
+		WHERE weight_class=$1 AND result <> ''
+		ORDER BY date, id`, wc)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bb []foo.Bout
+	for rows.Next() {
+		var b foo.Bout
+		if err = scanBout(rows, &b); err != nil {
+			return nil, err
+		}
+		bb = append(bb, b)
+	}
+	return bb, rows.Err()
+}
+
+func (c *Client) CreateBout(ctx context.Context, b *foo.Bout, mm ...foo.Message) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		row := tx.QueryRow(ctx, `
+			INSERT INTO bouts (id, red_fighter_id, blue_fighter_id, weight_class, date, result, method,
+				round, time_seconds)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING `+boutColumns,
+			b.ID.String(), b.RedFighterID.String(), b.BlueFighterID.String(), b.WeightClass, dateValue(&b.Date),
+			b.Result, b.Method, b.Round, b.TimeSeconds)
+		if err := scanBout(row, b); err != nil {
+			return err
+		}
+		return publish(ctx, tx, mm)
+	})
+}
+
+func (c *Client) UpdateBout(ctx context.Context, b *foo.Bout, mm ...foo.Message) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		row := tx.QueryRow(ctx, `
+			UPDATE bouts SET red_fighter_id=$2, blue_fighter_id=$3, weight_class=$4, date=$5, result=$6,
+				method=$7, round=$8, time_seconds=$9, updated_at=now()
+			WHERE id=$1
+			RETURNING `+boutColumns,
+			b.ID.String(), b.RedFighterID.String(), b.BlueFighterID.String(), b.WeightClass, dateValue(&b.Date),
+			b.Result, b.Method, b.Round, b.TimeSeconds)
+		if err := scanBout(row, b); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return foo.ErrBoutNotFound
+			}
+			return err
+		}
+		return publish(ctx, tx, mm)
+	})
+}
+
+func (c *Client) DeleteBout(ctx context.Context, id uuid.UUID, mm ...foo.Message) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `DELETE FROM bouts WHERE id=$1`, id.String())
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return foo.ErrBoutNotFound
+		}
+		return publish(ctx, tx, mm)
+	})
+}
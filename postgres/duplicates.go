@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kudarap/foo"
+)
+
+// fighterDuplicateThreshold is the minimum trigram similarity of fighter
+// names to be considered duplicates.
+const fighterDuplicateThreshold = 0.5
+
+// FighterDuplicates finds pairs of fighters with similar normalized first and
+// last names whose date of birth and nationality do not conflict, ranked by
+// matching date of birth, nationality and then name similarity. The name match
+// is served by the match_name trigram index.
+func (c *Client) FighterDuplicates(ctx context.Context, limit int) ([]foo.FighterDuplicate, error) {
+	query := `
+		WITH d AS (
+			SELECT a.id AS a_id, b.id AS b_id,
+				similarity(a.match_name, b.match_name) AS similarity,
+				a.date_of_birth IS NOT NULL AND a.date_of_birth = b.date_of_birth AS same_dob,
+				a.nationality <> '' AND a.nationality = b.nationality AS same_nationality
+			FROM fighters a
+			JOIN fighters b ON a.id < b.id AND a.match_name % b.match_name
+			WHERE a.deleted_at IS NULL AND b.deleted_at IS NULL
+				AND (a.date_of_birth IS NULL OR b.date_of_birth IS NULL OR a.date_of_birth = b.date_of_birth)
+				AND (a.nationality = '' OR b.nationality = '' OR a.nationality = b.nationality)
+			ORDER BY same_dob DESC, same_nationality DESC, similarity DESC, a.id, b.id
+			LIMIT $1
+		)
+		SELECT d.a_id, d.b_id, d.similarity, d.same_dob, d.same_nationality FROM d`
+
+	var dd []foo.FighterDuplicate
+	err := pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		// Applies duplicate threshold to % operator for this transaction only.
+		_, err := tx.Exec(ctx, `SELECT set_config('pg_trgm.similarity_threshold', $1::real::text, true)`,
+			fighterDuplicateThreshold)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, query, limit)
+		if err != nil {
+			return err
+		}
+		var ids []string
+		for rows.Next() {
+			var d foo.FighterDuplicate
+			err = rows.Scan(&d.Fighter.ID, &d.Duplicate.ID, &d.NameSimilarity, &d.SameDateOfBirth, &d.SameNationality)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			dd = append(dd, d)
+			ids = append(ids, d.Fighter.ID.String(), d.Duplicate.ID.String())
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil || len(dd) == 0 {
+			return err
+		}
+
+		rows, err = tx.Query(ctx, `SELECT `+fighterSelect()+` FROM fighters f`+fighterRecordJoin+`
+			WHERE f.id = ANY($1::uuid[])`, ids)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		fighters := map[uuid.UUID]foo.Fighter{}
+		for rows.Next() {
+			var f foo.Fighter
+			if err = scanFighter(rows, &f); err != nil {
+				return err
+			}
+			fighters[f.ID] = f
+		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		for i := range dd {
+			dd[i].Fighter = fighters[dd[i].Fighter.ID]
+			dd[i].Duplicate = fighters[dd[i].Duplicate.ID]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dd, nil
+}
+
+// MergeFighter moves duplicate bouts to the fighter, collapsing their overlapping
+// periods with the same team, removes the duplicate and leaves its id as an
+// alias of the fighter.
+func (c *Client) MergeFighter(ctx context.Context, id, duplicateID uuid.UUID, mm ...foo.Message) (*foo.Fighter, error) {
+	var fighter foo.Fighter
+	err := pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		// Locks both fighters in id order to avoid deadlocks with concurrent merges.
+		for _, fid := range sortedIDs(id, duplicateID) {
+			if _, err := fighterForUpdate(ctx, tx, fid, 0); err != nil {
+				return err
+			}
+		}
+
+		if err := mergeAffiliations(ctx, tx, id, duplicateID); err != nil {
+			return err
+		}
+		for _, q := range []string{
+			`UPDATE bouts SET red_fighter_id=$1, updated_at=now() WHERE red_fighter_id=$2`,
+			`UPDATE bouts SET blue_fighter_id=$1, updated_at=now() WHERE blue_fighter_id=$2`,
+			`UPDATE fighter_media SET fighter_id=$1 WHERE fighter_id=$2`,
+			`UPDATE fighter_affiliations SET fighter_id=$1, updated_at=now() WHERE fighter_id=$2`,
+			// Re-points aliases of the duplicate from earlier merges.
+			`UPDATE fighter_aliases SET fighter_id=$1 WHERE fighter_id=$2`,
+			`UPDATE fighter_slugs SET fighter_id=$1 WHERE fighter_id=$2`,
+			// Users following both fighters keep their follow of the fighter.
+			`INSERT INTO fighter_follows (tenant_id, user_id, fighter_id, created_at)
+				SELECT tenant_id, user_id, $1::uuid, created_at FROM fighter_follows WHERE fighter_id=$2
+				ON CONFLICT DO NOTHING`,
+			`INSERT INTO fighter_aliases (fighter_id, alias_id) VALUES ($1, $2)`,
+		} {
+			if _, err := tx.Exec(ctx, q, id.String(), duplicateID.String()); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM fighters WHERE id=$1`, duplicateID.String()); err != nil {
+			return err
+		}
+
+		row := tx.QueryRow(ctx, `
+			WITH f AS (
+				UPDATE fighters SET updated_at=now(), version=version+1
+				WHERE id=$1
+				RETURNING *
+			)
+			SELECT `+fighterSelect()+` FROM f`+fighterRecordJoin, id.String())
+		if err := scanFighter(row, &fighter); err != nil {
+			return err
+		}
+
+		ch := foo.NewFighterMergeChange(&fighter, duplicateID, foo.ActorFromContext(ctx))
+		if err := addFighterHistory(ctx, tx, ch, &fighter); err != nil {
+			return err
+		}
+		return publish(ctx, tx, mm)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &fighter, nil
+}
+
+// sortedIDs returns ids in ascending order.
+func sortedIDs(a, b uuid.UUID) []uuid.UUID {
+	if a.String() > b.String() {
+		a, b = b, a
+	}
+	return []uuid.UUID{a, b}
+}
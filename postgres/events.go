@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kudarap/foo"
+)
+
+const eventColumns = `id, name, venue, date, created_at, updated_at`
+
+func scanEvent(row pgx.Row, e *foo.Event) error {
+	var date pgtype.Date
+	if err := row.Scan(&e.ID, &e.Name, &e.Venue, &date, &e.CreatedAt, &e.UpdatedAt); err != nil {
+		return err
+	}
+	e.Date = foo.Date{Time: date.Time}
+	return nil
+}
+
+func (c *Client) Event(ctx context.Context, id uuid.UUID) (*foo.Event, error) {
+	var e foo.Event
+	row := c.db.QueryRow(ctx, `SELECT `+eventColumns+` FROM events WHERE id=$1`, id.String())
+	if err := scanEvent(row, &e); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := c.db.Query(ctx, `
+		SELECT `+boutColumns+`, card_segment, card_position FROM bouts
+		WHERE event_id=$1
+		ORDER BY card_segment = 'prelims', card_position`, id.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	e.Card = []foo.CardBout{}
+	for rows.Next() {
+		var b foo.Bout
+		var cb foo.CardBout
+		if err = scanBout(rows, &b, &cb.Segment, &cb.Position); err != nil {
+			return nil, err
+		}
+		cb.BoutID = b.ID
+		cb.Bout = &b
+		e.Card = append(e.Card, cb)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (c *Client) Events(ctx context.Context, q foo.EventQuery) ([]foo.Event, error) {
+	var where []string
+	var args []interface{}
+	if q.From != nil {
+		args = append(args, dateValue(q.From))
+		where = append(where, fmt.Sprintf("date >= $%d", len(args)))
+	}
+	if q.To != nil {
+		args = append(args, dateValue(q.To))
+		where = append(where, fmt.Sprintf("date <= $%d", len(args)))
+	}
+	args = append(args, q.Limit)
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT ` + eventColumns + ` FROM events`)
+	if len(where) != 0 {
+		sb.WriteString(" WHERE " + strings.Join(where, " AND "))
+	}
+	sb.WriteString(fmt.Sprintf(" ORDER BY date DESC, id LIMIT $%d", len(args)))
+
+	rows, err := c.db.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ee []foo.Event
+	for rows.Next() {
+		var e foo.Event
+		if err = scanEvent(rows, &e); err != nil {
+			return nil, err
+		}
+		ee = append(ee, e)
+	}
+	return ee, rows.Err()
+}
+
+func (c *Client) CreateEvent(ctx context.Context, e *foo.Event, mm ...foo.Message) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		row := tx.QueryRow(ctx, `
+			INSERT INTO events (name, venue, date) VALUES ($1, $2, $3)
+			RETURNING `+eventColumns,
+			e.Name, e.Venue, dateValue(&e.Date))
+		card := e.Card
+		if err := scanEvent(row, e); err != nil {
+			return err
+		}
+		e.Card = card
+		if err := setEventCard(ctx, tx, e); err != nil {
+			return err
+		}
+		return publish(ctx, tx, mm)
+	})
+}
+
+func (c *Client) UpdateEvent(ctx context.Context, e *foo.Event, mm ...foo.Message) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		row := tx.QueryRow(ctx, `
+			UPDATE events SET name=$2, venue=$3, date=$4, updated_at=now()
+			WHERE id=$1
+			RETURNING `+eventColumns,
+			e.ID.String(), e.Name, e.Venue, dateValue(&e.Date))
+		card := e.Card
+		if err := scanEvent(row, e); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return foo.ErrEventNotFound
+			}
+			return err
+		}
+		e.Card = card
+
+		_, err := tx.Exec(ctx, `
+			UPDATE bouts SET event_id=NULL, card_segment='', card_position=0, updated_at=now()
+			WHERE event_id=$1`, e.ID.String())
+		if err != nil {
+			return err
+		}
+		if err = setEventCard(ctx, tx, e); err != nil {
+			return err
+		}
+		return publish(ctx, tx, mm)
+	})
+}
+
+// setEventCard places card bouts on the event and moves their date to the event date.
+func setEventCard(ctx context.Context, tx pgx.Tx, e *foo.Event) error {
+	for i := range e.Card {
+		cb := &e.Card[i]
+		row := tx.QueryRow(ctx, `
+			UPDATE bouts SET event_id=$2, card_segment=$3, card_position=$4, date=$5, updated_at=now()
+			WHERE id=$1
+			RETURNING `+boutColumns,
+			cb.BoutID.String(), e.ID.String(), cb.Segment, cb.Position, dateValue(&e.Date))
+		var b foo.Bout
+		if err := scanBout(row, &b); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return foo.ErrBoutNotFound
+			}
+			return err
+		}
+		cb.Bout = &b
+	}
+	return nil
+}
+
+// DeleteEvent deletes an event and clears the card placement of its bouts.
+func (c *Client) DeleteEvent(ctx context.Context, id uuid.UUID) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+			UPDATE bouts SET event_id=NULL, card_segment='', card_position=0, updated_at=now()
+			WHERE event_id=$1`, id.String())
+		if err != nil {
+			return err
+		}
+		tag, err := tx.Exec(ctx, `DELETE FROM events WHERE id=$1`, id.String())
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return foo.ErrEventNotFound
+		}
+		return nil
+	})
+}
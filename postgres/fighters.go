@@ -3,24 +3,404 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kudarap/foo"
 )
 
-func (c *Client) Fighter(ctx context.Context, id uuid.UUID) (*foo.Fighter, error) {
+// fighterColumns lists fighters table columns in scanFighter order.
+var fighterColumns = []string{
+	"id", "slug", "first_name", "last_name", "nickname", "date_of_birth", "nationality",
+	"stance", "height_cm", "reach_cm", "weight_class", "created_at", "updated_at", "deleted_at",
+	"version",
+}
+
+// fighterSelect returns fighter select columns from fighters aliased as f joined
+// with fighterRecordJoin.
+func fighterSelect() string {
+	cc := make([]string, len(fighterColumns))
+	for i, c := range fighterColumns {
+		cc[i] = "f." + c
+	}
+	return strings.Join(cc, ", ") + ", r.wins, r.losses, r.draws, r.no_contests"
+}
+
+// fighterRecordJoin computes fighter professional record from completed bouts of
+// fighters aliased as f.
+var fighterRecordJoin = fighterRecordJoinOn("")
+
+// fighterRecordJoinOn is fighterRecordJoin counting only bouts matching the
+// extra condition.
+func fighterRecordJoinOn(cond string) string {
+	return `
+	LEFT JOIN LATERAL (
+		SELECT count(*) FILTER (WHERE o.result = 'win') AS wins,
+			count(*) FILTER (WHERE o.result = 'loss') AS losses,
+			count(*) FILTER (WHERE o.result = 'draw') AS draws,
+			count(*) FILTER (WHERE o.result = 'no_contest') AS no_contests
+		FROM (
+			SELECT result FROM bouts WHERE red_fighter_id = f.id AND result <> ''` + cond + `
+			UNION ALL
+			SELECT CASE result WHEN 'win' THEN 'loss' WHEN 'loss' THEN 'win' ELSE result END
+			FROM bouts WHERE blue_fighter_id = f.id AND result <> ''` + cond + `
+		) o
+	) r ON true`
+}
+
+// scanFighter scans fighter columns from a row followed by extra destinations.
+func scanFighter(row pgx.Row, f *foo.Fighter, extra ...interface{}) error {
+	var dob pgtype.Date
+	dest := []interface{}{
+		&f.ID, &f.Slug, &f.FirstName, &f.LastName, &f.Nickname, &dob, &f.Nationality,
+		&f.Stance, &f.HeightCM, &f.ReachCM, &f.WeightClass, &f.CreatedAt, &f.UpdatedAt, &f.DeletedAt,
+		&f.Version,
+		&f.Record.Wins, &f.Record.Losses, &f.Record.Draws, &f.Record.NoContests,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	f.DateOfBirth = nil
+	if dob.Valid {
+		f.DateOfBirth = &foo.Date{Time: dob.Time}
+	}
+	return nil
+}
+
+// dateValue returns nullable date parameter.
+func dateValue(d *foo.Date) pgtype.Date {
+	if d == nil {
+		return pgtype.Date{}
+	}
+	return pgtype.Date{Time: d.Time, Valid: true}
+}
+
+func (c *Client) Fighter(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
 	var fighter foo.Fighter
-	fighter.ID = id
-	err := c.db.
-		QueryRow(ctx, `SELECT id, first_name, last_name FROM fighters WHERE uuid=$1`, id.String()).
-		Scan(&fighter.ID, &fighter.FirstName, &fighter.LastName)
+	row := c.db.QueryRow(ctx, `SELECT `+fighterSelect()+` FROM fighters f`+fighterRecordJoin+`
+		WHERE f.id=COALESCE((SELECT fighter_id FROM fighter_aliases WHERE alias_id=$1), $1)
+			AND ($2 OR f.deleted_at IS NULL)`, id.String(), includeDeleted)
+	if err := scanFighter(row, &fighter); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrFighterNotFound
+		}
+		return nil, err
+	}
+
+	return &fighter, nil
+}
+
+// FightersByID returns fighters of given ids in no particular order, deleted
+// and unknown fighters are left out.
+func (c *Client) FightersByID(ctx context.Context, ids []uuid.UUID) ([]foo.Fighter, error) {
+	sids := make([]string, len(ids))
+	for i, id := range ids {
+		sids[i] = id.String()
+	}
+	rows, err := c.db.Query(ctx, `SELECT `+fighterSelect()+` FROM fighters f`+fighterRecordJoin+`
+		WHERE f.id = ANY($1::uuid[]) AND f.deleted_at IS NULL`, sids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ff []foo.Fighter
+	for rows.Next() {
+		var f foo.Fighter
+		if err = scanFighter(rows, &f); err != nil {
+			return nil, err
+		}
+		ff = append(ff, f)
+	}
+	return ff, rows.Err()
+}
+
+// fighterSortColumns maps sort fields to column and its type used for keyset comparison.
+var fighterSortColumns = map[string][2]string{
+	foo.FighterSortLastName:  {"last_name", "text"},
+	foo.FighterSortFirstName: {"first_name", "text"},
+	foo.FighterSortCreatedAt: {"created_at", "timestamptz"},
+}
+
+func (c *Client) Fighters(ctx context.Context, q foo.FighterQuery, after *foo.FighterCursor) ([]foo.Fighter, error) {
+	query, args, err := fighterListQuery(q, after)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, q.Limit)
+	query += fmt.Sprintf(" LIMIT $%d", len(args))
+
+	rows, err := c.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ff []foo.Fighter
+	for rows.Next() {
+		var f foo.Fighter
+		if err = scanFighter(rows, &f); err != nil {
+			return nil, err
+		}
+		ff = append(ff, f)
+	}
+	return ff, rows.Err()
+}
+
+// fighterListQuery returns fighter select query and its args filtered and sorted
+// by list options starting after the cursor when present.
+func fighterListQuery(q foo.FighterQuery, after *foo.FighterCursor) (string, []interface{}, error) {
+	col, ok := fighterSortColumns[q.SortField()]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown sort field: %s", q.Sort)
+	}
+	order, cmp := "ASC", ">"
+	if q.SortDesc() {
+		order, cmp = "DESC", "<"
+	}
+
+	where := []string{"f.deleted_at IS NULL"}
+	if q.Deleted {
+		where[0] = "f.deleted_at IS NOT NULL"
+	}
+	var args []interface{}
+	if q.Name != "" {
+		args = append(args, strings.ToLower(escapeLike(q.Name))+"%")
+		where = append(where, fmt.Sprintf("(lower(f.first_name) LIKE $%[1]d OR lower(f.last_name) LIKE $%[1]d)", len(args)))
+	}
+	if after != nil {
+		args = append(args, after.Value, after.ID.String())
+		where = append(where, fmt.Sprintf("(f.%s, f.id) %s ($%d::%s, $%d)",
+			col[0], cmp, len(args)-1, col[1], len(args)))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT ` + fighterSelect() + ` FROM fighters f` + fighterRecordJoin)
+	sb.WriteString(" WHERE " + strings.Join(where, " AND "))
+	sb.WriteString(fmt.Sprintf(" ORDER BY f.%[1]s %[2]s, f.id %[2]s", col[0], order))
+	return sb.String(), args, nil
+}
+
+// fighterExportFetchSize is the number of fighters fetched from export cursor at a time.
+const fighterExportFetchSize = 500
+
+// ExportFighters streams every fighter matching list options to fn using a
+// cursor, so only a batch of fighters is held in memory at a time.
+func (c *Client) ExportFighters(ctx context.Context, q foo.FighterQuery, fn func(foo.Fighter) error) error {
+	query, args, err := fighterListQuery(q, nil)
+	if err != nil {
+		return err
+	}
+
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DECLARE fighters_export NO SCROLL CURSOR FOR `+query, args...); err != nil {
+			return err
+		}
+		for {
+			rows, err := tx.Query(ctx, fmt.Sprintf(`FETCH %d FROM fighters_export`, fighterExportFetchSize))
+			if err != nil {
+				return err
+			}
+			var n int
+			for rows.Next() {
+				var f foo.Fighter
+				if err = scanFighter(rows, &f); err != nil {
+					rows.Close()
+					return err
+				}
+				if err = fn(f); err != nil {
+					rows.Close()
+					return err
+				}
+				n++
+			}
+			rows.Close()
+			if err = rows.Err(); err != nil {
+				return err
+			}
+			if n < fighterExportFetchSize {
+				return nil
+			}
+		}
+	})
+}
+
+func (c *Client) CreateFighter(ctx context.Context, f *foo.Fighter) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		return insertFighter(ctx, tx, pgtype.UUID{}, f)
+	})
+}
+
+// insertFighter inserts fighter with id or a generated id when null and adds
+// its history, returns pgx.ErrNoRows when the id is already taken.
+func insertFighter(ctx context.Context, tx pgx.Tx, id pgtype.UUID, f *foo.Fighter) error {
+	slug, err := fighterSlug(ctx, tx, uuid.Nil, "", f)
+	if err != nil {
+		return err
+	}
+	row := tx.QueryRow(ctx, `
+		WITH f AS (
+			INSERT INTO fighters (id, first_name, last_name, nickname, date_of_birth, nationality,
+				stance, height_cm, reach_cm, weight_class, slug)
+			VALUES (COALESCE($1, uuid_generate_v4()), $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (id) DO NOTHING
+			RETURNING *
+		)
+		SELECT `+fighterSelect()+` FROM f`+fighterRecordJoin,
+		id, f.FirstName, f.LastName, f.Nickname, dateValue(f.DateOfBirth), f.Nationality,
+		f.Stance, f.HeightCM, f.ReachCM, f.WeightClass, slug)
+	if err = scanFighter(row, f); err != nil {
+		return err
+	}
+	if err = addFighterSlug(ctx, tx, f.ID, f.Slug); err != nil {
+		return err
+	}
+	return addFighterChange(ctx, tx, foo.FighterActionCreate, nil, f)
+}
+
+func (c *Client) UpdateFighter(ctx context.Context, f *foo.Fighter) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		before, err := fighterForUpdate(ctx, tx, f.ID, f.Version)
+		if err != nil {
+			return err
+		}
+		return updateFighter(ctx, tx, before, f)
+	})
+}
+
+// updateFighter updates a locked fighter and adds its history. Name change
+// that changes its slug keeps the previous slug as an alias.
+func updateFighter(ctx context.Context, tx pgx.Tx, before, f *foo.Fighter) error {
+	slug, err := fighterSlug(ctx, tx, before.ID, before.Slug, f)
 	if err != nil {
+		return err
+	}
+	row := tx.QueryRow(ctx, `
+		WITH f AS (
+			UPDATE fighters SET first_name=$2, last_name=$3, nickname=$4, date_of_birth=$5, nationality=$6,
+				stance=$7, height_cm=$8, reach_cm=$9, weight_class=$10, slug=$11, updated_at=now(),
+				version=version+1
+			WHERE id=$1
+			RETURNING *
+		)
+		SELECT `+fighterSelect()+` FROM f`+fighterRecordJoin,
+		before.ID.String(), f.FirstName, f.LastName, f.Nickname, dateValue(f.DateOfBirth), f.Nationality,
+		f.Stance, f.HeightCM, f.ReachCM, f.WeightClass, slug)
+	if err = scanFighter(row, f); err != nil {
+		return err
+	}
+	if err = addFighterSlug(ctx, tx, f.ID, f.Slug); err != nil {
+		return err
+	}
+	return addFighterChange(ctx, tx, foo.FighterActionUpdate, before, f)
+}
+
+func (c *Client) DeleteFighter(ctx context.Context, id uuid.UUID, version int) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		before, err := fighterForUpdate(ctx, tx, id, version)
+		if err != nil {
+			return err
+		}
+		var after foo.Fighter
+		row := tx.QueryRow(ctx, `
+			WITH f AS (
+				UPDATE fighters SET deleted_at=now(), version=version+1
+				WHERE id=$1
+				RETURNING *
+			)
+			SELECT `+fighterSelect()+` FROM f`+fighterRecordJoin, id.String())
+		if err = scanFighter(row, &after); err != nil {
+			return err
+		}
+		return addFighterChange(ctx, tx, foo.FighterActionDelete, before, &after)
+	})
+}
+
+// fighterForUpdate returns a locked fighter that is not deleted for writing,
+// ErrFighterConflict when version is non-zero and does not match.
+func fighterForUpdate(ctx context.Context, tx pgx.Tx, id uuid.UUID, version int) (*foo.Fighter, error) {
+	var f foo.Fighter
+	row := tx.QueryRow(ctx, `SELECT `+fighterSelect()+` FROM fighters f`+fighterRecordJoin+`
+		WHERE f.id=$1 AND f.deleted_at IS NULL
+		FOR UPDATE OF f`, id.String())
+	if err := scanFighter(row, &f); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, foo.ErrFighterNotFound
 		}
 		return nil, err
 	}
+	if version != 0 && version != f.Version {
+		return nil, foo.ErrFighterConflict
+	}
+	return &f, nil
+}
 
+func (c *Client) RestoreFighter(ctx context.Context, id uuid.UUID) (*foo.Fighter, error) {
+	var fighter foo.Fighter
+	err := pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		var before foo.Fighter
+		row := tx.QueryRow(ctx, `SELECT `+fighterSelect()+` FROM fighters f`+fighterRecordJoin+`
+			WHERE f.id=$1 AND f.deleted_at IS NOT NULL
+			FOR UPDATE OF f`, id.String())
+		if err := scanFighter(row, &before); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return foo.ErrFighterNotFound
+			}
+			return err
+		}
+		row = tx.QueryRow(ctx, `
+			WITH f AS (
+				UPDATE fighters SET deleted_at=NULL, updated_at=now(), version=version+1
+				WHERE id=$1
+				RETURNING *
+			)
+			SELECT `+fighterSelect()+` FROM f`+fighterRecordJoin, id.String())
+		if err := scanFighter(row, &fighter); err != nil {
+			return err
+		}
+		return addFighterChange(ctx, tx, foo.FighterActionRestore, &before, &fighter)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return &fighter, nil
 }
+
+// PurgeFighters removes fighters deleted before the given time along with their
+// media and returns the media blob keys. Fighters with bouts stay deleted
+// instead so their opponents records are kept intact.
+func (c *Client) PurgeFighters(ctx context.Context, before time.Time) (*foo.FighterPurge, error) {
+	var p foo.FighterPurge
+	err := c.db.QueryRow(ctx, `
+		WITH expired AS (
+			SELECT f.id, EXISTS (
+				SELECT 1 FROM bouts WHERE red_fighter_id = f.id OR blue_fighter_id = f.id
+			) AS has_bouts
+			FROM fighters f WHERE f.deleted_at < $1
+		), d AS (
+			DELETE FROM fighters f USING expired e
+			WHERE f.id = e.id AND NOT e.has_bouts
+			RETURNING f.id
+		)
+		SELECT (SELECT count(*) FROM d), (SELECT count(*) FROM expired WHERE has_bouts),
+			ARRAY(
+				SELECT k FROM fighter_media m
+				JOIN d ON d.id = m.fighter_id
+				CROSS JOIN LATERAL unnest(ARRAY[m.blob_key, m.thumbnail_key]) k
+				WHERE k <> ''
+			)`,
+		before).Scan(&p.Purged, &p.KeptWithBouts, &p.BlobKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// escapeLike escapes LIKE pattern special characters.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
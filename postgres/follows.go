@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kudarap/foo"
+)
+
+// CreateFollow follows a fighter that is not deleted, following a followed
+// fighter returns the original follow time.
+func (c *Client) CreateFollow(ctx context.Context, userID string, f *foo.Follow) error {
+	err := c.db.QueryRow(ctx, `
+		WITH f AS (
+			SELECT id FROM fighters WHERE id=$2 AND deleted_at IS NULL
+		), i AS (
+			INSERT INTO fighter_follows (user_id, fighter_id)
+			SELECT $1, id FROM f
+			ON CONFLICT DO NOTHING
+			RETURNING created_at
+		)
+		SELECT created_at FROM i
+		UNION ALL
+		SELECT ff.created_at FROM fighter_follows ff JOIN f ON ff.fighter_id = f.id WHERE ff.user_id=$1
+		LIMIT 1`, userID, f.Fighter.ID.String()).Scan(&f.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return foo.ErrFighterNotFound
+	}
+	return err
+}
+
+// DeleteFollow unfollows a fighter, id of a merged fighter resolves to the
+// fighter it was merged into.
+func (c *Client) DeleteFollow(ctx context.Context, userID string, fighterID uuid.UUID) error {
+	_, err := c.db.Exec(ctx, `
+		DELETE FROM fighter_follows
+		WHERE user_id=$1
+			AND fighter_id=COALESCE((SELECT fighter_id FROM fighter_aliases WHERE alias_id=$2), $2)`,
+		userID, fighterID.String())
+	return err
+}
+
+func (c *Client) Follows(ctx context.Context, userID string) ([]foo.Follow, error) {
+	rows, err := c.db.Query(ctx, `SELECT `+fighterSelect()+`, ff.created_at
+		FROM fighter_follows ff
+		JOIN fighters f ON f.id = ff.fighter_id`+fighterRecordJoin+`
+		WHERE ff.user_id=$1 AND f.deleted_at IS NULL
+		ORDER BY ff.created_at DESC, f.id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ff []foo.Follow
+	for rows.Next() {
+		var f foo.Follow
+		if err = scanFighter(rows, &f.Fighter, &f.CreatedAt); err != nil {
+			return nil, err
+		}
+		ff = append(ff, f)
+	}
+	return ff, rows.Err()
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kudarap/foo"
+)
+
+// addFighterChange appends a fighter change made by the context actor to
+// fighter history and its domain event to the outbox on the write transaction.
+func addFighterChange(ctx context.Context, tx pgx.Tx, action foo.FighterAction, before, after *foo.Fighter) error {
+	ch := foo.NewFighterChange(action, before, after, foo.ActorFromContext(ctx))
+	return addFighterHistory(ctx, tx, ch, after)
+}
+
+// addFighterHistory appends a fighter change to fighter history and its domain
+// event to the outbox on the write transaction.
+func addFighterHistory(ctx context.Context, tx pgx.Tx, ch foo.FighterChange, after *foo.Fighter) error {
+	diff, err := json.Marshal(ch.Diff)
+	if err != nil {
+		return err
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO fighter_history (fighter_id, action, diff, user_id, request_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`,
+		ch.FighterID.String(), ch.Action, diff, ch.UserID, ch.RequestID).Scan(&ch.ID, &ch.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return publish(ctx, tx, []foo.Message{foo.NewFighterEvent(ch, *after)})
+}
+
+func (c *Client) FighterHistory(ctx context.Context, fighterID uuid.UUID) ([]foo.FighterChange, error) {
+	rows, err := c.db.Query(ctx, `
+		SELECT id, fighter_id, action, diff, user_id, request_id, created_at
+		FROM fighter_history
+		WHERE fighter_id IN (
+			-- Includes history of merged fighters resolving alias ids to the fighter.
+			WITH t AS (SELECT COALESCE((SELECT fighter_id FROM fighter_aliases WHERE alias_id=$1), $1) AS id)
+			SELECT id FROM t
+			UNION ALL
+			SELECT a.alias_id FROM fighter_aliases a, t WHERE a.fighter_id = t.id
+		)
+		ORDER BY id DESC`, fighterID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hh []foo.FighterChange
+	for rows.Next() {
+		var h foo.FighterChange
+		var diff []byte
+		if err = rows.Scan(&h.ID, &h.FighterID, &h.Action, &diff, &h.UserID, &h.RequestID, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(diff, &h.Diff); err != nil {
+			return nil, err
+		}
+		hh = append(hh, h)
+	}
+	return hh, rows.Err()
+}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kudarap/foo"
+)
+
+const fighterImportColumns = `id, format, status, message, total_rows, processed_rows, created_rows,
+	updated_rows, failed_rows, user_id, request_id, created_at, updated_at`
+
+func (c *Client) CreateFighterImport(ctx context.Context, imp *foo.FighterImport, data []byte, mm ...foo.Message) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		row := tx.QueryRow(ctx, `
+			INSERT INTO fighter_imports (id, format, status, data, user_id, request_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING `+fighterImportColumns,
+			imp.ID.String(), imp.Format, imp.Status, data, imp.UserID, imp.RequestID)
+		if err := scanFighterImport(row, imp); err != nil {
+			return err
+		}
+		return publish(ctx, tx, mm)
+	})
+}
+
+func scanFighterImport(row pgx.Row, imp *foo.FighterImport) error {
+	return row.Scan(&imp.ID, &imp.Format, &imp.Status, &imp.Message, &imp.TotalRows, &imp.ProcessedRows,
+		&imp.CreatedRows, &imp.UpdatedRows, &imp.FailedRows, &imp.UserID, &imp.RequestID,
+		&imp.CreatedAt, &imp.UpdatedAt)
+}
+
+func (c *Client) FighterImport(ctx context.Context, id uuid.UUID) (*foo.FighterImport, error) {
+	var imp foo.FighterImport
+	row := c.db.QueryRow(ctx, `SELECT `+fighterImportColumns+` FROM fighter_imports WHERE id=$1`, id.String())
+	if err := scanFighterImport(row, &imp); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrFighterImportNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := c.db.Query(ctx, `
+		SELECT line, fields, message FROM fighter_import_errors
+		WHERE import_id=$1
+		ORDER BY line`, id.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e foo.ImportRowError
+		if err = rows.Scan(&e.Row, &e.Fields, &e.Message); err != nil {
+			return nil, err
+		}
+		imp.Errors = append(imp.Errors, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &imp, nil
+}
+
+func (c *Client) FighterImportData(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	var data []byte
+	err := c.db.QueryRow(ctx, `SELECT data FROM fighter_imports WHERE id=$1`, id.String()).Scan(&data)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrFighterImportNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) UpdateFighterImportStatus(ctx context.Context, imp *foo.FighterImport) error {
+	row := c.db.QueryRow(ctx, `
+		UPDATE fighter_imports SET status=$2, message=$3, total_rows=$4, updated_at=now()
+		WHERE id=$1
+		RETURNING `+fighterImportColumns,
+		imp.ID.String(), imp.Status, imp.Message, imp.TotalRows)
+	if err := scanFighterImport(row, imp); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return foo.ErrFighterImportNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ImportFighters upserts import rows, fighter with id that is deleted is
+// recorded as a row error since it must be restored first. Ids taken by
+// another tenant are reported the same way without revealing the fighter.
+// Each row is upserted on its own savepoint so a row rejected by the database
+// is recorded as a row error instead of failing the whole batch.
+func (c *Client) ImportFighters(
+	ctx context.Context,
+	importID uuid.UUID,
+	rows []foo.FighterImportRow,
+	errs []foo.ImportRowError,
+	processed int,
+) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		var created, updated int
+		for _, r := range rows {
+			var isNew bool
+			err := pgx.BeginFunc(ctx, tx, func(tx pgx.Tx) (err error) {
+				isNew, err = importFighter(ctx, tx, r.Fighter)
+				return err
+			})
+			var pgErr *pgconn.PgError
+			switch {
+			case err == nil && isNew:
+				created++
+			case err == nil:
+				updated++
+			case errors.Is(err, pgx.ErrNoRows):
+				errs = append(errs, foo.ImportRowError{Row: r.Row,
+					Message: fmt.Sprintf("fighter %s is deleted or unavailable", r.Fighter.ID)})
+			case errors.As(err, &pgErr) && isRowRejected(pgErr):
+				errs = append(errs, foo.ImportRowError{Row: r.Row, Message: pgErr.Message})
+			default:
+				return err
+			}
+		}
+
+		for _, e := range errs {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO fighter_import_errors (import_id, line, fields, message)
+				VALUES ($1, $2, $3, $4)`,
+				importID.String(), e.Row, e.Fields, e.Message)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.Exec(ctx, `
+			UPDATE fighter_imports SET processed_rows=$2, created_rows=created_rows+$3,
+				updated_rows=updated_rows+$4, failed_rows=failed_rows+$5, updated_at=now()
+			WHERE id=$1`,
+			importID.String(), processed, created, updated, len(errs))
+		return err
+	})
+}
+
+// importFighter updates fighter with existing id or creates it and reports
+// whether it was created, id of a merged fighter updates the fighter it was
+// merged into. Returns pgx.ErrNoRows when the id is deleted or unavailable.
+func importFighter(ctx context.Context, tx pgx.Tx, f foo.Fighter) (bool, error) {
+	if f.ID == uuid.Nil {
+		return true, insertFighter(ctx, tx, pgtype.UUID{}, &f)
+	}
+
+	err := tx.QueryRow(ctx, `SELECT COALESCE((SELECT fighter_id FROM fighter_aliases WHERE alias_id=$1), $1)`,
+		f.ID.String()).Scan(&f.ID)
+	if err != nil {
+		return false, err
+	}
+
+	before, err := fighterForUpdate(ctx, tx, f.ID, 0)
+	if err == nil {
+		return false, updateFighter(ctx, tx, before, &f)
+	}
+	if !errors.Is(err, foo.ErrFighterNotFound) {
+		return false, err
+	}
+	return true, insertFighter(ctx, tx, pgtype.UUID{Bytes: f.ID, Valid: true}, &f)
+}
+
+// isRowRejected reports whether pgErr is a data exception or integrity
+// constraint violation of the row rather than a failure of the database.
+func isRowRejected(pgErr *pgconn.PgError) bool {
+	return strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/kudarap/foo/worker"
+)
+
+// job queue settings.
+const (
+	jobPollInterval = time.Second
+	jobBatchSize    = 10
+	// jobLeaseTimeout is how long a claimed job is hidden from other listeners
+	// before it can be retried.
+	jobLeaseTimeout = 5 * time.Minute
+	jobMaxAttempts  = 5
+	// jobSweepInterval is how often jobs out of attempts are marked failed and
+	// failed jobs older than jobFailedRetention are purged.
+	jobSweepInterval   = time.Minute
+	jobFailedRetention = 7 * 24 * time.Hour
+)
+
+// Listen polls jobs of topics and sends them to the worker queue until stopped. Jobs
+// are deleted when done, failed jobs are retried after lease timeout up to max attempts
+// and then kept as failed until purged.
+func (c *Client) Listen(topics []string, q chan<- worker.Job, stop chan struct{}) error {
+	go func() {
+		ctx := context.Background()
+		var swept time.Time
+		for {
+			if time.Since(swept) >= jobSweepInterval {
+				if err := c.sweepJobs(ctx, topics); err != nil {
+					c.logger.Error("could not sweep jobs", "err", err)
+				}
+				swept = time.Now()
+			}
+
+			jobs, err := c.claimJobs(ctx, topics)
+			if err != nil {
+				c.logger.Error("could not claim jobs", "err", err)
+			}
+
+			if len(jobs) == 0 {
+				select {
+				case <-stop:
+					c.logger.Info("job listener stopped")
+					return
+				case <-time.After(jobPollInterval):
+					continue
+				}
+			}
+			for _, j := range jobs {
+				select {
+				case <-stop:
+					c.logger.Info("job listener stopped")
+					return
+				case q <- j:
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (c *Client) claimJobs(ctx context.Context, topics []string) ([]worker.Job, error) {
+	rows, err := c.db.Query(ctx, `
+		WITH claimed AS (
+			UPDATE jobs SET attempts = attempts + 1, locked_until = now() + $3::interval
+			WHERE id IN (
+				SELECT id FROM jobs
+				WHERE topic = ANY($1)
+				  AND attempts < $4
+				  AND failed_at IS NULL
+				  AND (locked_until IS NULL OR locked_until < now())
+				ORDER BY id
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, topic, payload, tenant_id, attempts
+		)
+		SELECT id, topic, payload, COALESCE(tenant_id, ''), attempts >= $4 FROM claimed ORDER BY id`,
+		topics, jobBatchSize, jobLeaseTimeout, jobMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []worker.Job
+	for rows.Next() {
+		var id int64
+		var j worker.Job
+		if err = rows.Scan(&id, &j.Topic, &j.Payload, &j.Tenant, &j.LastAttempt); err != nil {
+			return nil, err
+		}
+		j.Done = func() error {
+			_, err := c.db.Exec(context.Background(), `DELETE FROM jobs WHERE id=$1`, id)
+			return err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, rows.Err()
+}
+
+// sweepJobs marks jobs of topics whose last attempt expired as failed and
+// purges failed jobs past retention.
+func (c *Client) sweepJobs(ctx context.Context, topics []string) error {
+	rows, err := c.db.Query(ctx, `
+		UPDATE jobs SET failed_at = now()
+		WHERE topic = ANY($1)
+		  AND attempts >= $2
+		  AND failed_at IS NULL
+		  AND locked_until < now()
+		RETURNING id, topic, payload, COALESCE(tenant_id, '')`,
+		topics, jobMaxAttempts)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var topic, tenant string
+		var payload []byte
+		if err = rows.Scan(&id, &topic, &payload, &tenant); err != nil {
+			return err
+		}
+		c.logger.Error("job failed", "id", id, "topic", topic, "tenant", tenant,
+			"attempts", jobMaxAttempts, "payload", string(payload))
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	tag, err := c.db.Exec(ctx, `DELETE FROM jobs WHERE failed_at < $1`, time.Now().Add(-jobFailedRetention))
+	if err != nil {
+		return err
+	}
+	if n := tag.RowsAffected(); n > 0 {
+		c.logger.Info("failed jobs purged", "count", n)
+	}
+	return nil
+}
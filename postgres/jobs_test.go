@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestClient_sweepJobs(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	topic := "test.sweep." + uuid.NewString()
+	t.Cleanup(func() {
+		c.db.Exec(ctx, `DELETE FROM jobs WHERE topic=$1`, topic)
+	})
+
+	past := time.Now().Add(-time.Minute)
+	_, err := c.db.Exec(ctx, `
+		INSERT INTO jobs (topic, payload, attempts, locked_until, failed_at) VALUES
+			($1, '{"n":1}', $2, $3, NULL),
+			($1, '{"n":2}', $2, $4, NULL),
+			($1, '{"n":3}', $2 - 1, $3, NULL),
+			($1, '{"n":4}', $2, $3, $5)`,
+		topic, jobMaxAttempts, past, time.Now().Add(time.Minute), time.Now().Add(-jobFailedRetention-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = c.sweepJobs(ctx, []string{topic}); err != nil {
+		t.Fatalf("sweepJobs() unexpected error %s", err)
+	}
+
+	rows, err := c.db.Query(ctx, `SELECT payload, failed_at IS NOT NULL FROM jobs WHERE topic=$1 ORDER BY id`, topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var got []bool
+	for rows.Next() {
+		var payload struct{ N int }
+		var failed bool
+		if err = rows.Scan(&payload, &failed); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, failed)
+	}
+	// Expired last attempt is failed, leased and retryable jobs are kept
+	// pending and the old failed job is purged.
+	want := []bool{true, false, false}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("sweepJobs() failed = %v, want %v", got, want)
+	}
+
+	jobs, err := c.claimJobs(ctx, []string{topic})
+	if err != nil {
+		t.Fatalf("claimJobs() unexpected error %s", err)
+	}
+	if len(jobs) != 1 || !jobs[0].LastAttempt {
+		t.Errorf("claimJobs() = %+v, want the retryable job on its last attempt", jobs)
+	}
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kudarap/foo"
+)
+
+const mediaColumns = `id, fighter_id, kind, content_type, size, filename, blob_key, thumbnail_key,
+	user_id, created_at`
+
+func scanMedia(row pgx.Row, m *foo.Media) error {
+	return row.Scan(&m.ID, &m.FighterID, &m.Kind, &m.ContentType, &m.Size, &m.Filename, &m.BlobKey,
+		&m.ThumbnailKey, &m.UserID, &m.CreatedAt)
+}
+
+func (c *Client) CreateMedia(ctx context.Context, m *foo.Media, mm ...foo.Message) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		row := tx.QueryRow(ctx, `
+			INSERT INTO fighter_media (id, fighter_id, kind, content_type, size, filename, blob_key, user_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING `+mediaColumns,
+			m.ID.String(), m.FighterID.String(), m.Kind, m.ContentType, m.Size, m.Filename, m.BlobKey, m.UserID)
+		if err := scanMedia(row, m); err != nil {
+			return err
+		}
+		return publish(ctx, tx, mm)
+	})
+}
+
+func (c *Client) Media(ctx context.Context, id uuid.UUID) (*foo.Media, error) {
+	var m foo.Media
+	row := c.db.QueryRow(ctx, `SELECT `+mediaColumns+` FROM fighter_media WHERE id=$1`, id.String())
+	if err := scanMedia(row, &m); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (c *Client) FighterMedia(ctx context.Context, fighterID uuid.UUID) ([]foo.Media, error) {
+	rows, err := c.db.Query(ctx, `
+		SELECT `+mediaColumns+` FROM fighter_media
+		WHERE fighter_id=$1
+		ORDER BY created_at DESC, id`, fighterID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mm []foo.Media
+	for rows.Next() {
+		var m foo.Media
+		if err = scanMedia(rows, &m); err != nil {
+			return nil, err
+		}
+		mm = append(mm, m)
+	}
+	return mm, rows.Err()
+}
+
+func (c *Client) UpdateMediaThumbnail(ctx context.Context, id uuid.UUID, key string) error {
+	tag, err := c.db.Exec(ctx, `UPDATE fighter_media SET thumbnail_key=$2 WHERE id=$1`, id.String(), key)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return foo.ErrMediaNotFound
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+DROP INDEX fighters_lower_first_name_idx;
+DROP INDEX fighters_lower_last_name_idx;
+DROP INDEX fighters_created_at_id_idx;
+DROP INDEX fighters_first_name_id_idx;
+DROP INDEX fighters_last_name_id_idx;
+
+ALTER TABLE fighters
+    DROP COLUMN created_at,
+    ALTER COLUMN last_name DROP NOT NULL,
+    ALTER COLUMN first_name DROP NOT NULL;
@@ -0,0 +1,16 @@
+UPDATE fighters SET first_name = '' WHERE first_name IS NULL;
+UPDATE fighters SET last_name = '' WHERE last_name IS NULL;
+
+ALTER TABLE fighters
+    ALTER COLUMN first_name SET NOT NULL,
+    ALTER COLUMN last_name SET NOT NULL,
+    ADD COLUMN created_at timestamptz NOT NULL DEFAULT now();
+
+-- keyset pagination indexes
+CREATE INDEX fighters_last_name_id_idx ON fighters (last_name, id);
+CREATE INDEX fighters_first_name_id_idx ON fighters (first_name, id);
+CREATE INDEX fighters_created_at_id_idx ON fighters (created_at, id);
+
+-- name prefix filter indexes
+CREATE INDEX fighters_lower_last_name_idx ON fighters (lower(last_name) text_pattern_ops);
+CREATE INDEX fighters_lower_first_name_idx ON fighters (lower(first_name) text_pattern_ops);
@@ -0,0 +1,6 @@
+DROP INDEX fighters_search_name_trgm_idx;
+DROP INDEX fighters_search_idx;
+
+ALTER TABLE fighters
+    DROP COLUMN search,
+    DROP COLUMN search_name;
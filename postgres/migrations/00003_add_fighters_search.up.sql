@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE fighters
+    ADD COLUMN search_name text GENERATED ALWAYS AS (first_name || ' ' || last_name) STORED,
+    ADD COLUMN search tsvector GENERATED ALWAYS AS (to_tsvector('simple', first_name || ' ' || last_name)) STORED;
+
+CREATE INDEX fighters_search_idx ON fighters USING gin (search);
+CREATE INDEX fighters_search_name_trgm_idx ON fighters USING gin (search_name gin_trgm_ops);
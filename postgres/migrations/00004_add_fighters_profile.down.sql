@@ -0,0 +1,22 @@
+DROP INDEX fighters_search_name_trgm_idx;
+DROP INDEX fighters_search_idx;
+ALTER TABLE fighters
+    DROP COLUMN search,
+    DROP COLUMN search_name;
+ALTER TABLE fighters
+    ADD COLUMN search_name text GENERATED ALWAYS AS (first_name || ' ' || last_name) STORED,
+    ADD COLUMN search tsvector GENERATED ALWAYS AS (to_tsvector('simple', first_name || ' ' || last_name)) STORED;
+CREATE INDEX fighters_search_idx ON fighters USING gin (search);
+CREATE INDEX fighters_search_name_trgm_idx ON fighters USING gin (search_name gin_trgm_ops);
+
+DROP INDEX fighters_weight_class_idx;
+
+ALTER TABLE fighters
+    DROP COLUMN updated_at,
+    DROP COLUMN weight_class,
+    DROP COLUMN reach_cm,
+    DROP COLUMN height_cm,
+    DROP COLUMN stance,
+    DROP COLUMN nationality,
+    DROP COLUMN date_of_birth,
+    DROP COLUMN nickname;
@@ -0,0 +1,29 @@
+ALTER TABLE fighters
+    ADD COLUMN nickname text NOT NULL DEFAULT '',
+    ADD COLUMN date_of_birth date,
+    ADD COLUMN nationality text NOT NULL DEFAULT '',
+    ADD COLUMN stance text NOT NULL DEFAULT '',
+    ADD COLUMN height_cm integer NOT NULL DEFAULT 0,
+    ADD COLUMN reach_cm integer NOT NULL DEFAULT 0,
+    ADD COLUMN weight_class text NOT NULL DEFAULT '',
+    ADD COLUMN updated_at timestamptz NOT NULL DEFAULT now();
+
+UPDATE fighters SET updated_at = created_at;
+
+CREATE INDEX fighters_weight_class_idx ON fighters (weight_class);
+
+-- nickname is now searchable
+DROP INDEX fighters_search_name_trgm_idx;
+DROP INDEX fighters_search_idx;
+ALTER TABLE fighters
+    DROP COLUMN search,
+    DROP COLUMN search_name;
+ALTER TABLE fighters
+    ADD COLUMN search_name text GENERATED ALWAYS AS (
+        first_name || ' ' || last_name || CASE WHEN nickname <> '' THEN ' ' || nickname ELSE '' END
+    ) STORED,
+    ADD COLUMN search tsvector GENERATED ALWAYS AS (
+        to_tsvector('simple', first_name || ' ' || last_name || ' ' || nickname)
+    ) STORED;
+CREATE INDEX fighters_search_idx ON fighters USING gin (search);
+CREATE INDEX fighters_search_name_trgm_idx ON fighters USING gin (search_name gin_trgm_ops);
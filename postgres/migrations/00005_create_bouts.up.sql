@@ -0,0 +1,18 @@
+CREATE TABLE bouts (
+    id uuid DEFAULT uuid_generate_v4(),
+    red_fighter_id uuid NOT NULL REFERENCES fighters (id),
+    blue_fighter_id uuid NOT NULL REFERENCES fighters (id),
+    weight_class text NOT NULL DEFAULT '',
+    date date NOT NULL,
+    result text NOT NULL DEFAULT '',
+    method text NOT NULL DEFAULT '',
+    round integer NOT NULL DEFAULT 0,
+    time_seconds integer NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id),
+    CHECK (red_fighter_id <> blue_fighter_id)
+);
+
+CREATE INDEX bouts_red_fighter_id_idx ON bouts (red_fighter_id, date);
+CREATE INDEX bouts_blue_fighter_id_idx ON bouts (blue_fighter_id, date);
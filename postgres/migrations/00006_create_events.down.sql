@@ -0,0 +1,9 @@
+DROP TRIGGER bouts_check_event_fighters ON bouts;
+DROP FUNCTION check_event_fighters();
+
+ALTER TABLE bouts
+    DROP COLUMN card_position,
+    DROP COLUMN card_segment,
+    DROP COLUMN event_id;
+
+DROP TABLE events;
@@ -0,0 +1,39 @@
+CREATE TABLE events (
+    id uuid DEFAULT uuid_generate_v4(),
+    name text NOT NULL,
+    venue text NOT NULL DEFAULT '',
+    date date NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX events_date_idx ON events (date);
+
+ALTER TABLE bouts
+    ADD COLUMN event_id uuid REFERENCES events (id) ON DELETE SET NULL,
+    ADD COLUMN card_segment text NOT NULL DEFAULT '',
+    ADD COLUMN card_position integer NOT NULL DEFAULT 0;
+
+CREATE INDEX bouts_event_id_idx ON bouts (event_id);
+
+-- Guards a fighter from being booked on two bouts of the same event.
+CREATE FUNCTION check_event_fighters() RETURNS trigger AS $$
+BEGIN
+    IF NEW.event_id IS NOT NULL AND EXISTS (
+        SELECT 1 FROM bouts b
+        WHERE b.event_id = NEW.event_id
+          AND b.id <> NEW.id
+          AND (b.red_fighter_id IN (NEW.red_fighter_id, NEW.blue_fighter_id)
+            OR b.blue_fighter_id IN (NEW.red_fighter_id, NEW.blue_fighter_id))
+    ) THEN
+        RAISE EXCEPTION 'fighter is already booked on event %', NEW.event_id
+            USING ERRCODE = 'unique_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER bouts_check_event_fighters
+    BEFORE INSERT OR UPDATE OF event_id, red_fighter_id, blue_fighter_id ON bouts
+    FOR EACH ROW EXECUTE FUNCTION check_event_fighters();
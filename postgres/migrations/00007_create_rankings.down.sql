@@ -0,0 +1,3 @@
+DROP TABLE rating_history;
+DROP TABLE ratings;
+DROP TABLE jobs;
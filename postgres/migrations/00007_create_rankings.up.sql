@@ -0,0 +1,37 @@
+-- jobs is a queue of messages published to worker topics.
+CREATE TABLE jobs (
+    id bigserial,
+    topic text NOT NULL,
+    payload jsonb NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    locked_until timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX jobs_topic_id_idx ON jobs (topic, id);
+
+CREATE TABLE ratings (
+    fighter_id uuid NOT NULL REFERENCES fighters (id) ON DELETE CASCADE,
+    weight_class text NOT NULL,
+    rank integer NOT NULL,
+    rating double precision NOT NULL,
+    deviation double precision NOT NULL,
+    volatility double precision NOT NULL,
+    bouts integer NOT NULL,
+    last_bout date NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (weight_class, fighter_id)
+);
+
+CREATE INDEX ratings_weight_class_rank_idx ON ratings (weight_class, rank);
+
+CREATE TABLE rating_history (
+    fighter_id uuid NOT NULL REFERENCES fighters (id) ON DELETE CASCADE,
+    weight_class text NOT NULL,
+    date date NOT NULL,
+    rating double precision NOT NULL,
+    deviation double precision NOT NULL,
+    volatility double precision NOT NULL,
+    PRIMARY KEY (fighter_id, weight_class, date)
+);
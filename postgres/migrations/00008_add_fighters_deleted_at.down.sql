@@ -0,0 +1,3 @@
+DROP INDEX fighters_deleted_at_idx;
+
+ALTER TABLE fighters DROP COLUMN deleted_at;
@@ -0,0 +1,3 @@
+ALTER TABLE fighters ADD COLUMN deleted_at timestamptz;
+
+CREATE INDEX fighters_deleted_at_idx ON fighters (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE fighters DROP COLUMN version;
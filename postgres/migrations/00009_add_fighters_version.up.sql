@@ -0,0 +1 @@
+ALTER TABLE fighters ADD COLUMN version integer NOT NULL DEFAULT 1;
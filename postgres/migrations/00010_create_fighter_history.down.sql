@@ -0,0 +1,4 @@
+DROP TRIGGER fighter_history_append_only ON fighter_history;
+DROP FUNCTION reject_fighter_history_change();
+
+DROP TABLE fighter_history;
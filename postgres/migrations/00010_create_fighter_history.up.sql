@@ -0,0 +1,25 @@
+-- fighter_history is an append-only log of fighter changes, it has no foreign
+-- key to fighters so entries are kept after fighters are purged.
+CREATE TABLE fighter_history (
+    id bigserial,
+    fighter_id uuid NOT NULL,
+    action text NOT NULL,
+    diff jsonb NOT NULL,
+    user_id text NOT NULL DEFAULT '',
+    request_id text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX fighter_history_fighter_id_idx ON fighter_history (fighter_id, id);
+
+-- Guards fighter history entries from being changed or removed.
+CREATE FUNCTION reject_fighter_history_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'fighter_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER fighter_history_append_only
+    BEFORE UPDATE OR DELETE ON fighter_history
+    FOR EACH ROW EXECUTE FUNCTION reject_fighter_history_change();
@@ -0,0 +1,2 @@
+DROP TABLE fighter_import_errors;
+DROP TABLE fighter_imports;
@@ -0,0 +1,25 @@
+CREATE TABLE fighter_imports (
+    id uuid DEFAULT uuid_generate_v4(),
+    format text NOT NULL,
+    status text NOT NULL,
+    message text NOT NULL DEFAULT '',
+    data bytea NOT NULL,
+    total_rows integer NOT NULL DEFAULT 0,
+    processed_rows integer NOT NULL DEFAULT 0,
+    created_rows integer NOT NULL DEFAULT 0,
+    updated_rows integer NOT NULL DEFAULT 0,
+    failed_rows integer NOT NULL DEFAULT 0,
+    user_id text NOT NULL DEFAULT '',
+    request_id text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE fighter_import_errors (
+    import_id uuid NOT NULL REFERENCES fighter_imports (id) ON DELETE CASCADE,
+    line integer NOT NULL,
+    fields jsonb,
+    message text NOT NULL DEFAULT '',
+    PRIMARY KEY (import_id, line)
+);
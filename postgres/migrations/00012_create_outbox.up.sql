@@ -0,0 +1,9 @@
+-- outbox holds messages written along with domain changes until relayed to
+-- worker topics jobs.
+CREATE TABLE outbox (
+    id bigserial,
+    topic text NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
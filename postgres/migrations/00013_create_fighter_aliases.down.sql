@@ -0,0 +1 @@
+DROP TABLE fighter_aliases;
@@ -0,0 +1,10 @@
+-- fighter_aliases keeps ids of merged duplicate fighters resolving to the
+-- fighter they were merged into.
+CREATE TABLE fighter_aliases (
+    alias_id uuid,
+    fighter_id uuid NOT NULL REFERENCES fighters (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (alias_id)
+);
+
+CREATE INDEX fighter_aliases_fighter_id_idx ON fighter_aliases (fighter_id);
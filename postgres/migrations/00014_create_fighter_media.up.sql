@@ -0,0 +1,15 @@
+CREATE TABLE fighter_media (
+    id uuid,
+    fighter_id uuid NOT NULL REFERENCES fighters (id) ON DELETE CASCADE,
+    kind text NOT NULL,
+    content_type text NOT NULL,
+    size bigint NOT NULL,
+    filename text NOT NULL DEFAULT '',
+    blob_key text NOT NULL,
+    thumbnail_key text NOT NULL DEFAULT '',
+    user_id text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX fighter_media_fighter_id_idx ON fighter_media (fighter_id, created_at);
@@ -0,0 +1,20 @@
+ALTER TABLE jobs DROP COLUMN tenant_id;
+
+DO $$
+DECLARE
+    t text;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'fighters', 'bouts', 'events', 'ratings', 'rating_history', 'fighter_history',
+        'fighter_imports', 'fighter_import_errors', 'outbox', 'fighter_aliases', 'fighter_media'
+    ] LOOP
+        EXECUTE format('REVOKE ALL ON %I FROM foo_tenant', t);
+        EXECUTE format('DROP POLICY tenant_isolation ON %I', t);
+        EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DROP COLUMN tenant_id', t);
+    END LOOP;
+END
+$$;
+
+REVOKE USAGE ON ALL SEQUENCES IN SCHEMA public FROM foo_tenant;
+REVOKE USAGE ON SCHEMA public FROM foo_tenant;
@@ -0,0 +1,38 @@
+-- Tenant queries run as foo_tenant role where row level security policies
+-- scope rows to app.tenant_id setting of the connection. The migrating user
+-- owns the tables and bypasses policies for system jobs.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'foo_tenant') THEN
+        CREATE ROLE foo_tenant NOLOGIN;
+    END IF;
+END
+$$;
+GRANT foo_tenant TO CURRENT_USER;
+GRANT USAGE ON SCHEMA public TO foo_tenant;
+GRANT USAGE ON ALL SEQUENCES IN SCHEMA public TO foo_tenant;
+
+-- Existing rows belong to the default tenant.
+DO $$
+DECLARE
+    t text;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'fighters', 'bouts', 'events', 'ratings', 'rating_history', 'fighter_history',
+        'fighter_imports', 'fighter_import_errors', 'outbox', 'fighter_aliases', 'fighter_media'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN tenant_id text NOT NULL DEFAULT ''default''', t);
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id SET DEFAULT NULLIF(current_setting(''app.tenant_id'', true), '''')', t);
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (tenant_id = current_setting(''app.tenant_id'', true))', t);
+        EXECUTE format('GRANT SELECT, INSERT, UPDATE, DELETE ON %I TO foo_tenant', t);
+    END LOOP;
+END
+$$;
+
+CREATE INDEX fighters_tenant_id_idx ON fighters (tenant_id);
+CREATE INDEX bouts_tenant_id_idx ON bouts (tenant_id);
+CREATE INDEX events_tenant_id_date_idx ON events (tenant_id, date);
+
+-- Jobs are read by the worker as system and carry the tenant of the message.
+ALTER TABLE jobs ADD COLUMN tenant_id text;
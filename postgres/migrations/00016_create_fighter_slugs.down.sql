@@ -0,0 +1,2 @@
+DROP TABLE fighter_slugs;
+ALTER TABLE fighters DROP COLUMN slug;
@@ -0,0 +1,34 @@
+ALTER TABLE fighters ADD COLUMN slug text NOT NULL DEFAULT '';
+
+-- fighter_slugs holds current and previous slugs of fighters so old urls
+-- keep resolving after name changes and merges.
+CREATE TABLE fighter_slugs (
+    tenant_id text NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), ''),
+    slug text NOT NULL,
+    fighter_id uuid NOT NULL REFERENCES fighters (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, slug)
+);
+
+CREATE INDEX fighter_slugs_fighter_id_idx ON fighter_slugs (fighter_id);
+
+ALTER TABLE fighter_slugs ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON fighter_slugs USING (tenant_id = current_setting('app.tenant_id', true));
+GRANT SELECT, INSERT, UPDATE, DELETE ON fighter_slugs TO foo_tenant;
+
+-- Backfills slugs of existing fighters numbering same names by creation.
+WITH b AS (
+    SELECT id, tenant_id, created_at, COALESCE(NULLIF(
+        trim(BOTH '-' FROM regexp_replace(lower(first_name || ' ' || last_name), '[^a-z0-9]+', '-', 'g')),
+        ''), 'fighter') AS base
+    FROM fighters
+), s AS (
+    SELECT id, base, row_number() OVER (PARTITION BY tenant_id, base ORDER BY created_at, id) AS n
+    FROM b
+)
+UPDATE fighters f SET slug = CASE WHEN s.n = 1 THEN s.base ELSE s.base || '-' || s.n END
+FROM s
+WHERE f.id = s.id;
+
+INSERT INTO fighter_slugs (tenant_id, slug, fighter_id)
+SELECT tenant_id, slug, id FROM fighters;
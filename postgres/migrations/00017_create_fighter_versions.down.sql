@@ -0,0 +1,4 @@
+DROP TRIGGER fighters_add_version ON fighters;
+DROP FUNCTION add_fighter_version();
+
+DROP TABLE fighter_versions;
@@ -0,0 +1,63 @@
+-- fighter_versions keeps every state of a fighter row with the period it was
+-- valid, valid_to is null on the current version. It is maintained by trigger
+-- so all fighter writes are versioned.
+CREATE TABLE fighter_versions (
+    tenant_id text NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), ''),
+    fighter_id uuid NOT NULL REFERENCES fighters (id) ON DELETE CASCADE,
+    slug text NOT NULL,
+    first_name text NOT NULL,
+    last_name text NOT NULL,
+    nickname text NOT NULL,
+    date_of_birth date,
+    nationality text NOT NULL,
+    stance text NOT NULL,
+    height_cm integer NOT NULL,
+    reach_cm integer NOT NULL,
+    weight_class text NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    deleted_at timestamptz,
+    version integer NOT NULL,
+    valid_from timestamptz NOT NULL,
+    valid_to timestamptz,
+    PRIMARY KEY (fighter_id, valid_from),
+    CHECK (valid_to > valid_from)
+);
+
+CREATE UNIQUE INDEX fighter_versions_current_idx ON fighter_versions (fighter_id) WHERE valid_to IS NULL;
+
+ALTER TABLE fighter_versions ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON fighter_versions USING (tenant_id = current_setting('app.tenant_id', true));
+GRANT SELECT, INSERT, UPDATE, DELETE ON fighter_versions TO foo_tenant;
+
+-- Closes the current fighter version and opens a new one from now. Versions
+-- replaced within the same transaction leave no empty period behind.
+CREATE FUNCTION add_fighter_version() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM fighter_versions
+    WHERE fighter_id = NEW.id AND valid_to IS NULL AND valid_from = now();
+    UPDATE fighter_versions SET valid_to = now()
+    WHERE fighter_id = NEW.id AND valid_to IS NULL;
+
+    INSERT INTO fighter_versions (tenant_id, fighter_id, slug, first_name, last_name, nickname,
+        date_of_birth, nationality, stance, height_cm, reach_cm, weight_class, created_at,
+        updated_at, deleted_at, version, valid_from)
+    VALUES (NEW.tenant_id, NEW.id, NEW.slug, NEW.first_name, NEW.last_name, NEW.nickname,
+        NEW.date_of_birth, NEW.nationality, NEW.stance, NEW.height_cm, NEW.reach_cm, NEW.weight_class,
+        NEW.created_at, NEW.updated_at, NEW.deleted_at, NEW.version, now());
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER fighters_add_version
+    AFTER INSERT OR UPDATE ON fighters
+    FOR EACH ROW EXECUTE FUNCTION add_fighter_version();
+
+-- Backfills current state of existing fighters as valid since their creation,
+-- earlier states are not recoverable.
+INSERT INTO fighter_versions (tenant_id, fighter_id, slug, first_name, last_name, nickname,
+    date_of_birth, nationality, stance, height_cm, reach_cm, weight_class, created_at,
+    updated_at, deleted_at, version, valid_from)
+SELECT tenant_id, id, slug, first_name, last_name, nickname, date_of_birth, nationality, stance,
+    height_cm, reach_cm, weight_class, created_at, updated_at, deleted_at, version, created_at
+FROM fighters;
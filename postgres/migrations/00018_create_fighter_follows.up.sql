@@ -0,0 +1,15 @@
+-- fighter_follows holds fighters users follow, keyed by authenticated user id.
+CREATE TABLE fighter_follows (
+    tenant_id text NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), ''),
+    user_id text NOT NULL,
+    fighter_id uuid NOT NULL REFERENCES fighters (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, user_id, fighter_id)
+);
+
+CREATE INDEX fighter_follows_user_id_idx ON fighter_follows (tenant_id, user_id, created_at);
+CREATE INDEX fighter_follows_fighter_id_idx ON fighter_follows (fighter_id);
+
+ALTER TABLE fighter_follows ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON fighter_follows USING (tenant_id = current_setting('app.tenant_id', true));
+GRANT SELECT, INSERT, UPDATE, DELETE ON fighter_follows TO foo_tenant;
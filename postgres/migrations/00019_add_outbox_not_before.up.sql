@@ -0,0 +1,2 @@
+-- not_before delays relayed jobs until the given time.
+ALTER TABLE outbox ADD COLUMN not_before timestamptz;
@@ -0,0 +1,36 @@
+-- notification_preferences holds where and when users want to be notified,
+-- users without preferences get in-app notifications only.
+CREATE TABLE notification_preferences (
+    tenant_id text NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), ''),
+    user_id text NOT NULL,
+    channels text[] NOT NULL DEFAULT '{}',
+    email text NOT NULL DEFAULT '',
+    webhook_url text NOT NULL DEFAULT '',
+    quiet_hours jsonb,
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+ALTER TABLE notification_preferences ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON notification_preferences USING (tenant_id = current_setting('app.tenant_id', true));
+GRANT SELECT, INSERT, UPDATE, DELETE ON notification_preferences TO foo_tenant;
+
+-- notifications is the in-app inbox of users.
+CREATE TABLE notifications (
+    tenant_id text NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), ''),
+    id uuid NOT NULL,
+    user_id text NOT NULL,
+    kind text NOT NULL,
+    bout_id uuid NOT NULL,
+    title text NOT NULL,
+    body text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    read_at timestamptz,
+    PRIMARY KEY (tenant_id, id)
+);
+
+CREATE INDEX notifications_user_id_idx ON notifications (tenant_id, user_id, created_at);
+
+ALTER TABLE notifications ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON notifications USING (tenant_id = current_setting('app.tenant_id', true));
+GRANT SELECT, INSERT, UPDATE, DELETE ON notifications TO foo_tenant;
@@ -0,0 +1,41 @@
+-- webhooks holds client urls pushed events they subscribed to.
+CREATE TABLE webhooks (
+    tenant_id text NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), ''),
+    id uuid NOT NULL,
+    url text NOT NULL,
+    events text[] NOT NULL,
+    secret text NOT NULL,
+    user_id text NOT NULL,
+    failures int NOT NULL DEFAULT 0,
+    disabled_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX webhooks_events_idx ON webhooks USING gin (events) WHERE disabled_at IS NULL;
+
+ALTER TABLE webhooks ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON webhooks USING (tenant_id = current_setting('app.tenant_id', true));
+GRANT SELECT, INSERT, UPDATE, DELETE ON webhooks TO foo_tenant;
+
+-- webhook_deliveries logs every post of an event to a webhook.
+CREATE TABLE webhook_deliveries (
+    tenant_id text NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), ''),
+    id uuid NOT NULL,
+    webhook_id uuid NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id uuid NOT NULL,
+    event text NOT NULL,
+    attempt int NOT NULL,
+    status_code int NOT NULL,
+    error text NOT NULL,
+    duration_ms bigint NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, created_at);
+
+ALTER TABLE webhook_deliveries ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON webhook_deliveries USING (tenant_id = current_setting('app.tenant_id', true));
+GRANT SELECT, INSERT, UPDATE, DELETE ON webhook_deliveries TO foo_tenant;
@@ -0,0 +1,31 @@
+-- fighter_stats holds fighter career aggregates refreshed by the worker when
+-- bouts change.
+CREATE TABLE fighter_stats (
+    tenant_id text NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), ''),
+    fighter_id uuid NOT NULL REFERENCES fighters (id) ON DELETE CASCADE,
+    win_streak int NOT NULL,
+    finish_rate double precision NOT NULL,
+    method_rates jsonb NOT NULL,
+    avg_fight_seconds int NOT NULL,
+    strength_of_schedule double precision NOT NULL,
+    recent_bout_dates date[] NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (fighter_id)
+);
+
+ALTER TABLE fighter_stats ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON fighter_stats USING (tenant_id = current_setting('app.tenant_id', true));
+GRANT SELECT, INSERT, UPDATE, DELETE ON fighter_stats TO foo_tenant;
+
+-- Queue stats refresh of fighters with completed bouts in batches of 100.
+INSERT INTO outbox (topic, payload, tenant_id)
+SELECT 'fighters.stats', jsonb_build_object('fighter_ids', jsonb_agg(id)), tenant_id
+FROM (
+    SELECT f.id, f.tenant_id, (row_number() OVER (PARTITION BY f.tenant_id ORDER BY f.id) - 1) / 100 AS batch
+    FROM fighters f
+    WHERE EXISTS (
+        SELECT 1 FROM bouts b
+        WHERE (b.red_fighter_id = f.id OR b.blue_fighter_id = f.id) AND b.result <> ''
+    )
+) s
+GROUP BY tenant_id, batch;
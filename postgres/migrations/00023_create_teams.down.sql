@@ -0,0 +1,2 @@
+DROP TABLE fighter_affiliations;
+DROP TABLE teams;
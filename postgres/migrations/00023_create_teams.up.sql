@@ -0,0 +1,38 @@
+-- teams holds gyms and fight camps fighters train under.
+CREATE TABLE teams (
+    tenant_id text NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), ''),
+    id uuid DEFAULT uuid_generate_v4(),
+    name text NOT NULL,
+    location text NOT NULL DEFAULT '',
+    head_coach text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX teams_name_idx ON teams (name);
+
+-- fighter_affiliations holds fighter team history, an open end date marks a
+-- current member.
+CREATE TABLE fighter_affiliations (
+    tenant_id text NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), ''),
+    id uuid DEFAULT uuid_generate_v4(),
+    fighter_id uuid NOT NULL REFERENCES fighters (id) ON DELETE CASCADE,
+    team_id uuid NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    start_date date NOT NULL,
+    end_date date CHECK (end_date >= start_date),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX fighter_affiliations_fighter_id_idx ON fighter_affiliations (fighter_id);
+CREATE INDEX fighter_affiliations_team_id_idx ON fighter_affiliations (team_id);
+
+ALTER TABLE teams ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON teams USING (tenant_id = current_setting('app.tenant_id', true));
+GRANT SELECT, INSERT, UPDATE, DELETE ON teams TO foo_tenant;
+
+ALTER TABLE fighter_affiliations ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON fighter_affiliations USING (tenant_id = current_setting('app.tenant_id', true));
+GRANT SELECT, INSERT, UPDATE, DELETE ON fighter_affiliations TO foo_tenant;
@@ -0,0 +1 @@
+ALTER TABLE bouts DROP CONSTRAINT bouts_card_event_check;
@@ -0,0 +1,6 @@
+-- Clears card placement left on bouts of deleted events.
+UPDATE bouts SET card_segment='', card_position=0
+WHERE event_id IS NULL AND (card_segment <> '' OR card_position <> 0);
+
+ALTER TABLE bouts ADD CONSTRAINT bouts_card_event_check
+    CHECK (event_id IS NOT NULL OR (card_segment = '' AND card_position = 0));
@@ -0,0 +1,2 @@
+DROP INDEX fighters_match_name_trgm_idx;
+ALTER TABLE fighters DROP COLUMN match_name;
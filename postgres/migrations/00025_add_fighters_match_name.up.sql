@@ -0,0 +1,7 @@
+-- match_name is the normalized first and last name duplicate fighters are
+-- matched on, nicknames are left out so fighters sharing one are not matched.
+ALTER TABLE fighters
+    ADD COLUMN match_name text GENERATED ALWAYS AS (
+        trim(regexp_replace(lower(first_name || ' ' || last_name), '[^[:alnum:]]+', ' ', 'g'))
+    ) STORED;
+CREATE INDEX fighters_match_name_trgm_idx ON fighters USING gin (match_name gin_trgm_ops);
@@ -0,0 +1,3 @@
+-- Re-slugged fighters keep their slugs, previous slugs still resolve from
+-- fighter_slugs.
+SELECT 1;
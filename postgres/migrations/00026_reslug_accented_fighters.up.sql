@@ -0,0 +1,40 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- Re-slugs fighters backfilled without removing diacritics, e.g. "jos-aldo"
+-- of "José Aldo" becomes "jose-aldo" as picked by foo.Fighter.BaseSlug.
+-- Previous slugs stay in fighter_slugs so old urls keep resolving.
+DO $$
+DECLARE
+    r record;
+    new_slug text;
+    n int;
+BEGIN
+    FOR r IN
+        SELECT id, tenant_id, new_base FROM (
+            SELECT id, tenant_id, slug, created_at,
+                COALESCE(NULLIF(
+                    trim(BOTH '-' FROM regexp_replace(lower(first_name || ' ' || last_name), '[^a-z0-9]+', '-', 'g')),
+                    ''), 'fighter') AS old_base,
+                COALESCE(NULLIF(
+                    trim(BOTH '-' FROM regexp_replace(unaccent(lower(first_name || ' ' || last_name)), '[^a-z0-9]+', '-', 'g')),
+                    ''), 'fighter') AS new_base
+            FROM fighters
+        ) f
+        WHERE old_base <> new_base AND (slug = old_base OR slug ~ ('^' || old_base || '-[0-9]+$'))
+        ORDER BY created_at, id
+    LOOP
+        n := 1;
+        new_slug := r.new_base;
+        WHILE EXISTS (
+            SELECT 1 FROM fighter_slugs s
+            WHERE s.tenant_id = r.tenant_id AND s.slug = new_slug AND s.fighter_id <> r.id
+        ) LOOP
+            n := n + 1;
+            new_slug := r.new_base || '-' || n;
+        END LOOP;
+
+        UPDATE fighters SET slug = new_slug WHERE id = r.id;
+        INSERT INTO fighter_slugs (tenant_id, slug, fighter_id) VALUES (r.tenant_id, new_slug, r.id)
+        ON CONFLICT (tenant_id, slug) DO NOTHING;
+    END LOOP;
+END $$;
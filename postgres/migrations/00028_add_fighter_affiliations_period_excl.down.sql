@@ -0,0 +1 @@
+ALTER TABLE fighter_affiliations DROP CONSTRAINT fighter_affiliations_period_excl;
@@ -0,0 +1,32 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Collapses overlapping periods of a fighter with the same team, left by
+-- fighter merges, into the earliest created affiliation.
+WITH p AS (
+    SELECT id, fighter_id, team_id, start_date, end_date, created_at,
+        max(COALESCE(end_date, 'infinity')) OVER (
+            PARTITION BY fighter_id, team_id ORDER BY start_date, id
+            ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS prev_end
+    FROM fighter_affiliations
+), g AS (
+    SELECT *, sum(CASE WHEN start_date <= prev_end THEN 0 ELSE 1 END) OVER (
+        PARTITION BY fighter_id, team_id ORDER BY start_date, id) AS island
+    FROM p
+), i AS (
+    SELECT fighter_id, team_id, island, min(start_date) AS start_date,
+        NULLIF(max(COALESCE(end_date, 'infinity')), 'infinity') AS end_date,
+        (array_agg(id ORDER BY created_at, id))[1] AS keep_id
+    FROM g
+    GROUP BY fighter_id, team_id, island
+    HAVING count(*) > 1
+), d AS (
+    DELETE FROM fighter_affiliations a
+    USING g JOIN i USING (fighter_id, team_id, island)
+    WHERE a.id = g.id AND a.id <> i.keep_id
+)
+UPDATE fighter_affiliations a SET start_date = i.start_date, end_date = i.end_date, updated_at = now()
+FROM i
+WHERE a.id = i.keep_id;
+
+ALTER TABLE fighter_affiliations ADD CONSTRAINT fighter_affiliations_period_excl
+    EXCLUDE USING gist (fighter_id WITH =, team_id WITH =, daterange(start_date, end_date, '[]') WITH &&);
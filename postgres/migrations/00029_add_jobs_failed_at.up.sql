@@ -0,0 +1,4 @@
+-- failed_at is set when a job used up its attempts, failed jobs are kept for
+-- inspection until purged.
+ALTER TABLE jobs ADD COLUMN failed_at timestamptz;
+CREATE INDEX jobs_failed_at_idx ON jobs (failed_at) WHERE failed_at IS NOT NULL;
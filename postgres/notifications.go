@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kudarap/foo"
+)
+
+const notificationColumns = `id, user_id, kind, bout_id, title, body, created_at, read_at`
+
+// FollowerIDs returns distinct users following any of the fighters.
+func (c *Client) FollowerIDs(ctx context.Context, fighterIDs []uuid.UUID) ([]string, error) {
+	ids := make([]string, len(fighterIDs))
+	for i, id := range fighterIDs {
+		ids[i] = id.String()
+	}
+	rows, err := c.db.Query(ctx, `
+		SELECT DISTINCT user_id FROM fighter_follows
+		WHERE fighter_id = ANY($1::uuid[])
+		ORDER BY user_id`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, rows.Err()
+}
+
+func (c *Client) NotificationPreferences(ctx context.Context, userID string) (*foo.NotificationPreferences, error) {
+	var p foo.NotificationPreferences
+	var channels []string
+	err := c.db.QueryRow(ctx, `
+		SELECT channels, email, webhook_url, quiet_hours, updated_at
+		FROM notification_preferences WHERE user_id=$1`, userID).
+		Scan(&channels, &p.Email, &p.WebhookURL, &p.QuietHours, &p.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	p.Channels = make([]foo.NotificationChannel, len(channels))
+	for i, ch := range channels {
+		p.Channels[i] = foo.NotificationChannel(ch)
+	}
+	return &p, nil
+}
+
+// UpdateNotificationPreferences creates or replaces user notification preferences.
+func (c *Client) UpdateNotificationPreferences(ctx context.Context, userID string, p *foo.NotificationPreferences) error {
+	channels := make([]string, len(p.Channels))
+	for i, ch := range p.Channels {
+		channels[i] = string(ch)
+	}
+	return c.db.QueryRow(ctx, `
+		INSERT INTO notification_preferences (user_id, channels, email, webhook_url, quiet_hours)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, user_id) DO UPDATE SET
+			channels = excluded.channels,
+			email = excluded.email,
+			webhook_url = excluded.webhook_url,
+			quiet_hours = excluded.quiet_hours,
+			updated_at = now()
+		RETURNING updated_at`,
+		userID, channels, p.Email, p.WebhookURL, p.QuietHours).Scan(&p.UpdatedAt)
+}
+
+// AddNotification adds a notification to the user in-app inbox, adding a
+// notification twice keeps the first one.
+func (c *Client) AddNotification(ctx context.Context, n foo.Notification) error {
+	_, err := c.db.Exec(ctx, `
+		INSERT INTO notifications (id, user_id, kind, bout_id, title, body, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT DO NOTHING`,
+		n.ID.String(), n.UserID, n.Kind, n.BoutID.String(), n.Title, n.Body, n.CreatedAt)
+	return err
+}
+
+func (c *Client) Notifications(ctx context.Context, userID string, limit int) ([]foo.Notification, error) {
+	rows, err := c.db.Query(ctx, `
+		SELECT `+notificationColumns+` FROM notifications
+		WHERE user_id=$1
+		ORDER BY created_at DESC, id
+		LIMIT $2`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var nn []foo.Notification
+	for rows.Next() {
+		var n foo.Notification
+		err = rows.Scan(&n.ID, &n.UserID, &n.Kind, &n.BoutID, &n.Title, &n.Body, &n.CreatedAt, &n.ReadAt)
+		if err != nil {
+			return nil, err
+		}
+		nn = append(nn, n)
+	}
+	return nn, rows.Err()
+}
+
+func (c *Client) ReadNotification(ctx context.Context, userID string, id uuid.UUID) error {
+	tag, err := c.db.Exec(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, now())
+		WHERE user_id=$1 AND id=$2`, userID, id.String())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return foo.ErrNotificationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kudarap/foo"
+)
+
+// publish writes messages to the outbox within a transaction, RelayOutbox then
+// publishes them to worker topics.
+func publish(ctx context.Context, tx pgx.Tx, mm []foo.Message) error {
+	for _, m := range mm {
+		var notBefore *time.Time
+		if !m.NotBefore.IsZero() {
+			notBefore = &m.NotBefore
+		}
+		_, err := tx.Exec(ctx, `INSERT INTO outbox (topic, payload, not_before) VALUES ($1, $2, $3)`,
+			m.Topic, m.Payload, notBefore)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Publish writes messages to the outbox without a domain change.
+func (c *Client) Publish(ctx context.Context, mm ...foo.Message) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		return publish(ctx, tx, mm)
+	})
+}
+
+// RelayOutbox moves up to limit oldest outbox messages to worker topics jobs
+// and returns the number of relayed messages. Delayed messages are relayed as
+// jobs locked until their not before time. Concurrent relays skip messages
+// being relayed by another.
+func (c *Client) RelayOutbox(ctx context.Context, limit int) (int, error) {
+	tag, err := c.db.Exec(ctx, `
+		WITH relayed AS (
+			DELETE FROM outbox
+			WHERE id IN (
+				SELECT id FROM outbox
+				ORDER BY id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, topic, payload, tenant_id, not_before
+		)
+		INSERT INTO jobs (topic, payload, tenant_id, locked_until)
+		SELECT topic, payload, tenant_id, not_before FROM relayed ORDER BY id`, limit)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+)
+
+func TestClient_RelayOutbox(t *testing.T) {
+	c := newTestClient(t)
+	sys := context.Background()
+	// Drains messages left by other tests so batches only hold ours.
+	for {
+		n, err := c.RelayOutbox(sys, 100)
+		if err != nil {
+			t.Fatalf("RelayOutbox() unexpected error %s", err)
+		}
+		if n < 100 {
+			break
+		}
+	}
+
+	topic := "test.relay." + uuid.NewString()
+	t.Cleanup(func() {
+		c.db.Exec(sys, `DELETE FROM jobs WHERE topic=$1`, topic)
+	})
+	notBefore := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+	ctx := foo.ContextWithTenant(sys, "relay-test")
+	err := c.Publish(ctx,
+		foo.Message{Topic: topic, Payload: json.RawMessage(`{"n":1}`)},
+		foo.Message{Topic: topic, Payload: json.RawMessage(`{"n":2}`)},
+		foo.Message{Topic: topic, Payload: json.RawMessage(`{"n":3}`), NotBefore: notBefore},
+	)
+	if err != nil {
+		t.Fatalf("Publish() unexpected error %s", err)
+	}
+
+	for _, want := range []int{2, 1, 0} {
+		n, err := c.RelayOutbox(sys, 2)
+		if err != nil || n != want {
+			t.Fatalf("RelayOutbox() = %d, %v, want %d", n, err, want)
+		}
+	}
+
+	rows, err := c.db.Query(sys, `SELECT payload, tenant_id, locked_until FROM jobs WHERE topic=$1 ORDER BY id`, topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var got int
+	for rows.Next() {
+		var payload struct{ N int }
+		var tenant string
+		var lockedUntil *time.Time
+		if err = rows.Scan(&payload, &tenant, &lockedUntil); err != nil {
+			t.Fatal(err)
+		}
+		got++
+		if payload.N != got || tenant != "relay-test" {
+			t.Errorf("job %d payload = %d tenant = %q, want relay order and tenant", got, payload.N, tenant)
+		}
+		delayed := payload.N == 3
+		if (lockedUntil != nil) != delayed || (delayed && !lockedUntil.Equal(notBefore)) {
+			t.Errorf("job %d locked until = %v, want delayed %v until %v", got, lockedUntil, delayed, notBefore)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if got != 3 {
+		t.Errorf("RelayOutbox() relayed %d jobs, want 3", got)
+	}
+}
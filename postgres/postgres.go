@@ -12,7 +12,9 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kudarap/foo"
 )
 
 // Client represents postgres database client.
@@ -33,6 +35,7 @@ func NewClient(conf Config, log *slog.Logger) (*Client, error) {
 	poolConf.MaxConns = int32(conf.MaxConns)
 	poolConf.MaxConnLifetime = conf.MaxConnLifetime
 	poolConf.MaxConnIdleTime = conf.MaxConnIdleTime
+	poolConf.BeforeAcquire = scopeTenant
 
 	ctx := context.Background()
 	pool, err := pgxpool.NewWithConfig(ctx, poolConf)
@@ -52,6 +55,29 @@ func NewClient(conf Config, log *slog.Logger) (*Client, error) {
 	return c, nil
 }
 
+// tenantRole is the role tenant queries run as so row level security applies.
+const tenantRole = "foo_tenant"
+
+// scopeTenant switches an acquired connection to the context tenant or back to
+// the pool user for system jobs when context has no tenant. Connection that
+// cannot be scoped is destroyed.
+func scopeTenant(ctx context.Context, conn *pgx.Conn) bool {
+	role, tenant := tenantSettings(ctx)
+	_, err := conn.Exec(ctx, `SELECT set_config('role', $1, false), set_config('app.tenant_id', $2, false)`,
+		role, tenant)
+	return err == nil
+}
+
+// tenantSettings returns the role and app.tenant_id setting of connections used
+// by the context, role none resets to the pool user.
+func tenantSettings(ctx context.Context) (role, tenant string) {
+	tenant, ok := foo.TenantFromContext(ctx)
+	if !ok {
+		return "none", ""
+	}
+	return tenantRole, tenant
+}
+
 // Ping checks connection that sends empty sql statement.
 func (c *Client) Ping() (ok bool, err error) {
 	if err = c.db.Ping(context.Background()); err != nil {
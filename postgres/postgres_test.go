@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/kudarap/foo"
+)
+
+// newTestClient connects to the TEST_POSTGRES_URL database and runs migrations,
+// tests are skipped when it is not set.
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set")
+	}
+	c, err := NewClient(Config{URL: url, MaxConns: 4}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("could not connect to test database: %s", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestTenantSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantRole   string
+		wantTenant string
+	}{
+		{"system", context.Background(), "none", ""},
+		{"blank tenant", foo.ContextWithTenant(context.Background(), ""), "none", ""},
+		{"default tenant", foo.ContextWithTenant(context.Background(), foo.DefaultTenant), tenantRole, foo.DefaultTenant},
+		{"tenant", foo.ContextWithTenant(context.Background(), "acme"), tenantRole, "acme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, tenant := tenantSettings(tt.ctx)
+			if role != tt.wantRole || tenant != tt.wantTenant {
+				t.Errorf("tenantSettings() = %q, %q, want %q, %q", role, tenant, tt.wantRole, tt.wantTenant)
+			}
+		})
+	}
+}
+
+func TestScopeTenant(t *testing.T) {
+	c := newTestClient(t)
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantTenant bool
+	}{
+		{"tenant", foo.ContextWithTenant(context.Background(), "acme"), true},
+		{"system", context.Background(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var role, sessionRole, tenant string
+			err := c.db.QueryRow(tt.ctx, `SELECT current_user, session_user, current_setting('app.tenant_id', true)`).
+				Scan(&role, &sessionRole, &tenant)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantRole, wantTenant := sessionRole, ""
+			if tt.wantTenant {
+				wantRole, wantTenant = tenantRole, "acme"
+			}
+			if role != wantRole || tenant != wantTenant {
+				t.Errorf("scopeTenant() role = %q tenant = %q, want %q %q", role, tenant, wantRole, wantTenant)
+			}
+		})
+	}
+}
+
+func TestClient_Fighter_TenantIsolation(t *testing.T) {
+	c := newTestClient(t)
+	acme := foo.ContextWithTenant(context.Background(), "acme")
+	other := foo.ContextWithTenant(context.Background(), "other")
+
+	f := &foo.Fighter{FirstName: "dave", LastName: "grohl"}
+	if err := c.CreateFighter(acme, f); err != nil {
+		t.Fatalf("CreateFighter() unexpected error %s", err)
+	}
+	t.Cleanup(func() {
+		c.db.Exec(context.Background(), `DELETE FROM fighters WHERE id=$1`, f.ID.String())
+	})
+
+	if _, err := c.Fighter(acme, f.ID, false); err != nil {
+		t.Errorf("Fighter() of own tenant error = %v", err)
+	}
+	if _, err := c.Fighter(other, f.ID, false); !errors.Is(err, foo.ErrFighterNotFound) {
+		t.Errorf("Fighter() of other tenant error = %v, want not found", err)
+	}
+}
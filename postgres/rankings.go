@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kudarap/foo"
+)
+
+func (c *Client) Ratings(ctx context.Context, wc foo.WeightClass, limit int) ([]foo.Rating, error) {
+	rows, err := c.db.Query(ctx, `
+		SELECT r.fighter_id, f.first_name || ' ' || f.last_name, r.weight_class, r.rank, r.rating,
+			r.deviation, r.volatility, r.bouts, r.last_bout, r.updated_at
+		FROM ratings r
+		JOIN fighters f ON f.id = r.fighter_id
+		WHERE r.weight_class=$1
+		ORDER BY r.rank
+		LIMIT $2`, wc, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rr []foo.Rating
+	for rows.Next() {
+		var r foo.Rating
+		var lastBout pgtype.Date
+		err = rows.Scan(&r.FighterID, &r.FighterName, &r.WeightClass, &r.Rank, &r.Rating,
+			&r.Deviation, &r.Volatility, &r.Bouts, &lastBout, &r.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		r.LastBout = foo.Date{Time: lastBout.Time}
+		rr = append(rr, r)
+	}
+	return rr, rows.Err()
+}
+
+func (c *Client) FighterRatingHistory(ctx context.Context, fighterID uuid.UUID) ([]foo.RatingChange, error) {
+	rows, err := c.db.Query(ctx, `
+		SELECT fighter_id, weight_class, date, rating, deviation, volatility
+		FROM rating_history
+		WHERE fighter_id=$1
+		ORDER BY date, weight_class`, fighterID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hh []foo.RatingChange
+	for rows.Next() {
+		var h foo.RatingChange
+		var date pgtype.Date
+		if err = rows.Scan(&h.FighterID, &h.WeightClass, &date, &h.Rating, &h.Deviation, &h.Volatility); err != nil {
+			return nil, err
+		}
+		h.Date = foo.Date{Time: date.Time}
+		hh = append(hh, h)
+	}
+	return hh, rows.Err()
+}
+
+// ReplaceRatings replaces weight class ratings and rating history in a transaction.
+func (c *Client) ReplaceRatings(ctx context.Context, wc foo.WeightClass, rr []foo.Rating, hh []foo.RatingChange) error {
+	return pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM ratings WHERE weight_class=$1`, wc); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM rating_history WHERE weight_class=$1`, wc); err != nil {
+			return err
+		}
+
+		_, err := tx.CopyFrom(ctx, pgx.Identifier{"ratings"},
+			[]string{"fighter_id", "weight_class", "rank", "rating", "deviation", "volatility", "bouts", "last_bout"},
+			pgx.CopyFromSlice(len(rr), func(i int) ([]interface{}, error) {
+				r := rr[i]
+				return []interface{}{
+					pgtype.UUID{Bytes: r.FighterID, Valid: true}, string(wc), r.Rank, r.Rating, r.Deviation,
+					r.Volatility, r.Bouts, dateValue(&r.LastBout),
+				}, nil
+			}))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CopyFrom(ctx, pgx.Identifier{"rating_history"},
+			[]string{"fighter_id", "weight_class", "date", "rating", "deviation", "volatility"},
+			pgx.CopyFromSlice(len(hh), func(i int) ([]interface{}, error) {
+				h := hh[i]
+				return []interface{}{
+					pgtype.UUID{Bytes: h.FighterID, Valid: true}, string(wc), dateValue(&h.Date), h.Rating,
+					h.Deviation, h.Volatility,
+				}, nil
+			}))
+		return err
+	})
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kudarap/foo"
+)
+
+// fighterSearchThreshold is the minimum trigram word similarity of a fuzzy match, lower
+// than pg_trgm default 0.6 to tolerate misspelled names.
+const fighterSearchThreshold = 0.4
+
+// SearchFighters finds fighters by full-text and trigram word similarity on their names
+// ranked by relevance.
+func (c *Client) SearchFighters(ctx context.Context, q string, limit int) ([]foo.FighterSearchResult, error) {
+	query := `
+		WITH q AS (
+			SELECT websearch_to_tsquery('simple', $1) AS tsq,
+				$1::text AS raw,
+				regexp_split_to_array(lower(trim($1)), '\s+') AS terms
+		)
+		SELECT ` + fighterSelect() + `,
+			ts_rank(f.search, q.tsq) + word_similarity(q.raw, f.search_name) AS rank,
+			w.words, w.hits
+		FROM fighters f` + fighterRecordJoin + `, q,
+			LATERAL (
+				SELECT array_agg(x.word ORDER BY x.n) AS words,
+					array_agg(EXISTS (
+						SELECT 1 FROM unnest(q.terms) t WHERE word_similarity(t, x.word) >= $2::real
+					) ORDER BY x.n) AS hits
+				FROM unnest(regexp_split_to_array(f.search_name, '\s+')) WITH ORDINALITY AS x(word, n)
+			) w
+		WHERE (f.search @@ q.tsq OR q.raw <% f.search_name) AND f.deleted_at IS NULL
+		ORDER BY rank DESC, f.id
+		LIMIT $3`
+
+	var results []foo.FighterSearchResult
+	err := pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		// Applies fuzzy match threshold to <% operator for this transaction only.
+		_, err := tx.Exec(ctx, `SELECT set_config('pg_trgm.word_similarity_threshold', $1::real::text, true)`,
+			fighterSearchThreshold)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, query, q, fighterSearchThreshold, limit)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var r foo.FighterSearchResult
+			var words []string
+			var hits []bool
+			err = scanFighter(rows, &r.Fighter, &r.Rank, &words, &hits)
+			if err != nil {
+				return err
+			}
+			r.Snippet = highlight(words, hits)
+			results = append(results, r)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// highlight joins words and wraps matched ones with <mark> tag, words are html
+// escaped since snippets are meant to be rendered.
+func highlight(words []string, hits []bool) string {
+	ww := make([]string, len(words))
+	for i, w := range words {
+		w = html.EscapeString(w)
+		if i < len(hits) && hits[i] {
+			w = "<mark>" + w + "</mark>"
+		}
+		ww[i] = w
+	}
+	return strings.Join(ww, " ")
+}
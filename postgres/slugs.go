@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kudarap/foo"
+)
+
+func (c *Client) FighterBySlug(ctx context.Context, slug string) (*foo.Fighter, error) {
+	var fighter foo.Fighter
+	row := c.db.QueryRow(ctx, `SELECT `+fighterSelect()+` FROM fighters f`+fighterRecordJoin+`
+		WHERE f.id IN (SELECT fighter_id FROM fighter_slugs WHERE slug=$1) AND f.deleted_at IS NULL`, slug)
+	if err := scanFighter(row, &fighter); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrFighterNotFound
+		}
+		return nil, err
+	}
+	return &fighter, nil
+}
+
+// fighterSlug returns the slug a fighter should have on write. Slug is kept
+// while its name has the same base slug, otherwise the fighter own previous
+// slug of the base is reused or the base is suffixed with the lowest free
+// number. Fighter id is uuid.Nil on create.
+func fighterSlug(ctx context.Context, tx pgx.Tx, id uuid.UUID, current string, f *foo.Fighter) (string, error) {
+	base := f.BaseSlug()
+	if hasSlugBase(current, base) {
+		return current, nil
+	}
+
+	// Serializes picks of the same base within the tenant until commit.
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext(current_setting('app.tenant_id', true) || '/' || $1))`, base)
+	if err != nil {
+		return "", err
+	}
+	rows, err := tx.Query(ctx, `
+		SELECT slug, fighter_id FROM fighter_slugs
+		WHERE slug = $1 OR slug LIKE $2`, base, escapeLike(base)+"-%")
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	taken := map[string]bool{}
+	for rows.Next() {
+		var slug string
+		var fid uuid.UUID
+		if err = rows.Scan(&slug, &fid); err != nil {
+			return "", err
+		}
+		if fid == id && hasSlugBase(slug, base) {
+			return slug, nil
+		}
+		taken[slug] = true
+	}
+	if err = rows.Err(); err != nil {
+		return "", err
+	}
+
+	for n := 1; ; n++ {
+		slug := base
+		if n > 1 {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		if !taken[slug] {
+			return slug, nil
+		}
+	}
+}
+
+// addFighterSlug records slug of a fighter, the fighter own previous slug is
+// left as is.
+func addFighterSlug(ctx context.Context, tx pgx.Tx, id uuid.UUID, slug string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO fighter_slugs (slug, fighter_id) VALUES ($1, $2)
+		ON CONFLICT (tenant_id, slug) DO NOTHING`, slug, id.String())
+	return err
+}
+
+// hasSlugBase reports whether slug is base or base suffixed with a number.
+func hasSlugBase(slug, base string) bool {
+	rest, ok := strings.CutPrefix(slug, base)
+	if !ok {
+		return false
+	}
+	if rest == "" {
+		return true
+	}
+	n, ok := strings.CutPrefix(rest, "-")
+	if !ok {
+		return false
+	}
+	_, err := strconv.ParseUint(n, 10, 32)
+	return err == nil
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kudarap/foo"
+)
+
+func (c *Client) FighterStats(ctx context.Context, fighterID uuid.UUID) (*foo.FighterStats, error) {
+	st := foo.FighterStats{FighterID: fighterID}
+	var dates []time.Time
+	err := c.db.QueryRow(ctx, `
+		SELECT win_streak, finish_rate, method_rates, avg_fight_seconds, strength_of_schedule,
+			recent_bout_dates, updated_at
+		FROM fighter_stats WHERE fighter_id=$1`, fighterID.String()).
+		Scan(&st.WinStreak, &st.FinishRate, &st.MethodRates, &st.AvgFightSeconds, &st.StrengthOfSchedule,
+			&dates, &st.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrFighterNotFound
+		}
+		return nil, err
+	}
+	for _, d := range dates {
+		st.RecentBoutDates = append(st.RecentBoutDates, foo.NewDate(d))
+	}
+	return &st, nil
+}
+
+// UpdateFighterStats creates or replaces stats of a fighter, stats of purged
+// fighters are skipped.
+func (c *Client) UpdateFighterStats(ctx context.Context, st *foo.FighterStats) error {
+	dates := make([]time.Time, len(st.RecentBoutDates))
+	for i, d := range st.RecentBoutDates {
+		dates[i] = d.Time
+	}
+	err := c.db.QueryRow(ctx, `
+		INSERT INTO fighter_stats (fighter_id, win_streak, finish_rate, method_rates, avg_fight_seconds,
+			strength_of_schedule, recent_bout_dates)
+		SELECT id, $2::int, $3::float8, $4::jsonb, $5::int, $6::float8, $7::date[] FROM fighters WHERE id=$1
+		ON CONFLICT (fighter_id) DO UPDATE SET
+			win_streak = excluded.win_streak,
+			finish_rate = excluded.finish_rate,
+			method_rates = excluded.method_rates,
+			avg_fight_seconds = excluded.avg_fight_seconds,
+			strength_of_schedule = excluded.strength_of_schedule,
+			recent_bout_dates = excluded.recent_bout_dates,
+			updated_at = now()
+		RETURNING updated_at`,
+		st.FighterID.String(), st.WinStreak, st.FinishRate, st.MethodRates, st.AvgFightSeconds,
+		st.StrengthOfSchedule, dates).Scan(&st.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kudarap/foo"
+)
+
+const teamColumns = `id, name, location, head_coach, created_at, updated_at`
+
+func scanTeam(row pgx.Row, t *foo.Team) error {
+	return row.Scan(&t.ID, &t.Name, &t.Location, &t.HeadCoach, &t.CreatedAt, &t.UpdatedAt)
+}
+
+const affiliationColumns = `a.id, a.fighter_id, a.team_id, a.start_date, a.end_date, a.created_at, a.updated_at`
+
+// scanAffiliation scans affiliation columns from a row followed by extra destinations.
+func scanAffiliation(row pgx.Row, a *foo.Affiliation, extra ...interface{}) error {
+	var start, end pgtype.Date
+	if err := row.Scan(append(affiliationDest(a, &start, &end), extra...)...); err != nil {
+		return err
+	}
+	setAffiliationDates(a, start, end)
+	return nil
+}
+
+func affiliationDest(a *foo.Affiliation, start, end *pgtype.Date) []interface{} {
+	return []interface{}{&a.ID, &a.FighterID, &a.TeamID, start, end, &a.CreatedAt, &a.UpdatedAt}
+}
+
+func setAffiliationDates(a *foo.Affiliation, start, end pgtype.Date) {
+	a.StartDate = foo.Date{Time: start.Time}
+	a.EndDate = nil
+	if end.Valid {
+		a.EndDate = &foo.Date{Time: end.Time}
+	}
+}
+
+// affiliationOverlapConstraint excludes overlapping periods of a fighter with
+// the same team.
+const affiliationOverlapConstraint = "fighter_affiliations_period_excl"
+
+// isAffiliationOverlap reports whether err violates affiliationOverlapConstraint.
+func isAffiliationOverlap(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.ConstraintName == affiliationOverlapConstraint
+}
+
+func (c *Client) Team(ctx context.Context, id uuid.UUID) (*foo.Team, error) {
+	var t foo.Team
+	row := c.db.QueryRow(ctx, `SELECT `+teamColumns+` FROM teams WHERE id=$1`, id.String())
+	if err := scanTeam(row, &t); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrTeamNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (c *Client) Teams(ctx context.Context, q foo.TeamQuery) ([]foo.Team, error) {
+	rows, err := c.db.Query(ctx, `SELECT `+teamColumns+` FROM teams ORDER BY name, id LIMIT $1`, q.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tt []foo.Team
+	for rows.Next() {
+		var t foo.Team
+		if err = scanTeam(rows, &t); err != nil {
+			return nil, err
+		}
+		tt = append(tt, t)
+	}
+	return tt, rows.Err()
+}
+
+func (c *Client) CreateTeam(ctx context.Context, t *foo.Team) error {
+	row := c.db.QueryRow(ctx, `
+		INSERT INTO teams (name, location, head_coach) VALUES ($1, $2, $3)
+		RETURNING `+teamColumns,
+		t.Name, t.Location, t.HeadCoach)
+	return scanTeam(row, t)
+}
+
+func (c *Client) UpdateTeam(ctx context.Context, t *foo.Team) error {
+	row := c.db.QueryRow(ctx, `
+		UPDATE teams SET name=$2, location=$3, head_coach=$4, updated_at=now()
+		WHERE id=$1
+		RETURNING `+teamColumns,
+		t.ID.String(), t.Name, t.Location, t.HeadCoach)
+	if err := scanTeam(row, t); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return foo.ErrTeamNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *Client) DeleteTeam(ctx context.Context, id uuid.UUID) error {
+	tag, err := c.db.Exec(ctx, `DELETE FROM teams WHERE id=$1`, id.String())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return foo.ErrTeamNotFound
+	}
+	return nil
+}
+
+func (c *Client) TeamAffiliations(ctx context.Context, teamID uuid.UUID) ([]foo.Affiliation, error) {
+	rows, err := c.db.Query(ctx, `SELECT `+fighterSelect()+`, `+affiliationColumns+`
+		FROM fighter_affiliations a
+		JOIN fighters f ON f.id = a.fighter_id`+fighterRecordJoin+`
+		WHERE a.team_id=$1 AND f.deleted_at IS NULL
+		ORDER BY a.start_date DESC, a.id`, teamID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aa []foo.Affiliation
+	for rows.Next() {
+		var a foo.Affiliation
+		var f foo.Fighter
+		var start, end pgtype.Date
+		if err = scanFighter(rows, &f, affiliationDest(&a, &start, &end)...); err != nil {
+			return nil, err
+		}
+		setAffiliationDates(&a, start, end)
+		a.Fighter = &f
+		aa = append(aa, a)
+	}
+	return aa, rows.Err()
+}
+
+func (c *Client) FighterAffiliations(ctx context.Context, fighterID uuid.UUID) ([]foo.Affiliation, error) {
+	rows, err := c.db.Query(ctx, `SELECT `+affiliationColumns+`,
+			t.id, t.name, t.location, t.head_coach, t.created_at, t.updated_at
+		FROM fighter_affiliations a
+		JOIN teams t ON t.id = a.team_id
+		WHERE a.fighter_id=$1
+		ORDER BY a.start_date DESC, a.id`, fighterID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aa []foo.Affiliation
+	for rows.Next() {
+		var a foo.Affiliation
+		var t foo.Team
+		if err = scanAffiliation(rows, &a, &t.ID, &t.Name, &t.Location, &t.HeadCoach, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		a.Team = &t
+		aa = append(aa, a)
+	}
+	return aa, rows.Err()
+}
+
+func (c *Client) CreateAffiliation(ctx context.Context, a *foo.Affiliation) error {
+	team := a.Team
+	row := c.db.QueryRow(ctx, `
+		INSERT INTO fighter_affiliations AS a (fighter_id, team_id, start_date, end_date)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+affiliationColumns,
+		a.FighterID.String(), a.TeamID.String(), dateValue(&a.StartDate), dateValue(a.EndDate))
+	if err := scanAffiliation(row, a); err != nil {
+		if isAffiliationOverlap(err) {
+			return foo.ErrAffiliationInvalid
+		}
+		return err
+	}
+	a.Team = team
+	return nil
+}
+
+func (c *Client) UpdateAffiliation(ctx context.Context, a *foo.Affiliation) error {
+	team := a.Team
+	row := c.db.QueryRow(ctx, `
+		UPDATE fighter_affiliations AS a SET team_id=$3, start_date=$4, end_date=$5, updated_at=now()
+		WHERE a.id=$1 AND a.fighter_id=$2
+		RETURNING `+affiliationColumns,
+		a.ID.String(), a.FighterID.String(), a.TeamID.String(), dateValue(&a.StartDate), dateValue(a.EndDate))
+	if err := scanAffiliation(row, a); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return foo.ErrAffiliationNotFound
+		}
+		if isAffiliationOverlap(err) {
+			return foo.ErrAffiliationInvalid
+		}
+		return err
+	}
+	a.Team = team
+	return nil
+}
+
+func (c *Client) DeleteAffiliation(ctx context.Context, fighterID, id uuid.UUID) error {
+	tag, err := c.db.Exec(ctx, `DELETE FROM fighter_affiliations WHERE id=$2 AND fighter_id=$1`,
+		fighterID.String(), id.String())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return foo.ErrAffiliationNotFound
+	}
+	return nil
+}
+
+// mergeAffiliations collapses overlapping periods of a fighter and its
+// duplicate with the same team into the earliest created affiliation, so
+// moving the duplicate affiliations keeps affiliationOverlapConstraint.
+func mergeAffiliations(ctx context.Context, tx pgx.Tx, id, duplicateID uuid.UUID) error {
+	rows, err := tx.Query(ctx, `
+		WITH p AS (
+			SELECT id, team_id, start_date, end_date, created_at,
+				max(COALESCE(end_date, 'infinity')) OVER (
+					PARTITION BY team_id ORDER BY start_date, id
+					ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS prev_end
+			FROM fighter_affiliations WHERE fighter_id IN ($1, $2)
+		), g AS (
+			SELECT *, sum(CASE WHEN start_date <= prev_end THEN 0 ELSE 1 END) OVER (
+				PARTITION BY team_id ORDER BY start_date, id) AS island
+			FROM p
+		)
+		SELECT array_agg(id::text ORDER BY created_at, id), min(start_date),
+			NULLIF(max(COALESCE(end_date, 'infinity')), 'infinity')
+		FROM g
+		GROUP BY team_id, island
+		HAVING count(*) > 1`, id.String(), duplicateID.String())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type island struct {
+		ids        []string
+		start, end pgtype.Date
+	}
+	var ii []island
+	for rows.Next() {
+		var i island
+		if err = rows.Scan(&i.ids, &i.start, &i.end); err != nil {
+			return err
+		}
+		ii = append(ii, i)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for _, i := range ii {
+		if _, err = tx.Exec(ctx, `DELETE FROM fighter_affiliations WHERE id = ANY($1::uuid[])`, i.ids[1:]); err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			UPDATE fighter_affiliations SET start_date=$2, end_date=$3, updated_at=now()
+			WHERE id=$1`, i.ids[0], i.start, i.end)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kudarap/foo"
+)
+
+func TestClient_MergeFighter_Affiliations(t *testing.T) {
+	c := newTestClient(t)
+	ctx := foo.ContextWithTenant(context.Background(), "acme")
+	date := func(s string) *foo.Date {
+		tt, _ := time.Parse("2006-01-02", s)
+		d := foo.NewDate(tt)
+		return &d
+	}
+
+	f := &foo.Fighter{FirstName: "jose", LastName: "aldo"}
+	dup := &foo.Fighter{FirstName: "josé", LastName: "aldo"}
+	team := &foo.Team{Name: "Nova Uniao"}
+	for _, ff := range []*foo.Fighter{f, dup} {
+		if err := c.CreateFighter(ctx, ff); err != nil {
+			t.Fatalf("CreateFighter() unexpected error %s", err)
+		}
+	}
+	if err := c.CreateTeam(ctx, team); err != nil {
+		t.Fatalf("CreateTeam() unexpected error %s", err)
+	}
+	t.Cleanup(func() {
+		c.db.Exec(context.Background(), `DELETE FROM fighters WHERE id = ANY($1::uuid[])`,
+			[]string{f.ID.String(), dup.ID.String()})
+		c.db.Exec(context.Background(), `DELETE FROM teams WHERE id=$1`, team.ID.String())
+	})
+
+	for _, a := range []*foo.Affiliation{
+		{FighterID: f.ID, TeamID: team.ID, StartDate: *date("2004-01-01"), EndDate: date("2016-12-31")},
+		{FighterID: dup.ID, TeamID: team.ID, StartDate: *date("2016-06-01")},
+	} {
+		if err := c.CreateAffiliation(ctx, a); err != nil {
+			t.Fatalf("CreateAffiliation() unexpected error %s", err)
+		}
+	}
+	overlap := &foo.Affiliation{FighterID: f.ID, TeamID: team.ID, StartDate: *date("2010-01-01"), EndDate: date("2011-01-01")}
+	if err := c.CreateAffiliation(ctx, overlap); !errors.Is(err, foo.ErrAffiliationInvalid) {
+		t.Fatalf("CreateAffiliation() overlap error = %v, want invalid", err)
+	}
+
+	if _, err := c.MergeFighter(ctx, f.ID, dup.ID); err != nil {
+		t.Fatalf("MergeFighter() unexpected error %s", err)
+	}
+	aa, err := c.FighterAffiliations(ctx, f.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(aa) != 1 || !aa[0].StartDate.Equal(date("2004-01-01").Time) || aa[0].EndDate != nil {
+		t.Errorf("FighterAffiliations() after merge = %+v, want one period from 2004-01-01", aa)
+	}
+}
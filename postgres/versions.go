@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kudarap/foo"
+)
+
+// FighterAsOf returns the fighter version valid at the given time with its
+// record of bouts dated on or before it. Merged fighter id resolves to the
+// fighter it was merged into.
+func (c *Client) FighterAsOf(ctx context.Context, id uuid.UUID, at time.Time) (*foo.Fighter, error) {
+	var fighter foo.Fighter
+	row := c.db.QueryRow(ctx, `SELECT `+fighterSelect()+` FROM (
+			SELECT v.fighter_id AS id, v.* FROM fighter_versions v
+			WHERE v.fighter_id=COALESCE((SELECT fighter_id FROM fighter_aliases WHERE alias_id=$1), $1)
+				AND v.valid_from <= $2 AND (v.valid_to IS NULL OR v.valid_to > $2)
+		) f`+fighterRecordJoinOn(` AND date <= ($2::timestamptz AT TIME ZONE 'UTC')::date`)+`
+		WHERE f.deleted_at IS NULL`, id.String(), at)
+	if err := scanFighter(row, &fighter); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrFighterNotFound
+		}
+		return nil, err
+	}
+	return &fighter, nil
+}
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/kudarap/foo"
+)
+
+const webhookColumns = `id, url, events, secret, user_id, failures, disabled_at, created_at, updated_at`
+
+func scanWebhook(row pgx.Row, w *foo.Webhook) error {
+	return row.Scan(&w.ID, &w.URL, &w.Events, &w.Secret, &w.UserID, &w.Failures, &w.DisabledAt,
+		&w.CreatedAt, &w.UpdatedAt)
+}
+
+const webhookDeliveryColumns = `id, webhook_id, event_id, event, attempt, status_code, error, duration_ms, created_at`
+
+func (c *Client) CreateWebhook(ctx context.Context, w *foo.Webhook) error {
+	row := c.db.QueryRow(ctx, `
+		INSERT INTO webhooks (id, url, events, secret, user_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+webhookColumns,
+		w.ID.String(), w.URL, w.Events, w.Secret, w.UserID)
+	return scanWebhook(row, w)
+}
+
+func (c *Client) Webhook(ctx context.Context, id uuid.UUID) (*foo.Webhook, error) {
+	var w foo.Webhook
+	row := c.db.QueryRow(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id=$1`, id.String())
+	if err := scanWebhook(row, &w); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (c *Client) Webhooks(ctx context.Context) ([]foo.Webhook, error) {
+	return c.queryWebhooks(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY created_at DESC, id`)
+}
+
+// WebhooksByEvent returns enabled webhooks subscribed to the event.
+func (c *Client) WebhooksByEvent(ctx context.Context, event string) ([]foo.Webhook, error) {
+	return c.queryWebhooks(ctx, `
+		SELECT `+webhookColumns+` FROM webhooks
+		WHERE events @> ARRAY[$1::text] AND disabled_at IS NULL
+		ORDER BY id`, event)
+}
+
+func (c *Client) queryWebhooks(ctx context.Context, sql string, args ...interface{}) ([]foo.Webhook, error) {
+	rows, err := c.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ww []foo.Webhook
+	for rows.Next() {
+		var w foo.Webhook
+		if err = scanWebhook(rows, &w); err != nil {
+			return nil, err
+		}
+		ww = append(ww, w)
+	}
+	return ww, rows.Err()
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	tag, err := c.db.Exec(ctx, `DELETE FROM webhooks WHERE id=$1`, id.String())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return foo.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// EnableWebhook clears webhook failures and disabled time.
+func (c *Client) EnableWebhook(ctx context.Context, id uuid.UUID) (*foo.Webhook, error) {
+	var w foo.Webhook
+	row := c.db.QueryRow(ctx, `
+		UPDATE webhooks SET failures = 0, disabled_at = NULL, updated_at = now()
+		WHERE id=$1
+		RETURNING `+webhookColumns, id.String())
+	if err := scanWebhook(row, &w); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &w, nil
+}
+
+// AddWebhookDelivery logs a delivery and resets or increments its webhook
+// consecutive failures on the same transaction, disabling the webhook when
+// they reach disableAfter.
+func (c *Client) AddWebhookDelivery(ctx context.Context, d *foo.WebhookDelivery, disableAfter int) (*foo.Webhook, error) {
+	var w foo.Webhook
+	err := pgx.BeginFunc(ctx, c.db, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			INSERT INTO webhook_deliveries (id, webhook_id, event_id, event, attempt, status_code, error, duration_ms)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING created_at`,
+			d.ID.String(), d.WebhookID.String(), d.EventID.String(), d.Event, d.Attempt, d.StatusCode, d.Error,
+			d.DurationMS).Scan(&d.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		row := tx.QueryRow(ctx, `
+			UPDATE webhooks SET
+				failures = CASE WHEN $2 THEN 0 ELSE failures + 1 END,
+				disabled_at = CASE WHEN NOT $2 AND failures + 1 >= $3 THEN COALESCE(disabled_at, now()) ELSE disabled_at END,
+				updated_at = now()
+			WHERE id=$1
+			RETURNING `+webhookColumns, d.WebhookID.String(), d.Succeeded(), disableAfter)
+		return scanWebhook(row, &w)
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, foo.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (c *Client) WebhookDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]foo.WebhookDelivery, error) {
+	rows, err := c.db.Query(ctx, `
+		SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries
+		WHERE webhook_id=$1
+		ORDER BY created_at DESC, id
+		LIMIT $2`, webhookID.String(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dd []foo.WebhookDelivery
+	for rows.Next() {
+		var d foo.WebhookDelivery
+		err = rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.Event, &d.Attempt, &d.StatusCode, &d.Error,
+			&d.DurationMS, &d.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		dd = append(dd, d)
+	}
+	return dd, rows.Err()
+}
+
+func (c *Client) PurgeWebhookDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := c.db.Exec(ctx, `DELETE FROM webhook_deliveries WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
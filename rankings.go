@@ -0,0 +1,154 @@
+package foo
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+var ErrRankingInvalid = xerror.Error(xerror.CodeInvalid)
+
+// Rating represents a fighter Glicko-2 rating on a weight class.
+type Rating struct {
+	FighterID   uuid.UUID   `json:"fighter_id"`
+	FighterName string      `json:"fighter_name"`
+	WeightClass WeightClass `json:"weight_class"`
+	Rank        int         `json:"rank"`
+	Rating      float64     `json:"rating"`
+	Deviation   float64     `json:"deviation"`
+	Volatility  float64     `json:"volatility"`
+	Bouts       int         `json:"bouts"`
+	LastBout    Date        `json:"last_bout"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// RatingChange represents a fighter rating after a rating period.
+type RatingChange struct {
+	FighterID   uuid.UUID   `json:"fighter_id"`
+	WeightClass WeightClass `json:"weight_class"`
+	Date        Date        `json:"date"`
+	Rating      float64     `json:"rating"`
+	Deviation   float64     `json:"deviation"`
+	Volatility  float64     `json:"volatility"`
+}
+
+// boutScore returns the Glicko score of a fighter result, no contest is not rated.
+func boutScore(r BoutResult) (score float64, rated bool) {
+	switch r {
+	case BoutResultWin:
+		return 1, true
+	case BoutResultLoss:
+		return 0, true
+	case BoutResultDraw:
+		return 0.5, true
+	}
+	return 0, false
+}
+
+// computeRatings replays completed bouts of a weight class from scratch and returns
+// ranked ratings and rating history. Each bout date is a rating period where bouts
+// are rated against opponent ratings before that date and rated fighters sitting
+// it out only gain deviation, which is not recorded in history. Bouts are replayed
+// by date then bout id which makes the computation deterministic regardless of
+// input order.
+func computeRatings(wc WeightClass, bouts []Bout) ([]Rating, []RatingChange) {
+	bb := make([]Bout, 0, len(bouts))
+	for _, b := range bouts {
+		if b.Completed() && b.WeightClass == wc {
+			bb = append(bb, b)
+		}
+	}
+	sort.Slice(bb, func(i, j int) bool {
+		if !bb[i].Date.Equal(bb[j].Date.Time) {
+			return bb[i].Date.Before(bb[j].Date.Time)
+		}
+		return bb[i].ID.String() < bb[j].ID.String()
+	})
+
+	current := map[uuid.UUID]glickoRating{}
+	ratingOf := func(id uuid.UUID) glickoRating {
+		if r, ok := current[id]; ok {
+			return r
+		}
+		return newGlickoRating()
+	}
+	counts := map[uuid.UUID]int{}
+	lastBout := map[uuid.UUID]Date{}
+
+	var history []RatingChange
+	for start := 0; start < len(bb); {
+		end := start
+		for end < len(bb) && bb[end].Date.Equal(bb[start].Date.Time) {
+			end++
+		}
+		period := bb[start:end]
+		start = end
+
+		// Collects results against ratings before the period.
+		results := map[uuid.UUID][]glickoResult{}
+		var fighters []uuid.UUID
+		for _, b := range period {
+			for _, fid := range []uuid.UUID{b.RedFighterID, b.BlueFighterID} {
+				score, rated := boutScore(b.ResultFor(fid))
+				if !rated {
+					continue
+				}
+				if _, ok := results[fid]; !ok {
+					fighters = append(fighters, fid)
+				}
+				results[fid] = append(results[fid], glickoResult{ratingOf(b.Opponent(fid)), score})
+			}
+		}
+
+		updated := make(map[uuid.UUID]glickoRating, len(fighters))
+		for _, fid := range fighters {
+			updated[fid] = ratingOf(fid).update(results[fid])
+		}
+		// Rated fighters that sat out the period grow less certain.
+		for fid, r := range current {
+			if _, ok := updated[fid]; !ok {
+				current[fid] = r.update(nil)
+			}
+		}
+		date := period[0].Date
+		for _, fid := range fighters {
+			r := updated[fid]
+			current[fid] = r
+			counts[fid] += len(results[fid])
+			lastBout[fid] = date
+			history = append(history, RatingChange{
+				FighterID:   fid,
+				WeightClass: wc,
+				Date:        date,
+				Rating:      r.rating,
+				Deviation:   r.deviation,
+				Volatility:  r.volatility,
+			})
+		}
+	}
+
+	ratings := make([]Rating, 0, len(current))
+	for fid, r := range current {
+		ratings = append(ratings, Rating{
+			FighterID:   fid,
+			WeightClass: wc,
+			Rating:      r.rating,
+			Deviation:   r.deviation,
+			Volatility:  r.volatility,
+			Bouts:       counts[fid],
+			LastBout:    lastBout[fid],
+		})
+	}
+	sort.Slice(ratings, func(i, j int) bool {
+		if ratings[i].Rating != ratings[j].Rating {
+			return ratings[i].Rating > ratings[j].Rating
+		}
+		return ratings[i].FighterID.String() < ratings[j].FighterID.String()
+	})
+	for i := range ratings {
+		ratings[i].Rank = i + 1
+	}
+	return ratings, history
+}
@@ -8,13 +8,15 @@ import (
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
 )
 
 // authentication is middleware that looks for authorization bearer token
 // from header request and process verification. Verified token provides authorized
 // user id and adds to request context that can be use for validating authorized requests.
 //
-// When authorization header is not present it skips the verification.
+// When authorization header is not present it skips the verification. Request
+// tenant is the verified tenant_id claim or foo.DefaultTenant when absent.
 func authentication(auth authenticator) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
@@ -24,9 +26,10 @@ func authentication(auth authenticator) mux.MiddlewareFunc {
 				return
 			}
 			// When token is available it will be validated and parsed to get user id
-			// and attach to context for next handler.
+			// and tenant and attach to context for next handler.
+			ctx := r.Context()
+			tenantID := foo.DefaultTenant
 			if token != "" {
-				ctx := r.Context()
 				claims, err := auth.VerifyToken(ctx, token)
 				if err != nil {
 					encodeJSONError(w, err, http.StatusForbidden)
@@ -34,8 +37,11 @@ func authentication(auth authenticator) mux.MiddlewareFunc {
 				}
 				userID, _ := claims["user_id"].(string)
 				ctx = userToContext(ctx, userID)
-				r = r.WithContext(ctx)
+				if t, _ := claims["tenant_id"].(string); t != "" {
+					tenantID = t
+				}
 			}
+			r = r.WithContext(foo.ContextWithTenant(ctx, tenantID))
 
 			next.ServeHTTP(w, r)
 		}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kudarap/foo"
+)
+
+type mockAuthenticator map[string]interface{}
+
+func (m mockAuthenticator) VerifyToken(ctx context.Context, token string) (map[string]interface{}, error) {
+	return m, nil
+}
+
+func TestAuthentication_Tenant(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		claims     mockAuthenticator
+		wantTenant string
+	}{
+		{"anonymous", "", nil, foo.DefaultTenant},
+		{"no tenant claim", "t", mockAuthenticator{"user_id": "u1"}, foo.DefaultTenant},
+		{"tenant claim", "t", mockAuthenticator{"user_id": "u1", "tenant_id": "acme"}, "acme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			h := authentication(tt.claims)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = foo.TenantFromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/fighters", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			if got != tt.wantTenant {
+				t.Errorf("authentication() tenant = %q, want %q", got, tt.wantTenant)
+			}
+		})
+	}
+}
+
+type mockTenantService struct {
+	service
+	tenant string
+}
+
+func (m *mockTenantService) FighterByID(ctx context.Context, ref string) (*foo.Fighter, error) {
+	m.tenant, _ = foo.TenantFromContext(ctx)
+	return &foo.Fighter{Slug: ref}, nil
+}
+
+func TestRoutes_Tenant(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		claims     mockAuthenticator
+		wantTenant string
+	}{
+		{"anonymous", "", nil, foo.DefaultTenant},
+		{"no tenant claim", "t", mockAuthenticator{"user_id": "u1"}, foo.DefaultTenant},
+		{"tenant claim", "t", mockAuthenticator{"user_id": "u1", "tenant_id": "acme"}, "acme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockTenantService{}
+			s := &Server{
+				service:       svc,
+				authenticator: tt.claims,
+				tracing:       mockTracing{},
+				logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+			}
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/fighters/dave-grohl", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			s.Routes().ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("GET /fighters/dave-grohl status = %d, want %d", w.Code, http.StatusOK)
+			}
+			if svc.tenant != tt.wantTenant {
+				t.Errorf("GET /fighters/dave-grohl tenant = %q, want %q", svc.tenant, tt.wantTenant)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kudarap/foo"
 )
 
 // Key to use when setting the request id.
@@ -25,6 +26,11 @@ type responseRecorder struct {
 	code        int
 }
 
+// Unwrap returns the underlying response writer used by http.ResponseController.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 func (r *responseRecorder) WriteHeader(statusCode int) {
 	if r.wroteHeader {
 		return
@@ -64,6 +70,11 @@ func (s *Server) recoveryMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if rvr := recover(); rvr != nil {
+				// Aborted handler is left to http server to close the connection.
+				if rvr == http.ErrAbortHandler {
+					panic(rvr)
+				}
+
 				rqd, err := httputil.DumpRequest(r, true)
 				if err != nil {
 					s.logger.Error(err.Error())
@@ -100,3 +111,14 @@ func requestIDMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// actorMiddleware is a middleware that sets user id and request id from request context
+// as foo actor that is recorded on changes.
+func actorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		reqID, _ := ctx.Value(requestIDKey).(string)
+		ctx = foo.ContextWithActor(ctx, foo.Actor{UserID: userFromContext(ctx), RequestID: reqID})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -3,8 +3,11 @@ package server
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/kudarap/foo/xerror"
 )
@@ -21,9 +24,10 @@ func encodeJSONResp(w http.ResponseWriter, data interface{}, code int) {
 
 func encodeJSONError(w http.ResponseWriter, err error, statusCode int) {
 	m := struct {
-		Error  string `json:"error"`
-		Code   string `json:"code,omitempty"`
-		Status int    `json:"status"`
+		Error   string             `json:"error"`
+		Code    string             `json:"code,omitempty"`
+		Status  int                `json:"status"`
+		Details []xerror.Violation `json:"details,omitempty"`
 	}{}
 	m.Error = err.Error()
 	m.Status = statusCode
@@ -34,10 +38,40 @@ func encodeJSONError(w http.ResponseWriter, err error, statusCode int) {
 		m.Error = errX.Err.Error()
 		m.Code = errX.Code
 	}
+	// Field violations are listed so clients can point out each invalid input.
+	var errV xerror.ValidationError
+	if errors.As(err, &errV) {
+		m.Details = errV
+	}
 
 	encodeJSONResp(w, m, statusCode)
 }
 
+// errorStatus returns http status code base on xerror code and fallbacks
+// to given status code when error is not coded or unknown. Validation
+// errors are unprocessable regardless of code.
+func errorStatus(err error, fallback int) int {
+	var errV xerror.ValidationError
+	if errors.As(err, &errV) {
+		return http.StatusUnprocessableEntity
+	}
+
+	var errX xerror.XError
+	if !errors.As(err, &errX) {
+		return fallback
+	}
+
+	switch errX.Code {
+	case xerror.CodeNotFound:
+		return http.StatusNotFound
+	case xerror.CodeInvalid:
+		return http.StatusBadRequest
+	case xerror.CodeConflict:
+		return http.StatusConflict
+	}
+	return fallback
+}
+
 func decodeJSONReq(r *http.Request, in interface{}) error {
 	reqBody, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -50,3 +84,36 @@ func decodeJSONReq(r *http.Request, in interface{}) error {
 	}
 	return nil
 }
+
+// versionETag returns strong entity tag of a resource version.
+func versionETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// ifMatchVersion returns resource version from If-Match request header, ok is
+// false when header is absent or matches any version.
+func ifMatchVersion(r *http.Request) (version int, ok bool, err error) {
+	h := strings.TrimSpace(r.Header.Get("If-Match"))
+	if h == "" || h == "*" {
+		return 0, false, nil
+	}
+	s, err := strconv.Unquote(h)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid If-Match: %s", h)
+	}
+	version, err = strconv.Atoi(s)
+	if err != nil || version <= 0 {
+		return 0, false, fmt.Errorf("invalid If-Match: %s", h)
+	}
+	return version, true, nil
+}
+
+// writeErrorStatus returns http status code of a versioned write error,
+// conflict on If-Match precondition is reported as 412.
+func writeErrorStatus(err error, ifMatch bool) int {
+	status := errorStatus(err, http.StatusBadRequest)
+	if status == http.StatusConflict && ifMatch {
+		return http.StatusPreconditionFailed
+	}
+	return status
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifMatch     string
+		wantVersion int
+		wantOK      bool
+		wantErr     bool
+	}{
+		{"absent", "", 0, false, false},
+		{"any", "*", 0, false, false},
+		{"version", `"3"`, 3, true, false},
+		{"unquoted", `3`, 0, false, true},
+		{"weak", `W/"3"`, 0, false, true},
+		{"not a version", `"abc"`, 0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "http://localhost/fighters/1", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			version, ok, err := ifMatchVersion(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ifMatchVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if version != tt.wantVersion || ok != tt.wantOK {
+				t.Errorf("ifMatchVersion() = %d, %v, want %d, %v", version, ok, tt.wantVersion, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestWriteErrorStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		ifMatch bool
+		want    int
+	}{
+		{"stale body version", foo.ErrFighterConflict.X(foo.ErrFighterConflict), false, http.StatusConflict},
+		{"stale If-Match", foo.ErrFighterConflict.X(foo.ErrFighterConflict), true, http.StatusPreconditionFailed},
+		{"not found", foo.ErrFighterNotFound.X(foo.ErrFighterNotFound), true, http.StatusNotFound},
+		{"invalid field", foo.ErrFighterInvalid.X(xerror.NewValidationError("id", xerror.ViolationMalformed, "must be a uuid")), true, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := writeErrorStatus(tt.err, tt.ifMatch); got != tt.want {
+				t.Errorf("writeErrorStatus() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeJSONError_Validation(t *testing.T) {
+	ve := xerror.ValidationError{}.
+		Add("first_name", xerror.ViolationRequired, "is required").
+		Add("height_cm", xerror.ViolationOutOfRange, "must be between 50 and 300")
+	err := foo.ErrFighterInvalid.X(ve)
+
+	w := httptest.NewRecorder()
+	encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("encodeJSONError() status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	var got struct {
+		Code    string             `json:"code"`
+		Status  int                `json:"status"`
+		Details []xerror.Violation `json:"details"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Code != xerror.CodeInvalid || got.Status != http.StatusUnprocessableEntity {
+		t.Errorf("encodeJSONError() code = %s, status = %d", got.Code, got.Status)
+	}
+	if !reflect.DeepEqual(got.Details, []xerror.Violation(ve)) {
+		t.Errorf("encodeJSONError() details = %v, want %v", got.Details, ve)
+	}
+}
@@ -20,6 +20,7 @@ type Server struct {
 	service         service
 	authenticator   authenticator
 	databaseChecker databasePinger
+	blobs           http.Handler
 
 	tracing tracing
 	logger  *slog.Logger
@@ -32,6 +33,7 @@ func New(
 	service service,
 	authenticator authenticator,
 	databaseChecker databasePinger,
+	blobs http.Handler,
 	tracing tracing,
 	version Version,
 	logger *slog.Logger,
@@ -50,6 +52,7 @@ func New(
 		service:         service,
 		authenticator:   authenticator,
 		databaseChecker: databaseChecker,
+		blobs:           blobs,
 		tracing:         tracing,
 		Version:         version,
 		logger:          l,
@@ -70,6 +73,7 @@ func (s *Server) Routes() http.Handler {
 		s.tracing.Middleware(),
 		authentication(s.authenticator),
 		requestIDMiddleware,
+		actorMiddleware,
 		s.loggingMiddleware,
 		s.recoveryMiddleware,
 	)
@@ -77,7 +81,28 @@ func (s *Server) Routes() http.Handler {
 	// Public endpoints
 	r.HandleFunc("/version", GetVersion(s.Version)).Methods(http.MethodGet)
 	r.HandleFunc("/healthcheck", Healthcheck(s.databaseChecker)).Methods(http.MethodGet)
+	r.HandleFunc("/fighters/search", SearchFighters(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/fighters/export", ExportFighters(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/fighters/duplicates", ListFighterDuplicates(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/fighters/compare", CompareFighters(s.service)).Methods(http.MethodGet)
 	r.HandleFunc("/fighters/{id}", GetFighterByID(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/fighters/{id}/bouts", ListFighterBouts(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/fighters/{id}/ratings", ListFighterRatings(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/fighters/{id}/stats", GetFighterStats(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/fighters/{id}/history", ListFighterHistory(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/fighters/{id}/media", ListFighterMedia(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/fighters/{id}/teams", ListFighterTeams(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/bouts/{id}", GetBoutByID(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/events", ListEvents(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/rankings/{weightClass}", ListRankings(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/events/{id}", GetEventByID(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/teams", ListTeams(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/teams/{id}", GetTeamByID(s.service)).Methods(http.MethodGet)
+	r.HandleFunc("/teams/{id}/fighters", ListTeamFighters(s.service)).Methods(http.MethodGet)
+	if s.blobs != nil {
+		// Signed blob urls of local blob store, access is checked by the handler.
+		r.PathPrefix(BlobsPath+"/").Handler(http.StripPrefix(BlobsPath, s.blobs)).Methods(http.MethodGet, http.MethodHead)
+	}
 	r.NotFoundHandler = s.noMatchHandler(http.StatusNotFound)
 	r.MethodNotAllowedHandler = s.noMatchHandler(http.StatusMethodNotAllowed)
 
@@ -85,6 +110,47 @@ func (s *Server) Routes() http.Handler {
 	pr := r.PathPrefix("/").Subrouter()
 	//pr.Use(authorizedMiddleware)
 	pr.HandleFunc("/fighters", ListFighters(s.service)).Methods(http.MethodGet)
+	pr.HandleFunc("/fighters", CreateFighter(s.service)).Methods(http.MethodPost)
+	pr.HandleFunc("/fighters/imports", CreateFighterImport(s.service)).Methods(http.MethodPost)
+	pr.HandleFunc("/fighters/imports/{id}", GetFighterImportByID(s.service)).Methods(http.MethodGet)
+	pr.HandleFunc("/fighters/{id}", UpdateFighter(s.service)).Methods(http.MethodPut)
+	pr.HandleFunc("/fighters/{id}", DeleteFighter(s.service)).Methods(http.MethodDelete)
+	pr.HandleFunc("/fighters/{id}:restore", RestoreFighter(s.service)).Methods(http.MethodPost)
+	pr.HandleFunc("/fighters/{id}/media", CreateFighterMedia(s.service)).Methods(http.MethodPost)
+	pr.HandleFunc("/fighters/{id}:merge", MergeFighter(s.service)).Methods(http.MethodPost)
+	pr.HandleFunc("/admin/fighters/deleted", ListDeletedFighters(s.service)).Methods(http.MethodGet)
+	pr.HandleFunc("/bouts", CreateBout(s.service)).Methods(http.MethodPost)
+	pr.HandleFunc("/bouts/{id}", UpdateBout(s.service)).Methods(http.MethodPut)
+	pr.HandleFunc("/bouts/{id}", DeleteBout(s.service)).Methods(http.MethodDelete)
+	pr.HandleFunc("/events", CreateEvent(s.service)).Methods(http.MethodPost)
+	pr.HandleFunc("/events/{id}", UpdateEvent(s.service)).Methods(http.MethodPut)
+	pr.HandleFunc("/events/{id}", DeleteEvent(s.service)).Methods(http.MethodDelete)
+	pr.HandleFunc("/teams", CreateTeam(s.service)).Methods(http.MethodPost)
+	pr.HandleFunc("/teams/{id}", UpdateTeam(s.service)).Methods(http.MethodPut)
+	pr.HandleFunc("/teams/{id}", DeleteTeam(s.service)).Methods(http.MethodDelete)
+	pr.HandleFunc("/fighters/{id}/teams", CreateFighterTeam(s.service)).Methods(http.MethodPost)
+	pr.HandleFunc("/fighters/{id}/teams/{affiliationID}", UpdateFighterTeam(s.service)).Methods(http.MethodPut)
+	pr.HandleFunc("/fighters/{id}/teams/{affiliationID}", DeleteFighterTeam(s.service)).Methods(http.MethodDelete)
+
+	// User owned endpoints keyed by the authorized user
+	me := pr.PathPrefix("/me").Subrouter()
+	me.Use(authorizedMiddleware)
+	me.HandleFunc("/follows", ListFollows(s.service)).Methods(http.MethodGet)
+	me.HandleFunc("/follows/{fighterID}", FollowFighter(s.service)).Methods(http.MethodPut)
+	me.HandleFunc("/follows/{fighterID}", UnfollowFighter(s.service)).Methods(http.MethodDelete)
+	me.HandleFunc("/notifications", ListNotifications(s.service)).Methods(http.MethodGet)
+	me.HandleFunc("/notifications/{id}:read", ReadNotification(s.service)).Methods(http.MethodPost)
+	me.HandleFunc("/notification-preferences", GetNotificationPreferences(s.service)).Methods(http.MethodGet)
+	me.HandleFunc("/notification-preferences", UpdateNotificationPreferences(s.service)).Methods(http.MethodPut)
+
+	// Outbound webhook subscriptions of the tenant
+	wh := pr.PathPrefix("/webhooks").Subrouter()
+	wh.Use(authorizedMiddleware)
+	wh.HandleFunc("", CreateWebhook(s.service)).Methods(http.MethodPost)
+	wh.HandleFunc("", ListWebhooks(s.service)).Methods(http.MethodGet)
+	wh.HandleFunc("/{id}", DeleteWebhook(s.service)).Methods(http.MethodDelete)
+	wh.HandleFunc("/{id}:enable", EnableWebhook(s.service)).Methods(http.MethodPost)
+	wh.HandleFunc("/{id}/deliveries", ListWebhookDeliveries(s.service)).Methods(http.MethodGet)
 	return r
 }
 
@@ -136,6 +202,9 @@ const (
 	defaultShutdownTimeout = time.Second * 5
 )
 
+// BlobsPath is the path prefix serving local blob store signed urls.
+const BlobsPath = "/blobs"
+
 // Config represents server config.
 type Config struct {
 	Addr            string
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
+)
+
+type boutService interface {
+	BoutByID(ctx context.Context, id string) (*foo.Bout, error)
+	FighterBouts(ctx context.Context, fighterID string) ([]foo.FighterBout, error)
+	CreateBout(ctx context.Context, b *foo.Bout) (*foo.Bout, error)
+	UpdateBout(ctx context.Context, id string, b *foo.Bout) (*foo.Bout, error)
+	DeleteBout(ctx context.Context, id string) error
+}
+
+func GetBoutByID(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		b, err := s.BoutByID(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, b, http.StatusOK)
+	}
+}
+
+func ListFighterBouts(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		bb, err := s.FighterBouts(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.FighterBout `json:"data"`
+		}{bb}, http.StatusOK)
+	}
+}
+
+func CreateBout(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var b foo.Bout
+		if err := decodeJSONReq(r, &b); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		c, err := s.CreateBout(r.Context(), &b)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, c, http.StatusCreated)
+	}
+}
+
+func UpdateBout(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var b foo.Bout
+		if err := decodeJSONReq(r, &b); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		v := mux.Vars(r)
+		c, err := s.UpdateBout(r.Context(), v["id"], &b)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, c, http.StatusOK)
+	}
+}
+
+func DeleteBout(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		if err := s.DeleteBout(r.Context(), v["id"]); err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
+)
+
+type eventService interface {
+	EventByID(ctx context.Context, id string) (*foo.Event, error)
+	Events(ctx context.Context, q foo.EventQuery) ([]foo.Event, error)
+	CreateEvent(ctx context.Context, e *foo.Event) (*foo.Event, error)
+	UpdateEvent(ctx context.Context, id string, e *foo.Event) (*foo.Event, error)
+	DeleteEvent(ctx context.Context, id string) error
+}
+
+func GetEventByID(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		e, err := s.EventByID(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, e, http.StatusOK)
+	}
+}
+
+func ListEvents(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q, err := eventQueryFromURL(r.URL.Query())
+		if err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		ee, err := s.Events(r.Context(), q)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.Event `json:"data"`
+		}{ee}, http.StatusOK)
+	}
+}
+
+func CreateEvent(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var e foo.Event
+		if err := decodeJSONReq(r, &e); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		c, err := s.CreateEvent(r.Context(), &e)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, c, http.StatusCreated)
+	}
+}
+
+func UpdateEvent(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var e foo.Event
+		if err := decodeJSONReq(r, &e); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		v := mux.Vars(r)
+		c, err := s.UpdateEvent(r.Context(), v["id"], &e)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, c, http.StatusOK)
+	}
+}
+
+func DeleteEvent(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		if err := s.DeleteEvent(r.Context(), v["id"]); err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// eventQueryFromURL parses event list options from url query values.
+func eventQueryFromURL(v url.Values) (foo.EventQuery, error) {
+	var q foo.EventQuery
+	for key, dst := range map[string]**foo.Date{"from": &q.From, "to": &q.To} {
+		s := v.Get(key)
+		if s == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			return q, fmt.Errorf("invalid %s date: %s", key, s)
+		}
+		d := foo.NewDate(t)
+		*dst = &d
+	}
+	if l := v.Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil {
+			return q, fmt.Errorf("invalid limit: %s", l)
+		}
+		q.Limit = n
+	}
+	return q, nil
+}
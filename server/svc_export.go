@@ -0,0 +1,168 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kudarap/foo"
+)
+
+// exportFlushRows is the number of exported rows written before flushing response.
+const exportFlushRows = 100
+
+// ExportFighters streams every fighter matching listing filters as ndjson, csv
+// or a json array without buffering the whole export.
+func ExportFighters(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q, err := fighterQueryFromURL(r.URL.Query())
+		if err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "ndjson"
+		}
+		newEnc, ok := fighterEncoders[format]
+		if !ok {
+			encodeJSONError(w, fmt.Errorf("unsupported export format: %s", format), http.StatusBadRequest)
+			return
+		}
+
+		// Exports outlive server write timeout and are flushed as they go.
+		rc := http.NewResponseController(w)
+		_ = rc.SetWriteDeadline(time.Time{})
+
+		enc := newEnc(w)
+		var n int
+		begin := func() {
+			w.Header().Set("Content-Type", enc.contentType())
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="fighters.%s"`, format))
+			w.WriteHeader(http.StatusOK)
+			enc.begin()
+		}
+		err = s.ExportFighters(r.Context(), q, func(f foo.Fighter) error {
+			if n == 0 {
+				begin()
+			}
+			if err := enc.encode(f); err != nil {
+				return err
+			}
+			if n++; n%exportFlushRows == 0 {
+				if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			if n == 0 {
+				encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+				return
+			}
+			// Aborts response so client does not take a partial export as complete.
+			panic(http.ErrAbortHandler)
+		}
+		if n == 0 {
+			begin()
+		}
+		if err = enc.end(); err != nil {
+			panic(http.ErrAbortHandler)
+		}
+	}
+}
+
+// fighterEncoder writes fighters of an export.
+type fighterEncoder interface {
+	contentType() string
+	begin()
+	encode(f foo.Fighter) error
+	end() error
+}
+
+// fighterEncoders maps export format to its fighter encoder.
+var fighterEncoders = map[string]func(w io.Writer) fighterEncoder{
+	"ndjson": func(w io.Writer) fighterEncoder { return &ndjsonFighterEncoder{json.NewEncoder(w)} },
+	"json":   func(w io.Writer) fighterEncoder { return &jsonFighterEncoder{w: w} },
+	"csv":    func(w io.Writer) fighterEncoder { return &csvFighterEncoder{csv.NewWriter(w)} },
+}
+
+type ndjsonFighterEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonFighterEncoder) contentType() string        { return "application/x-ndjson" }
+func (e *ndjsonFighterEncoder) begin()                     {}
+func (e *ndjsonFighterEncoder) encode(f foo.Fighter) error { return e.enc.Encode(f) }
+func (e *ndjsonFighterEncoder) end() error                 { return nil }
+
+// jsonFighterEncoder writes fighters as elements of a json array.
+type jsonFighterEncoder struct {
+	w    io.Writer
+	next bool
+}
+
+func (e *jsonFighterEncoder) contentType() string { return contentType }
+
+func (e *jsonFighterEncoder) begin() { io.WriteString(e.w, "[") }
+
+func (e *jsonFighterEncoder) encode(f foo.Fighter) error {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if e.next {
+		b = append([]byte(","), b...)
+	}
+	e.next = true
+	_, err = e.w.Write(b)
+	return err
+}
+
+func (e *jsonFighterEncoder) end() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+// fighterCSVHeader lists fighter export csv columns.
+var fighterCSVHeader = []string{
+	"id", "first_name", "last_name", "nickname", "date_of_birth", "nationality", "stance",
+	"height_cm", "reach_cm", "weight_class", "wins", "losses", "draws", "no_contests",
+	"created_at", "updated_at",
+}
+
+type csvFighterEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvFighterEncoder) contentType() string { return "text/csv; charset=utf-8" }
+
+func (e *csvFighterEncoder) begin() { e.w.Write(fighterCSVHeader) }
+
+func (e *csvFighterEncoder) encode(f foo.Fighter) error {
+	var dob string
+	if f.DateOfBirth != nil {
+		dob = f.DateOfBirth.Format("2006-01-02")
+	}
+	e.w.Write([]string{
+		f.ID.String(), f.FirstName, f.LastName, f.Nickname, dob, f.Nationality, string(f.Stance),
+		strconv.Itoa(f.HeightCM), strconv.Itoa(f.ReachCM), string(f.WeightClass),
+		strconv.Itoa(f.Record.Wins), strconv.Itoa(f.Record.Losses), strconv.Itoa(f.Record.Draws),
+		strconv.Itoa(f.Record.NoContests),
+		f.CreatedAt.Format(time.RFC3339), f.UpdatedAt.Format(time.RFC3339),
+	})
+	// Flushes csv buffer to response writer so rows are not held in memory.
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvFighterEncoder) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
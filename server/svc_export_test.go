@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+)
+
+type mockExportService struct {
+	service
+	fighters []foo.Fighter
+}
+
+func (m *mockExportService) ExportFighters(ctx context.Context, q foo.FighterQuery, fn func(foo.Fighter) error) error {
+	for _, f := range m.fighters {
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportFighters(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	f1 := foo.Fighter{ID: uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140"), FirstName: "dave", LastName: "grohl",
+		Record: foo.Record{Wins: 2}, CreatedAt: ts, UpdatedAt: ts}
+	f2 := foo.Fighter{ID: uuid.MustParse("0b5e7c2b-4a3f-4f7e-9d4c-1a0c2b7e5d11"), FirstName: "taylor", LastName: "hawkins",
+		CreatedAt: ts, UpdatedAt: ts}
+	tests := []struct {
+		name        string
+		format      string
+		fighters    []foo.Fighter
+		wantCode    int
+		wantType    string
+		wantBody    string
+		wantAnyBody bool
+	}{
+		{
+			"csv",
+			"csv",
+			[]foo.Fighter{f1},
+			http.StatusOK,
+			"text/csv; charset=utf-8",
+			"id,first_name,last_name,nickname,date_of_birth,nationality,stance,height_cm,reach_cm,weight_class,wins,losses,draws,no_contests,created_at,updated_at\n" +
+				"b41c7709-04e3-4c48-b233-34e6838d9140,dave,grohl,,,,,0,0,,2,0,0,0,2024-01-02T03:04:05Z,2024-01-02T03:04:05Z\n",
+			false,
+		},
+		{
+			"json empty",
+			"json",
+			nil,
+			http.StatusOK,
+			contentType,
+			"[]\n",
+			false,
+		},
+		{
+			"json",
+			"json",
+			[]foo.Fighter{f1, f2},
+			http.StatusOK,
+			contentType,
+			"",
+			true,
+		},
+		{
+			"ndjson default",
+			"",
+			[]foo.Fighter{f2},
+			http.StatusOK,
+			"application/x-ndjson",
+			"",
+			true,
+		},
+		{
+			"unsupported",
+			"xml",
+			nil,
+			http.StatusBadRequest,
+			contentType,
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/fighters/export?format="+tt.format, nil)
+			w := httptest.NewRecorder()
+			ExportFighters(&mockExportService{fighters: tt.fighters})(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("ExportFighters() code = %d, want %d", w.Code, tt.wantCode)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantType {
+				t.Errorf("ExportFighters() content type = %s, want %s", got, tt.wantType)
+			}
+			if !tt.wantAnyBody && w.Body.String() != tt.wantBody {
+				t.Errorf("ExportFighters() body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+			switch {
+			case tt.wantCode != http.StatusOK:
+			case tt.format == "json":
+				var ff []foo.Fighter
+				if err := json.Unmarshal(w.Body.Bytes(), &ff); err != nil || len(ff) != len(tt.fighters) {
+					t.Errorf("ExportFighters() json = %d fighters, %v, want %d", len(ff), err, len(tt.fighters))
+				}
+			case tt.format == "":
+				if got := strings.Count(w.Body.String(), "\n"); got != len(tt.fighters) {
+					t.Errorf("ExportFighters() ndjson = %d lines, want %d", got, len(tt.fighters))
+				}
+			}
+		})
+	}
+}
@@ -2,22 +2,79 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
 )
 
 type service interface {
+	boutService
+	eventService
+	rankingService
+	importService
+	mediaService
+	followService
+	notificationService
+	webhookService
+	statsService
+	teamService
+
 	FighterByID(ctx context.Context, id string) (*foo.Fighter, error)
+	FighterAsOf(ctx context.Context, id string, at time.Time) (*foo.Fighter, error)
+	Fighters(ctx context.Context, q foo.FighterQuery) (*foo.FighterPage, error)
+	SearchFighters(ctx context.Context, q string, limit int) ([]foo.FighterSearchResult, error)
+	ExportFighters(ctx context.Context, q foo.FighterQuery, fn func(foo.Fighter) error) error
+	CreateFighter(ctx context.Context, f *foo.Fighter) (*foo.Fighter, error)
+	UpdateFighter(ctx context.Context, id string, f *foo.Fighter) (*foo.Fighter, error)
+	DeleteFighter(ctx context.Context, id string, version int) error
+	RestoreFighter(ctx context.Context, id string) (*foo.Fighter, error)
+	FighterHistory(ctx context.Context, id string) ([]foo.FighterChange, error)
+	FighterDuplicates(ctx context.Context, limit int) ([]foo.FighterDuplicate, error)
+	MergeFighter(ctx context.Context, id string, m foo.FighterMerge) (*foo.Fighter, error)
+	CompareFighters(ctx context.Context, a, b string) (*foo.FighterComparison, error)
 }
 
+// GetFighterByID responds fighter by id or slug, requests by id or a previous
+// slug are permanently redirected to the canonical slug url. Fighter as it was
+// at as_of time is responded as is.
 func GetFighterByID(s service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		v := mux.Vars(r)
+		if asOf := r.URL.Query().Get("as_of"); asOf != "" {
+			getFighterAsOf(w, r, s, v["id"], asOf)
+			return
+		}
+
 		c, err := s.FighterByID(r.Context(), v["id"])
 		if err != nil {
-			encodeJSONError(w, err, http.StatusBadRequest)
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+		if c.Slug != "" && v["id"] != c.Slug {
+			u := url.URL{Path: "/fighters/" + c.Slug, RawQuery: r.URL.RawQuery}
+			http.Redirect(w, r, u.String(), http.StatusMovedPermanently)
+			return
+		}
+
+		w.Header().Set("ETag", versionETag(c.Version))
+		encodeJSONResp(w, c, http.StatusOK)
+	}
+}
+
+// CompareFighters responds fighters a and b side by side, fighters may be
+// referenced by id or slug.
+func CompareFighters(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := r.URL.Query()
+		c, err := s.CompareFighters(r.Context(), v.Get("a"), v.Get("b"))
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
 			return
 		}
 
@@ -25,10 +82,240 @@ func GetFighterByID(s service) http.HandlerFunc {
 	}
 }
 
+func getFighterAsOf(w http.ResponseWriter, r *http.Request, s service, ref, asOf string) {
+	at, err := time.Parse(time.RFC3339, asOf)
+	if err != nil {
+		err = xerror.NewValidationError("as_of", xerror.ViolationMalformed, "must be an RFC 3339 timestamp")
+		encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+		return
+	}
+	c, err := s.FighterAsOf(r.Context(), ref, at)
+	if err != nil {
+		encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	encodeJSONResp(w, c, http.StatusOK)
+}
+
 func ListFighters(s service) http.HandlerFunc {
+	return listFighters(s, false)
+}
+
+// ListDeletedFighters lists deleted fighters that can still be restored.
+func ListDeletedFighters(s service) http.HandlerFunc {
+	return listFighters(s, true)
+}
+
+func listFighters(s service, deleted bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		q, err := fighterQueryFromURL(r.URL.Query())
+		if err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+		q.Deleted = deleted
+
+		p, err := s.Fighters(r.Context(), q)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		if p.NextCursor != "" {
+			w.Header().Set("Link", nextPageLink(r.URL, p.NextCursor))
+		}
+		encodeJSONResp(w, p, http.StatusOK)
+	}
+}
+
+func SearchFighters(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := r.URL.Query()
+		var limit int
+		if l := v.Get("limit"); l != "" {
+			n, err := strconv.Atoi(l)
+			if err != nil {
+				encodeJSONError(w, fmt.Errorf("invalid limit: %s", l), http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+
+		rr, err := s.SearchFighters(r.Context(), v.Get("q"), limit)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
 		encodeJSONResp(w, struct {
-			Msg string `json:"message"`
-		}{"no fighters yet implemented"}, http.StatusOK)
+			Data []foo.FighterSearchResult `json:"data"`
+		}{rr}, http.StatusOK)
 	}
 }
+
+func CreateFighter(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var f foo.Fighter
+		if err := decodeJSONReq(r, &f); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		c, err := s.CreateFighter(r.Context(), &f)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		w.Header().Set("ETag", versionETag(c.Version))
+		encodeJSONResp(w, c, http.StatusCreated)
+	}
+}
+
+func UpdateFighter(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var f foo.Fighter
+		if err := decodeJSONReq(r, &f); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		version, ok, err := ifMatchVersion(r)
+		if err != nil {
+			encodeJSONError(w, err, http.StatusPreconditionFailed)
+			return
+		}
+		if ok {
+			f.Version = version
+		}
+
+		v := mux.Vars(r)
+		c, err := s.UpdateFighter(r.Context(), v["id"], &f)
+		if err != nil {
+			encodeJSONError(w, err, writeErrorStatus(err, ok))
+			return
+		}
+
+		w.Header().Set("ETag", versionETag(c.Version))
+		encodeJSONResp(w, c, http.StatusOK)
+	}
+}
+
+func DeleteFighter(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		version, ok, err := ifMatchVersion(r)
+		if err != nil {
+			encodeJSONError(w, err, http.StatusPreconditionFailed)
+			return
+		}
+
+		v := mux.Vars(r)
+		if err = s.DeleteFighter(r.Context(), v["id"], version); err != nil {
+			encodeJSONError(w, err, writeErrorStatus(err, ok))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func RestoreFighter(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		c, err := s.RestoreFighter(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		w.Header().Set("ETag", versionETag(c.Version))
+		encodeJSONResp(w, c, http.StatusOK)
+	}
+}
+
+// MergeFighter merges the duplicate fighter on request body into the fighter.
+func MergeFighter(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m foo.FighterMerge
+		if err := decodeJSONReq(r, &m); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		v := mux.Vars(r)
+		c, err := s.MergeFighter(r.Context(), v["id"], m)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		w.Header().Set("ETag", versionETag(c.Version))
+		encodeJSONResp(w, c, http.StatusOK)
+	}
+}
+
+func ListFighterDuplicates(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var limit int
+		if l := r.URL.Query().Get("limit"); l != "" {
+			n, err := strconv.Atoi(l)
+			if err != nil {
+				encodeJSONError(w, fmt.Errorf("invalid limit: %s", l), http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+
+		dd, err := s.FighterDuplicates(r.Context(), limit)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.FighterDuplicate `json:"data"`
+		}{dd}, http.StatusOK)
+	}
+}
+
+func ListFighterHistory(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		hh, err := s.FighterHistory(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.FighterChange `json:"data"`
+		}{hh}, http.StatusOK)
+	}
+}
+
+// fighterQueryFromURL parses fighter list options from url query values.
+func fighterQueryFromURL(v url.Values) (foo.FighterQuery, error) {
+	q := foo.FighterQuery{
+		Cursor: v.Get("cursor"),
+		Sort:   v.Get("sort"),
+		Name:   v.Get("name"),
+	}
+	if l := v.Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil {
+			return q, fmt.Errorf("invalid limit: %s", l)
+		}
+		q.Limit = n
+	}
+	return q, nil
+}
+
+// nextPageLink returns RFC 8288 Link header value of the next page using
+// the request url with cursor replaced.
+func nextPageLink(u *url.URL, cursor string) string {
+	q := u.Query()
+	q.Set("cursor", cursor)
+	next := url.URL{Path: u.Path, RawQuery: q.Encode()}
+	return fmt.Sprintf(`<%s>; rel="next"`, next.String())
+}
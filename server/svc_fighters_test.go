@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
+)
+
+type mockFighterService struct {
+	service
+	fighter foo.Fighter
+}
+
+func (m *mockFighterService) FighterByID(ctx context.Context, ref string) (*foo.Fighter, error) {
+	return &m.fighter, nil
+}
+
+func (m *mockFighterService) FighterAsOf(ctx context.Context, ref string, at time.Time) (*foo.Fighter, error) {
+	return &m.fighter, nil
+}
+
+func (m *mockFighterService) UpdateFighter(ctx context.Context, ref string, f *foo.Fighter) (*foo.Fighter, error) {
+	if f.Version != 0 && f.Version != m.fighter.Version {
+		return nil, foo.ErrFighterConflict.X(errors.New("fighter version mismatch"))
+	}
+	c := *f
+	c.Version = m.fighter.Version + 1
+	return &c, nil
+}
+
+func TestGetFighterByID(t *testing.T) {
+	f := foo.Fighter{ID: uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140"), Slug: "dave-grohl", Version: 2}
+	tests := []struct {
+		name         string
+		path         string
+		wantStatus   int
+		wantLocation string
+	}{
+		{"canonical slug", "/fighters/dave-grohl", http.StatusOK, ""},
+		{"id", "/fighters/" + f.ID.String(), http.StatusMovedPermanently, "/fighters/dave-grohl"},
+		{"previous slug", "/fighters/david-grohl?fields=all", http.StatusMovedPermanently, "/fighters/dave-grohl?fields=all"},
+		{"as of", "/fighters/" + f.ID.String() + "?as_of=2024-01-01T00:00:00Z", http.StatusOK, ""},
+		{"malformed as of", "/fighters/dave-grohl?as_of=2024-01-01", http.StatusUnprocessableEntity, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mux.NewRouter()
+			r.HandleFunc("/fighters/{id}", GetFighterByID(&mockFighterService{fighter: f}))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("GetFighterByID() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("GetFighterByID() location = %q, want %q", got, tt.wantLocation)
+			}
+		})
+	}
+}
+
+func TestUpdateFighter_Version(t *testing.T) {
+	f := foo.Fighter{ID: uuid.MustParse("b41c7709-04e3-4c48-b233-34e6838d9140"), Version: 3}
+	tests := []struct {
+		name       string
+		body       string
+		ifMatch    string
+		wantStatus int
+	}{
+		{"current version", `{"first_name":"dave","last_name":"grohl","version":3}`, "", http.StatusOK},
+		{"stale version", `{"first_name":"dave","last_name":"grohl","version":2}`, "", http.StatusConflict},
+		{"stale if-match", `{"first_name":"dave","last_name":"grohl"}`, `"2"`, http.StatusPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mux.NewRouter()
+			r.HandleFunc("/fighters/{id}", UpdateFighter(&mockFighterService{fighter: f}))
+			req := httptest.NewRequest(http.MethodPut, "/fighters/"+f.ID.String(), strings.NewReader(tt.body))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("UpdateFighter() status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if w.Code != http.StatusOK && !strings.Contains(w.Body.String(), `"conflict"`) {
+				t.Errorf("UpdateFighter() body = %s, want conflict code", w.Body)
+			}
+		})
+	}
+}
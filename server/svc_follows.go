@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
+)
+
+type followService interface {
+	FollowFighter(ctx context.Context, fighterID string) (*foo.Follow, error)
+	UnfollowFighter(ctx context.Context, fighterID string) error
+	Follows(ctx context.Context) ([]foo.Follow, error)
+}
+
+// ListFollows lists fighters followed by the authorized user.
+func ListFollows(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ff, err := s.Follows(r.Context())
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.Follow `json:"data"`
+		}{ff}, http.StatusOK)
+	}
+}
+
+func FollowFighter(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		f, err := s.FollowFighter(r.Context(), v["fighterID"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, f, http.StatusOK)
+	}
+}
+
+func UnfollowFighter(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		if err := s.UnfollowFighter(r.Context(), v["fighterID"]); err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kudarap/foo"
+)
+
+type mockFollowService struct {
+	service
+	userID string
+}
+
+func (m *mockFollowService) Follows(ctx context.Context) ([]foo.Follow, error) {
+	m.userID = foo.ActorFromContext(ctx).UserID
+	return []foo.Follow{}, nil
+}
+
+type mockTracing struct{}
+
+func (mockTracing) Middleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler { return next }
+}
+
+func TestRoutes_Follows(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		claims     mockAuthenticator
+		wantStatus int
+		wantUserID string
+	}{
+		{"anonymous", "", nil, http.StatusForbidden, ""},
+		{"no user claim", "t", mockAuthenticator{}, http.StatusForbidden, ""},
+		{"user", "t", mockAuthenticator{"user_id": "u1"}, http.StatusOK, "u1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockFollowService{}
+			s := &Server{
+				service:       svc,
+				authenticator: tt.claims,
+				tracing:       mockTracing{},
+				logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+			}
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/me/follows", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			s.Routes().ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("GET /me/follows status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if svc.userID != tt.wantUserID {
+				t.Errorf("GET /me/follows user = %q, want %q", svc.userID, tt.wantUserID)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
+)
+
+type importService interface {
+	CreateFighterImport(ctx context.Context, format foo.FighterImportFormat, data []byte) (*foo.FighterImport, error)
+	FighterImportByID(ctx context.Context, id string) (*foo.FighterImport, error)
+}
+
+// CreateFighterImport accepts a csv or jsonl fighter import as request body or
+// multipart form file and responds with the queued import.
+func CreateFighterImport(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, foo.MaxFighterImportSize+1<<20)
+		format, data, err := importFromRequest(r)
+		if err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		imp, err := s.CreateFighterImport(r.Context(), format, data)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		w.Header().Set("Location", "/fighters/imports/"+imp.ID.String())
+		encodeJSONResp(w, imp, http.StatusAccepted)
+	}
+}
+
+func GetFighterImportByID(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		imp, err := s.FighterImportByID(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, imp, http.StatusOK)
+	}
+}
+
+// importFormats maps import media types and file extensions to import format.
+var importFormats = map[string]foo.FighterImportFormat{
+	"text/csv":             foo.FighterImportCSV,
+	"application/x-ndjson": foo.FighterImportJSONL,
+	"application/jsonl":    foo.FighterImportJSONL,
+	".csv":                 foo.FighterImportCSV,
+	".jsonl":               foo.FighterImportJSONL,
+	".ndjson":              foo.FighterImportJSONL,
+}
+
+// importFromRequest reads import file and its format from a multipart form
+// "file" field by file extension or from request body by content type.
+func importFromRequest(r *http.Request) (foo.FighterImportFormat, []byte, error) {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid content type: %s", err)
+	}
+
+	body, name := r.Body, mt
+	if strings.HasPrefix(mt, "multipart/") {
+		file, fh, err := r.FormFile("file")
+		if err != nil {
+			return "", nil, fmt.Errorf("could not read file: %s", err)
+		}
+		defer file.Close()
+		body, name = file, strings.ToLower(path.Ext(fh.Filename))
+	}
+
+	format, ok := importFormats[name]
+	if !ok {
+		return "", nil, errors.New("import must be a csv or jsonl file")
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", nil, err
+	}
+	return format, data, nil
+}
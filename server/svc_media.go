@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
+)
+
+// mediaUploadTimeout is how long reading a media upload may take, longer than
+// server read timeout.
+const mediaUploadTimeout = 5 * time.Minute
+
+type mediaService interface {
+	CreateFighterMedia(ctx context.Context, fighterID string, kind foo.MediaKind, filename string, r io.Reader) (*foo.Media, error)
+	FighterMedia(ctx context.Context, fighterID string) ([]foo.Media, error)
+}
+
+// CreateFighterMedia uploads fighter media from a multipart form "file" field
+// or the request body, kind is read from "kind" form field or query.
+func CreateFighterMedia(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = http.NewResponseController(w).SetReadDeadline(time.Now().Add(mediaUploadTimeout))
+		r.Body = http.MaxBytesReader(w, r.Body, foo.MaxMediaSize+1<<20)
+
+		mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			encodeJSONError(w, fmt.Errorf("invalid content type: %s", err), http.StatusBadRequest)
+			return
+		}
+		body, filename := io.Reader(r.Body), ""
+		if strings.HasPrefix(mt, "multipart/") {
+			file, fh, err := r.FormFile("file")
+			if err != nil {
+				encodeJSONError(w, fmt.Errorf("could not read file: %s", err), http.StatusBadRequest)
+				return
+			}
+			defer file.Close()
+			body, filename = file, fh.Filename
+		}
+
+		v := mux.Vars(r)
+		kind := foo.MediaKind(r.FormValue("kind"))
+		m, err := s.CreateFighterMedia(r.Context(), v["id"], kind, filename, body)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, m, http.StatusCreated)
+	}
+}
+
+func ListFighterMedia(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		mm, err := s.FighterMedia(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.Media `json:"data"`
+		}{mm}, http.StatusOK)
+	}
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
+)
+
+type notificationService interface {
+	Notifications(ctx context.Context, limit int) ([]foo.Notification, error)
+	ReadNotification(ctx context.Context, id string) error
+	NotificationPreferences(ctx context.Context) (*foo.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, p *foo.NotificationPreferences) (*foo.NotificationPreferences, error)
+}
+
+// ListNotifications lists the authorized user in-app notifications.
+func ListNotifications(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var limit int
+		if l := r.URL.Query().Get("limit"); l != "" {
+			n, err := strconv.Atoi(l)
+			if err != nil {
+				encodeJSONError(w, fmt.Errorf("invalid limit: %s", l), http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+
+		nn, err := s.Notifications(r.Context(), limit)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.Notification `json:"data"`
+		}{nn}, http.StatusOK)
+	}
+}
+
+func ReadNotification(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		if err := s.ReadNotification(r.Context(), v["id"]); err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func GetNotificationPreferences(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p, err := s.NotificationPreferences(r.Context())
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, p, http.StatusOK)
+	}
+}
+
+func UpdateNotificationPreferences(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var p foo.NotificationPreferences
+		if err := decodeJSONReq(r, &p); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		u, err := s.UpdateNotificationPreferences(r.Context(), &p)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, u, http.StatusOK)
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kudarap/foo"
+)
+
+type mockNotificationService struct {
+	service
+	readID string
+}
+
+func (m *mockNotificationService) ReadNotification(ctx context.Context, id string) error {
+	m.readID = id
+	return nil
+}
+
+func (m *mockNotificationService) UpdateNotificationPreferences(ctx context.Context, p *foo.NotificationPreferences) (*foo.NotificationPreferences, error) {
+	return p, p.Validate()
+}
+
+func TestRoutes_Notifications(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantReadID string
+	}{
+		{"read", http.MethodPost, "/me/notifications/n1:read", "", http.StatusNoContent, "n1"},
+		{"update preferences", http.MethodPut, "/me/notification-preferences",
+			`{"channels":["in_app"]}`, http.StatusOK, ""},
+		{"invalid preferences", http.MethodPut, "/me/notification-preferences",
+			`{"channels":["sms"]}`, http.StatusUnprocessableEntity, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockNotificationService{}
+			s := &Server{
+				service:       svc,
+				authenticator: mockAuthenticator{"user_id": "u1"},
+				tracing:       mockTracing{},
+				logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+			}
+			req := httptest.NewRequest(tt.method, "http://localhost"+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer t")
+			w := httptest.NewRecorder()
+			s.Routes().ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("%s %s status = %d, want %d: %s", tt.method, tt.path, w.Code, tt.wantStatus, w.Body)
+			}
+			if svc.readID != tt.wantReadID {
+				t.Errorf("%s %s read id = %q, want %q", tt.method, tt.path, svc.readID, tt.wantReadID)
+			}
+		})
+	}
+}
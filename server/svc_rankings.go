@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
+)
+
+type rankingService interface {
+	Rankings(ctx context.Context, weightClass string, limit int) ([]foo.Rating, error)
+	FighterRatingHistory(ctx context.Context, fighterID string) ([]foo.RatingChange, error)
+}
+
+func ListRankings(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var limit int
+		if l := r.URL.Query().Get("limit"); l != "" {
+			n, err := strconv.Atoi(l)
+			if err != nil {
+				encodeJSONError(w, fmt.Errorf("invalid limit: %s", l), http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+
+		v := mux.Vars(r)
+		rr, err := s.Rankings(r.Context(), v["weightClass"], limit)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.Rating `json:"data"`
+		}{rr}, http.StatusOK)
+	}
+}
+
+func ListFighterRatings(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		hh, err := s.FighterRatingHistory(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.RatingChange `json:"data"`
+		}{hh}, http.StatusOK)
+	}
+}
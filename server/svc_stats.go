@@ -0,0 +1,27 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
+)
+
+type statsService interface {
+	FighterStats(ctx context.Context, fighterID string) (*foo.FighterStats, error)
+}
+
+// GetFighterStats returns fighter career aggregates by fighter id or slug.
+func GetFighterStats(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		st, err := s.FighterStats(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, st, http.StatusOK)
+	}
+}
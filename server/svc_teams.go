@@ -0,0 +1,198 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
+)
+
+type teamService interface {
+	TeamByID(ctx context.Context, id string) (*foo.Team, error)
+	Teams(ctx context.Context, q foo.TeamQuery) ([]foo.Team, error)
+	CreateTeam(ctx context.Context, t *foo.Team) (*foo.Team, error)
+	UpdateTeam(ctx context.Context, id string, t *foo.Team) (*foo.Team, error)
+	DeleteTeam(ctx context.Context, id string) error
+	TeamMembers(ctx context.Context, id string) (*foo.TeamMembers, error)
+	FighterAffiliations(ctx context.Context, fighterID string) ([]foo.Affiliation, error)
+	CreateAffiliation(ctx context.Context, fighterID string, a *foo.Affiliation) (*foo.Affiliation, error)
+	UpdateAffiliation(ctx context.Context, fighterID, id string, a *foo.Affiliation) (*foo.Affiliation, error)
+	DeleteAffiliation(ctx context.Context, fighterID, id string) error
+}
+
+func GetTeamByID(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		t, err := s.TeamByID(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, t, http.StatusOK)
+	}
+}
+
+func ListTeams(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q, err := teamQueryFromURL(r.URL.Query())
+		if err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		tt, err := s.Teams(r.Context(), q)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.Team `json:"data"`
+		}{tt}, http.StatusOK)
+	}
+}
+
+func CreateTeam(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var t foo.Team
+		if err := decodeJSONReq(r, &t); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		c, err := s.CreateTeam(r.Context(), &t)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, c, http.StatusCreated)
+	}
+}
+
+func UpdateTeam(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var t foo.Team
+		if err := decodeJSONReq(r, &t); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		v := mux.Vars(r)
+		c, err := s.UpdateTeam(r.Context(), v["id"], &t)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, c, http.StatusOK)
+	}
+}
+
+func DeleteTeam(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		if err := s.DeleteTeam(r.Context(), v["id"]); err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func ListTeamFighters(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		m, err := s.TeamMembers(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, m, http.StatusOK)
+	}
+}
+
+func ListFighterTeams(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		aa, err := s.FighterAffiliations(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.Affiliation `json:"data"`
+		}{aa}, http.StatusOK)
+	}
+}
+
+func CreateFighterTeam(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var a foo.Affiliation
+		if err := decodeJSONReq(r, &a); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		v := mux.Vars(r)
+		c, err := s.CreateAffiliation(r.Context(), v["id"], &a)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, c, http.StatusCreated)
+	}
+}
+
+func UpdateFighterTeam(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var a foo.Affiliation
+		if err := decodeJSONReq(r, &a); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		v := mux.Vars(r)
+		c, err := s.UpdateAffiliation(r.Context(), v["id"], v["affiliationID"], &a)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, c, http.StatusOK)
+	}
+}
+
+func DeleteFighterTeam(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		if err := s.DeleteAffiliation(r.Context(), v["id"], v["affiliationID"]); err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// teamQueryFromURL parses team list options from url query values.
+func teamQueryFromURL(v url.Values) (foo.TeamQuery, error) {
+	var q foo.TeamQuery
+	if l := v.Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil {
+			return q, fmt.Errorf("invalid limit: %s", l)
+		}
+		q.Limit = n
+	}
+	return q, nil
+}
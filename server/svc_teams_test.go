@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+)
+
+type mockTeamService struct {
+	service
+	teamID string
+}
+
+func (m *mockTeamService) TeamMembers(ctx context.Context, id string) (*foo.TeamMembers, error) {
+	m.teamID = id
+	if id != "t1" {
+		return nil, foo.ErrTeamNotFound.X(errors.New("team not found"))
+	}
+	return &foo.TeamMembers{
+		Current: []foo.Affiliation{{ID: uuid.New()}},
+		Former:  []foo.Affiliation{},
+	}, nil
+}
+
+func TestRoutes_TeamFighters(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		wantStatus  int
+		wantCurrent int
+	}{
+		{"members", "t1", http.StatusOK, 1},
+		{"unknown team", "t2", http.StatusNotFound, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockTeamService{}
+			s := &Server{
+				service:       svc,
+				authenticator: mockAuthenticator{},
+				tracing:       mockTracing{},
+				logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+			}
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/teams/"+tt.id+"/fighters", nil)
+			w := httptest.NewRecorder()
+			s.Routes().ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("GET /teams/%s/fighters status = %d, want %d", tt.id, w.Code, tt.wantStatus)
+			}
+			if svc.teamID != tt.id {
+				t.Errorf("GET /teams/%s/fighters team = %q", tt.id, svc.teamID)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got foo.TeamMembers
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Current) != tt.wantCurrent || got.Former == nil {
+				t.Errorf("GET /teams/%s/fighters got = %+v", tt.id, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kudarap/foo"
+)
+
+type webhookService interface {
+	CreateWebhook(ctx context.Context, w *foo.Webhook) (*foo.Webhook, error)
+	Webhooks(ctx context.Context) ([]foo.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	EnableWebhook(ctx context.Context, id string) (*foo.Webhook, error)
+	WebhookDeliveries(ctx context.Context, id string, limit int) ([]foo.WebhookDelivery, error)
+}
+
+// CreateWebhook registers a webhook, the response is the only time its
+// signing secret is shown.
+func CreateWebhook(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var wh foo.Webhook
+		if err := decodeJSONReq(r, &wh); err != nil {
+			encodeJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		c, err := s.CreateWebhook(r.Context(), &wh)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, c, http.StatusCreated)
+	}
+}
+
+func ListWebhooks(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ww, err := s.Webhooks(r.Context())
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.Webhook `json:"data"`
+		}{ww}, http.StatusOK)
+	}
+}
+
+func DeleteWebhook(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		if err := s.DeleteWebhook(r.Context(), v["id"]); err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// EnableWebhook re-enables a webhook disabled for failing too often.
+func EnableWebhook(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := mux.Vars(r)
+		wh, err := s.EnableWebhook(r.Context(), v["id"])
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, wh, http.StatusOK)
+	}
+}
+
+func ListWebhookDeliveries(s service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var limit int
+		if l := r.URL.Query().Get("limit"); l != "" {
+			n, err := strconv.Atoi(l)
+			if err != nil {
+				encodeJSONError(w, fmt.Errorf("invalid limit: %s", l), http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+
+		v := mux.Vars(r)
+		dd, err := s.WebhookDeliveries(r.Context(), v["id"], limit)
+		if err != nil {
+			encodeJSONError(w, err, errorStatus(err, http.StatusBadRequest))
+			return
+		}
+
+		encodeJSONResp(w, struct {
+			Data []foo.WebhookDelivery `json:"data"`
+		}{dd}, http.StatusOK)
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kudarap/foo"
+)
+
+type mockWebhookService struct {
+	service
+}
+
+func (m *mockWebhookService) CreateWebhook(ctx context.Context, w *foo.Webhook) (*foo.Webhook, error) {
+	return w, w.Validate()
+}
+
+func (m *mockWebhookService) WebhookDeliveries(ctx context.Context, id string, limit int) ([]foo.WebhookDelivery, error) {
+	return []foo.WebhookDelivery{}, nil
+}
+
+func TestRoutes_Webhooks(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     mockAuthenticator
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"anonymous", nil, http.MethodPost, "/webhooks", `{}`, http.StatusForbidden},
+		{"create", mockAuthenticator{"user_id": "u1"}, http.MethodPost, "/webhooks",
+			`{"url":"https://partner.test","events":["fighter.updated"]}`, http.StatusCreated},
+		{"invalid", mockAuthenticator{"user_id": "u1"}, http.MethodPost, "/webhooks",
+			`{"url":"https://partner.test"}`, http.StatusUnprocessableEntity},
+		{"deliveries", mockAuthenticator{"user_id": "u1"}, http.MethodGet, "/webhooks/w1/deliveries", "",
+			http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockWebhookService{}
+			s := &Server{
+				service:       svc,
+				authenticator: tt.claims,
+				tracing:       mockTracing{},
+				logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+			}
+			req := httptest.NewRequest(tt.method, "http://localhost"+tt.path, strings.NewReader(tt.body))
+			if tt.claims != nil {
+				req.Header.Set("Authorization", "Bearer t")
+			}
+			w := httptest.NewRecorder()
+			s.Routes().ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("%s %s status = %d, want %d: %s", tt.method, tt.path, w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
@@ -5,42 +5,393 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
 )
 
 // Service represents foo service.
 type Service struct {
-	repo   repository
-	logger *slog.Logger
+	repo      repository
+	blobs     BlobStore
+	notifiers map[NotificationChannel]Notifier
+	webhooks  WebhookPoster
+	logger    *slog.Logger
 }
 
-// NewService returns new foo service.
-func NewService(r repository, l *slog.Logger) *Service {
-	return &Service{repo: r, logger: l}
+// NewService returns new foo service. Notifications are not delivered on
+// channels without a notifier.
+func NewService(r repository, b BlobStore, nn map[NotificationChannel]Notifier, wp WebhookPoster, l *slog.Logger) *Service {
+	return &Service{repo: r, blobs: b, notifiers: nn, webhooks: wp, logger: l}
 }
 
-// FighterByID returns a fighter by id.
-func (s *Service) FighterByID(ctx context.Context, sid string) (*Fighter, error) {
+// FighterByID returns a fighter by id, current or previous slug. Compare ref
+// with the fighter Slug to tell whether it is the canonical one.
+func (s *Service) FighterByID(ctx context.Context, ref string) (*Fighter, error) {
 	// NOTE this is a just a demo logging and should use InfoContext enabling telemetry logs.
-	s.logger.InfoContext(ctx, "getting foo fighter by id", "id", sid)
+	s.logger.InfoContext(ctx, "getting foo fighter by id", "id", ref)
 
-	id, err := uuid.Parse(sid)
+	var f *Fighter
+	var err error
+	if id, perr := uuid.Parse(ref); perr == nil {
+		f, err = s.repo.Fighter(ctx, id, false)
+	} else {
+		f, err = s.repo.FighterBySlug(ctx, strings.ToLower(ref))
+	}
+	if err != nil {
+		if errors.Is(err, ErrFighterNotFound) {
+			return nil, ErrFighterNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not find fighter on repository: %s", err)
+	}
+	return f, nil
+}
+
+// FighterAsOf returns a fighter by id or current slug as it was at the given
+// time, its record only counts bouts dated on or before it.
+func (s *Service) FighterAsOf(ctx context.Context, ref string, at time.Time) (*Fighter, error) {
+	s.logger.InfoContext(ctx, "getting foo fighter as of", "id", ref, "as_of", at)
+
+	if at.After(time.Now()) {
+		return nil, ErrFighterInvalid.X(xerror.NewValidationError("as_of", xerror.ViolationOutOfRange,
+			"must not be in the future"))
+	}
+	id, err := uuid.Parse(ref)
+	if err != nil {
+		f, err := s.repo.FighterBySlug(ctx, strings.ToLower(ref))
+		if err != nil {
+			if errors.Is(err, ErrFighterNotFound) {
+				return nil, ErrFighterNotFound.X(err)
+			}
+			return nil, fmt.Errorf("could not find fighter on repository: %s", err)
+		}
+		id = f.ID
+	}
+
+	f, err := s.repo.FighterAsOf(ctx, id, at)
 	if err != nil {
+		if errors.Is(err, ErrFighterNotFound) {
+			return nil, ErrFighterNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not find fighter version on repository: %s", err)
+	}
+	return f, nil
+}
+
+// Fighters returns a page of fighters using keyset pagination.
+func (s *Service) Fighters(ctx context.Context, q FighterQuery) (*FighterPage, error) {
+	s.logger.InfoContext(ctx, "listing foo fighters", "limit", q.Limit, "sort", q.Sort, "name", q.Name)
+
+	q = q.setDefaults()
+	if err := q.validate(); err != nil {
 		return nil, err
 	}
+	var after *FighterCursor
+	if q.Cursor != "" {
+		c, err := decodeFighterCursor(q.Cursor, q.Sort)
+		if err != nil {
+			return nil, err
+		}
+		after = c
+	}
 
-	f, err := s.repo.Fighter(ctx, id)
+	// Fetches an extra fighter to check if there is a next page.
+	limit := q.Limit
+	q.Limit++
+	ff, err := s.repo.Fighters(ctx, q, after)
 	if err != nil {
+		return nil, fmt.Errorf("could not list fighters on repository: %s", err)
+	}
+
+	page := &FighterPage{Data: ff}
+	if len(ff) > limit {
+		page.Data = ff[:limit]
+		page.NextCursor = newFighterCursor(page.Data[limit-1], q.Sort).Encode()
+	}
+	if page.Data == nil {
+		page.Data = []Fighter{}
+	}
+	return page, nil
+}
+
+// ExportFighters streams every fighter matching query filters and sort to fn,
+// query limit and cursor are ignored.
+func (s *Service) ExportFighters(ctx context.Context, q FighterQuery, fn func(Fighter) error) error {
+	s.logger.InfoContext(ctx, "exporting foo fighters", "sort", q.Sort, "name", q.Name)
+
+	q.Limit, q.Cursor = 0, ""
+	q = q.setDefaults()
+	if err := q.validate(); err != nil {
+		return err
+	}
+	if err := s.repo.ExportFighters(ctx, q, fn); err != nil {
+		return fmt.Errorf("could not export fighters on repository: %s", err)
+	}
+	return nil
+}
+
+// SearchFighters returns fighters matching the search text ranked by relevance,
+// tolerating misspelled names.
+func (s *Service) SearchFighters(ctx context.Context, q string, limit int) ([]FighterSearchResult, error) {
+	s.logger.InfoContext(ctx, "searching foo fighters", "q", q, "limit", limit)
+
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, ErrFighterInvalid.X(xerror.NewValidationError("q", xerror.ViolationRequired, "is required"))
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	rr, err := s.repo.SearchFighters(ctx, q, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not search fighters on repository: %s", err)
+	}
+	if rr == nil {
+		rr = []FighterSearchResult{}
+	}
+	return rr, nil
+}
+
+// CreateFighter creates a new fighter.
+func (s *Service) CreateFighter(ctx context.Context, f *Fighter) (*Fighter, error) {
+	s.logger.InfoContext(ctx, "creating foo fighter", "first_name", f.FirstName, "last_name", f.LastName)
+
+	f.normalize()
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateFighter(ctx, f); err != nil {
+		return nil, fmt.Errorf("could not create fighter on repository: %s", err)
+	}
+	return f, nil
+}
+
+// UpdateFighter updates fighter details by id. Non-zero fighter version must
+// match the current fighter version.
+func (s *Service) UpdateFighter(ctx context.Context, sid string, f *Fighter) (*Fighter, error) {
+	s.logger.InfoContext(ctx, "updating foo fighter", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrFighterInvalid.X(err)
+	}
+	f.ID = id
+	f.normalize()
+	if err = f.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.UpdateFighter(ctx, f); err != nil {
 		if errors.Is(err, ErrFighterNotFound) {
 			return nil, ErrFighterNotFound.X(err)
 		}
-		return nil, fmt.Errorf("could not find fighter on repository: %s", err)
+		if errors.Is(err, ErrFighterConflict) {
+			return nil, ErrFighterConflict.X(err)
+		}
+		return nil, fmt.Errorf("could not update fighter on repository: %s", err)
 	}
 	return f, nil
 }
 
-// repository manages storage operation for fighters.
+// DeleteFighter deletes a fighter by id, deleted fighter can be restored until
+// purged after the retention period. Non-zero version must match the current
+// fighter version.
+func (s *Service) DeleteFighter(ctx context.Context, sid string, version int) error {
+	s.logger.InfoContext(ctx, "deleting foo fighter", "id", sid, "version", version)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return ErrFighterInvalid.X(err)
+	}
+
+	if err = s.repo.DeleteFighter(ctx, id, version); err != nil {
+		if errors.Is(err, ErrFighterNotFound) {
+			return ErrFighterNotFound.X(err)
+		}
+		if errors.Is(err, ErrFighterConflict) {
+			return ErrFighterConflict.X(err)
+		}
+		return fmt.Errorf("could not delete fighter on repository: %s", err)
+	}
+	return nil
+}
+
+// RestoreFighter restores a deleted fighter by id.
+func (s *Service) RestoreFighter(ctx context.Context, sid string) (*Fighter, error) {
+	s.logger.InfoContext(ctx, "restoring foo fighter", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrFighterInvalid.X(err)
+	}
+
+	f, err := s.repo.RestoreFighter(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrFighterNotFound) {
+			return nil, ErrFighterNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not restore fighter on repository: %s", err)
+	}
+	return f, nil
+}
+
+// DefaultFighterRetention is how long deleted fighters are kept before purged.
+const DefaultFighterRetention = 30 * 24 * time.Hour
+
+// PurgeFighters permanently removes fighters deleted longer than retention and
+// returns the number of purged fighters. Media blobs of purged fighters are
+// deleted from the blob store. Fighters with bouts are not purged to keep their
+// opponents records intact and are logged instead.
+func (s *Service) PurgeFighters(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		retention = DefaultFighterRetention
+	}
+	s.logger.InfoContext(ctx, "purging foo fighters", "retention", retention.String())
+
+	p, err := s.repo.PurgeFighters(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("could not purge fighters on repository: %s", err)
+	}
+	if p.KeptWithBouts > 0 {
+		s.logger.WarnContext(ctx, "deleted fighters with bouts kept from purge", "fighters", p.KeptWithBouts)
+	}
+	for _, key := range p.BlobKeys {
+		s.deleteBlob(ctx, key)
+	}
+	return p.Purged, nil
+}
+
+// repository manages storage operation for fighters, bouts, events and rankings.
 type repository interface {
-	Fighter(ctx context.Context, id uuid.UUID) (*Fighter, error)
+	// Fighter returns ErrFighterNotFound on deleted fighter unless includeDeleted,
+	// id of a merged fighter resolves to the fighter it was merged into.
+	Fighter(ctx context.Context, id uuid.UUID, includeDeleted bool) (*Fighter, error)
+	// FighterBySlug returns ErrFighterNotFound on deleted fighter, slug may be
+	// the current or a previous slug of the fighter.
+	FighterBySlug(ctx context.Context, slug string) (*Fighter, error)
+	// FighterAsOf returns ErrFighterNotFound when the fighter did not exist or
+	// was deleted at the given time.
+	FighterAsOf(ctx context.Context, id uuid.UUID, at time.Time) (*Fighter, error)
+	// FightersByID leaves out deleted and unknown fighters.
+	FightersByID(ctx context.Context, ids []uuid.UUID) ([]Fighter, error)
+	Fighters(ctx context.Context, q FighterQuery, after *FighterCursor) ([]Fighter, error)
+	SearchFighters(ctx context.Context, q string, limit int) ([]FighterSearchResult, error)
+	// ExportFighters calls fn on each fighter and stops on its error.
+	ExportFighters(ctx context.Context, q FighterQuery, fn func(Fighter) error) error
+	// Fighter writes append fighter history of the context Actor and its
+	// domain event message on the same transaction.
+	CreateFighter(ctx context.Context, f *Fighter) error
+	// UpdateFighter and DeleteFighter returns ErrFighterConflict when non-zero
+	// version does not match.
+	UpdateFighter(ctx context.Context, f *Fighter) error
+	DeleteFighter(ctx context.Context, id uuid.UUID, version int) error
+	RestoreFighter(ctx context.Context, id uuid.UUID) (*Fighter, error)
+	// PurgeFighters permanently removes fighters deleted before the given time
+	// with their media, fighters with bouts are kept deleted and counted instead.
+	PurgeFighters(ctx context.Context, before time.Time) (*FighterPurge, error)
+	FighterHistory(ctx context.Context, fighterID uuid.UUID) ([]FighterChange, error)
+	FighterDuplicates(ctx context.Context, limit int) ([]FighterDuplicate, error)
+	// MergeFighter moves duplicate bouts to the fighter, removes the duplicate
+	// leaving an alias and publishes messages on the same transaction.
+	MergeFighter(ctx context.Context, id, duplicateID uuid.UUID, mm ...Message) (*Fighter, error)
+
+	CreateMedia(ctx context.Context, m *Media, mm ...Message) error
+	Media(ctx context.Context, id uuid.UUID) (*Media, error)
+	FighterMedia(ctx context.Context, fighterID uuid.UUID) ([]Media, error)
+	UpdateMediaThumbnail(ctx context.Context, id uuid.UUID, key string) error
+
+	// CreateFighterImport stores import data and publishes messages on the same transaction.
+	CreateFighterImport(ctx context.Context, imp *FighterImport, data []byte, mm ...Message) error
+	FighterImport(ctx context.Context, id uuid.UUID) (*FighterImport, error)
+	FighterImportData(ctx context.Context, id uuid.UUID) ([]byte, error)
+	UpdateFighterImportStatus(ctx context.Context, imp *FighterImport) error
+	// ImportFighters upserts valid rows, appends row errors and sets processed
+	// rows of an import on the same transaction.
+	ImportFighters(ctx context.Context, importID uuid.UUID, rows []FighterImportRow, errs []ImportRowError, processed int) error
+
+	// CreateFollow sets follow created time, ErrFighterNotFound when the
+	// fighter is deleted.
+	CreateFollow(ctx context.Context, userID string, f *Follow) error
+	DeleteFollow(ctx context.Context, userID string, fighterID uuid.UUID) error
+	// Follows leaves out deleted fighters.
+	Follows(ctx context.Context, userID string) ([]Follow, error)
+
+	// FollowerIDs returns distinct users following any of the fighters.
+	FollowerIDs(ctx context.Context, fighterIDs []uuid.UUID) ([]string, error)
+	// Publish writes messages to the outbox.
+	Publish(ctx context.Context, mm ...Message) error
+	// NotificationPreferences returns ErrNotificationNotFound when the user has
+	// not set any.
+	NotificationPreferences(ctx context.Context, userID string) (*NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, userID string, p *NotificationPreferences) error
+	Notifications(ctx context.Context, userID string, limit int) ([]Notification, error)
+	// ReadNotification returns ErrNotificationNotFound when the notification
+	// is not on the user inbox.
+	ReadNotification(ctx context.Context, userID string, id uuid.UUID) error
+
+	CreateWebhook(ctx context.Context, w *Webhook) error
+	// Webhook returns ErrWebhookNotFound when missing.
+	Webhook(ctx context.Context, id uuid.UUID) (*Webhook, error)
+	Webhooks(ctx context.Context) ([]Webhook, error)
+	// WebhooksByEvent returns enabled webhooks subscribed to the event.
+	WebhooksByEvent(ctx context.Context, event string) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	// EnableWebhook clears webhook failures and returns ErrWebhookNotFound when missing.
+	EnableWebhook(ctx context.Context, id uuid.UUID) (*Webhook, error)
+	// AddWebhookDelivery logs a delivery and updates its webhook consecutive
+	// failures, disabling it when they reach disableAfter. It returns the
+	// updated webhook.
+	AddWebhookDelivery(ctx context.Context, d *WebhookDelivery, disableAfter int) (*Webhook, error)
+	WebhookDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]WebhookDelivery, error)
+	// PurgeWebhookDeliveries removes deliveries logged before the given time and
+	// returns the number of removed deliveries.
+	PurgeWebhookDeliveries(ctx context.Context, before time.Time) (int64, error)
+
+	// FighterStats returns ErrFighterNotFound when the fighter stats are not
+	// yet refreshed.
+	FighterStats(ctx context.Context, fighterID uuid.UUID) (*FighterStats, error)
+	// UpdateFighterStats creates or replaces stats of an existing fighter.
+	UpdateFighterStats(ctx context.Context, st *FighterStats) error
+
+	Team(ctx context.Context, id uuid.UUID) (*Team, error)
+	Teams(ctx context.Context, q TeamQuery) ([]Team, error)
+	CreateTeam(ctx context.Context, t *Team) error
+	UpdateTeam(ctx context.Context, t *Team) error
+	DeleteTeam(ctx context.Context, id uuid.UUID) error
+	// TeamAffiliations returns team affiliations with their fighters, deleted
+	// fighters are left out.
+	TeamAffiliations(ctx context.Context, teamID uuid.UUID) ([]Affiliation, error)
+	// FighterAffiliations returns fighter affiliations with their teams.
+	FighterAffiliations(ctx context.Context, fighterID uuid.UUID) ([]Affiliation, error)
+	// CreateAffiliation and UpdateAffiliation return ErrAffiliationInvalid when
+	// the period overlaps another of the fighter with the same team.
+	CreateAffiliation(ctx context.Context, a *Affiliation) error
+	UpdateAffiliation(ctx context.Context, a *Affiliation) error
+	DeleteAffiliation(ctx context.Context, fighterID, id uuid.UUID) error
+
+	Bout(ctx context.Context, id uuid.UUID) (*Bout, error)
+	FighterBouts(ctx context.Context, fighterID uuid.UUID) ([]Bout, error)
+	CompletedBouts(ctx context.Context, wc WeightClass) ([]Bout, error)
+	// Bout writes publish messages on the same transaction.
+	CreateBout(ctx context.Context, b *Bout, mm ...Message) error
+	UpdateBout(ctx context.Context, b *Bout, mm ...Message) error
+	DeleteBout(ctx context.Context, id uuid.UUID, mm ...Message) error
+
+	Event(ctx context.Context, id uuid.UUID) (*Event, error)
+	Events(ctx context.Context, q EventQuery) ([]Event, error)
+	// Event writes move card bouts to the event date and publish messages on
+	// the same transaction.
+	CreateEvent(ctx context.Context, e *Event, mm ...Message) error
+	UpdateEvent(ctx context.Context, e *Event, mm ...Message) error
+	DeleteEvent(ctx context.Context, id uuid.UUID) error
+
+	Ratings(ctx context.Context, wc WeightClass, limit int) ([]Rating, error)
+	FighterRatingHistory(ctx context.Context, fighterID uuid.UUID) ([]RatingChange, error)
+	ReplaceRatings(ctx context.Context, wc WeightClass, rr []Rating, hh []RatingChange) error
 }
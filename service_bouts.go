@@ -0,0 +1,236 @@
+package foo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+// BoutByID returns a bout by id.
+func (s *Service) BoutByID(ctx context.Context, sid string) (*Bout, error) {
+	s.logger.InfoContext(ctx, "getting bout by id", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrBoutInvalid.X(err)
+	}
+
+	b, err := s.repo.Bout(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrBoutNotFound) {
+			return nil, ErrBoutNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not find bout on repository: %s", err)
+	}
+	return b, nil
+}
+
+// FighterBouts returns fighter bouts from the fighter perspective, latest first.
+func (s *Service) FighterBouts(ctx context.Context, sid string) ([]FighterBout, error) {
+	s.logger.InfoContext(ctx, "getting fighter bouts", "fighter_id", sid)
+
+	f, err := s.FighterByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+
+	bb, err := s.repo.FighterBouts(ctx, f.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find fighter bouts on repository: %s", err)
+	}
+	fbb := make([]FighterBout, len(bb))
+	for i, b := range bb {
+		fbb[i] = newFighterBout(f.ID, b)
+	}
+	return fbb, nil
+}
+
+// CreateBout creates a new bout between two existing fighters.
+func (s *Service) CreateBout(ctx context.Context, b *Bout) (*Bout, error) {
+	s.logger.InfoContext(ctx, "creating bout", "red", b.RedFighterID, "blue", b.BlueFighterID)
+
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkBoutFighters(ctx, b); err != nil {
+		return nil, err
+	}
+	b.ID = uuid.New()
+
+	if err := s.repo.CreateBout(ctx, b, boutMessages(nil, b)...); err != nil {
+		return nil, fmt.Errorf("could not create bout on repository: %s", err)
+	}
+	return b, nil
+}
+
+// UpdateBout updates bout details by id including its result.
+func (s *Service) UpdateBout(ctx context.Context, sid string, b *Bout) (*Bout, error) {
+	s.logger.InfoContext(ctx, "updating bout", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrBoutInvalid.X(err)
+	}
+	b.ID = id
+	if err = b.Validate(); err != nil {
+		return nil, err
+	}
+	if err = s.checkBoutFighters(ctx, b); err != nil {
+		return nil, err
+	}
+
+	cur, err := s.repo.Bout(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrBoutNotFound) {
+			return nil, ErrBoutNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not find bout on repository: %s", err)
+	}
+	// Event placement is managed by the event card and re-checked for
+	// scheduling when fighters change.
+	b.EventID = cur.EventID
+	if b.EventID != nil {
+		if err = s.checkBoutSchedule(ctx, b); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.repo.UpdateBout(ctx, b, boutMessages(cur, b)...); err != nil {
+		if errors.Is(err, ErrBoutNotFound) {
+			return nil, ErrBoutNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not update bout on repository: %s", err)
+	}
+	return b, nil
+}
+
+// DeleteBout deletes a bout by id.
+func (s *Service) DeleteBout(ctx context.Context, sid string) error {
+	s.logger.InfoContext(ctx, "deleting bout", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return ErrBoutInvalid.X(err)
+	}
+
+	cur, err := s.repo.Bout(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrBoutNotFound) {
+			return ErrBoutNotFound.X(err)
+		}
+		return fmt.Errorf("could not find bout on repository: %s", err)
+	}
+
+	if err = s.repo.DeleteBout(ctx, id, boutMessages(cur, nil)...); err != nil {
+		if errors.Is(err, ErrBoutNotFound) {
+			return ErrBoutNotFound.X(err)
+		}
+		return fmt.Errorf("could not delete bout on repository: %s", err)
+	}
+	return nil
+}
+
+// boutMessages returns rankings, stats and follower notification messages of
+// a bout change, prev and next are nil on create and delete respectively.
+func boutMessages(prev, next *Bout) []Message {
+	mm := boutCompletedMessages(prev, next)
+	mm = append(mm, fighterStatsMessages(prev, next)...)
+	if k, ok := boutNoticeKind(prev, next); ok {
+		mm = append(mm, newMessage(TopicNotificationsFanout, BoutNotice{BoutID: next.ID, Kind: k}))
+	}
+	return mm
+}
+
+// boutNoticeKind returns what followers are notified about a bout change, ok
+// is false when the change is not worth a notification. A bout is scheduled
+// when created without a result or its fighters or date changed, and has a
+// result when it changed.
+func boutNoticeKind(prev, next *Bout) (k NotificationKind, ok bool) {
+	if next == nil {
+		return "", false
+	}
+	if next.Completed() {
+		if prev == nil || prev.Result != next.Result {
+			return NotificationBoutResult, true
+		}
+		return "", false
+	}
+	if prev == nil || prev.RedFighterID != next.RedFighterID || prev.BlueFighterID != next.BlueFighterID ||
+		!prev.Date.Equal(next.Date.Time) {
+		return NotificationBoutScheduled, true
+	}
+	return "", false
+}
+
+// boutCompletedMessages returns bout completed messages for each weight class rankings
+// affected by a bout change, prev and next are nil on create and delete respectively.
+func boutCompletedMessages(prev, next *Bout) []Message {
+	var mm []Message
+	seen := map[WeightClass]bool{}
+	for _, b := range []*Bout{prev, next} {
+		if b == nil || !b.Completed() || !b.WeightClass.Valid() || seen[b.WeightClass] {
+			continue
+		}
+		seen[b.WeightClass] = true
+		mm = append(mm, newMessage(TopicBoutCompleted, BoutCompleted{BoutID: b.ID, WeightClass: b.WeightClass}))
+	}
+	return mm
+}
+
+// fighterStatsMessages returns a stats refresh message of fighters on either
+// side of a bout change that involves a completed bout.
+func fighterStatsMessages(prev, next *Bout) []Message {
+	var ids []uuid.UUID
+	for _, b := range []*Bout{prev, next} {
+		if b == nil || !b.Completed() {
+			continue
+		}
+		for _, id := range []uuid.UUID{b.RedFighterID, b.BlueFighterID} {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return []Message{newMessage(TopicFighterStats, FighterStatsStale{FighterIDs: ids})}
+}
+
+// checkBoutFighters checks both corner fighters exist and replaces merged
+// fighter ids with the fighter they were merged into.
+func (s *Service) checkBoutFighters(ctx context.Context, b *Bout) error {
+	for _, id := range []*uuid.UUID{&b.RedFighterID, &b.BlueFighterID} {
+		f, err := s.repo.Fighter(ctx, *id, false)
+		if err != nil {
+			if errors.Is(err, ErrFighterNotFound) {
+				return ErrFighterNotFound.X(fmt.Errorf("fighter %s not found", *id))
+			}
+			return fmt.Errorf("could not find fighter on repository: %s", err)
+		}
+		*id = f.ID
+	}
+	if b.RedFighterID == b.BlueFighterID {
+		return ErrBoutInvalid.X(xerror.NewValidationError("blue_fighter_id", xerror.ViolationDuplicate,
+			"must not be the same as red corner fighter"))
+	}
+	return nil
+}
+
+// checkBoutSchedule checks updated bout still satisfies its event scheduling rules.
+func (s *Service) checkBoutSchedule(ctx context.Context, b *Bout) error {
+	e, err := s.repo.Event(ctx, *b.EventID)
+	if err != nil {
+		return fmt.Errorf("could not find bout event on repository: %s", err)
+	}
+	for i := range e.Card {
+		if e.Card[i].BoutID == b.ID {
+			e.Card[i].Bout = b
+		}
+	}
+	return e.checkSchedule()
+}
@@ -0,0 +1,103 @@
+package foo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+// CompareFighters returns fighters by id or slug side by side with their
+// shared opponents and direct bouts. Deleted shared opponents are left out.
+func (s *Service) CompareFighters(ctx context.Context, aRef, bRef string) (*FighterComparison, error) {
+	s.logger.InfoContext(ctx, "comparing foo fighters", "a", aRef, "b", bRef)
+
+	var ve xerror.ValidationError
+	if strings.TrimSpace(aRef) == "" {
+		ve = ve.Add("a", xerror.ViolationRequired, "is required")
+	}
+	if strings.TrimSpace(bRef) == "" {
+		ve = ve.Add("b", xerror.ViolationRequired, "is required")
+	}
+	if len(ve) != 0 {
+		return nil, ErrFighterInvalid.X(ve)
+	}
+
+	a, err := s.FighterByID(ctx, aRef)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.FighterByID(ctx, bRef)
+	if err != nil {
+		return nil, err
+	}
+	if a.ID == b.ID {
+		return nil, ErrFighterInvalid.X(xerror.NewValidationError("b", xerror.ViolationDuplicate,
+			"must not be the same fighter as a"))
+	}
+
+	aBouts, err := s.fighterBoutsByOpponent(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	bBouts, err := s.fighterBoutsByOpponent(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &FighterComparison{
+		A:               *a,
+		B:               *b,
+		SharedOpponents: []SharedOpponent{},
+		DirectBouts:     aBouts[b.ID],
+	}
+	if c.DirectBouts == nil {
+		c.DirectBouts = []FighterBout{}
+	}
+
+	var ids []uuid.UUID
+	for id := range aBouts {
+		if id != b.ID && bBouts[id] != nil {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return c, nil
+	}
+	ff, err := s.repo.FightersByID(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("could not find shared opponents on repository: %s", err)
+	}
+	for _, f := range ff {
+		c.SharedOpponents = append(c.SharedOpponents, SharedOpponent{
+			Opponent: f,
+			ABouts:   aBouts[f.ID],
+			BBouts:   bBouts[f.ID],
+		})
+	}
+	slices.SortFunc(c.SharedOpponents, func(x, y SharedOpponent) int {
+		if n := y.latestBout().Compare(x.latestBout().Time); n != 0 {
+			return n
+		}
+		return strings.Compare(x.Opponent.ID.String(), y.Opponent.ID.String())
+	})
+	return c, nil
+}
+
+// fighterBoutsByOpponent returns fighter bouts from the fighter perspective
+// grouped by opponent, latest first.
+func (s *Service) fighterBoutsByOpponent(ctx context.Context, fighterID uuid.UUID) (map[uuid.UUID][]FighterBout, error) {
+	bb, err := s.repo.FighterBouts(ctx, fighterID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find fighter bouts on repository: %s", err)
+	}
+	m := map[uuid.UUID][]FighterBout{}
+	for _, b := range bb {
+		fb := newFighterBout(fighterID, b)
+		m[fb.OpponentID] = append(m[fb.OpponentID], fb)
+	}
+	return m, nil
+}
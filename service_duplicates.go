@@ -0,0 +1,124 @@
+package foo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+// FighterDuplicates returns likely duplicate fighters with similar names and
+// no conflicting date of birth and nationality, most likely first.
+func (s *Service) FighterDuplicates(ctx context.Context, limit int) ([]FighterDuplicate, error) {
+	s.logger.InfoContext(ctx, "listing foo fighter duplicates", "limit", limit)
+
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	dd, err := s.repo.FighterDuplicates(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not find fighter duplicates on repository: %s", err)
+	}
+	if dd == nil {
+		dd = []FighterDuplicate{}
+	}
+	return dd, nil
+}
+
+// MergeFighter merges a duplicate fighter into the fighter by id. Duplicate
+// bouts and history moves to the fighter and its id remains as an alias of
+// the fighter.
+func (s *Service) MergeFighter(ctx context.Context, sid string, m FighterMerge) (*Fighter, error) {
+	s.logger.InfoContext(ctx, "merging foo fighter", "id", sid, "duplicate_id", m.DuplicateID)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrFighterInvalid.X(err)
+	}
+	if m.DuplicateID == uuid.Nil {
+		return nil, ErrFighterInvalid.X(xerror.NewValidationError("duplicate_id", xerror.ViolationRequired, "is required"))
+	}
+	if m.DuplicateID == id {
+		return nil, ErrFighterInvalid.X(xerror.NewValidationError("duplicate_id", xerror.ViolationDuplicate,
+			"must not be the same as the fighter"))
+	}
+
+	// Checks both fighters exist and not resolved from an alias of the other.
+	var ff [2]*Fighter
+	for i, fid := range []uuid.UUID{id, m.DuplicateID} {
+		f, err := s.repo.Fighter(ctx, fid, false)
+		if err != nil {
+			if errors.Is(err, ErrFighterNotFound) {
+				return nil, ErrFighterNotFound.X(fmt.Errorf("fighter %s not found", fid))
+			}
+			return nil, fmt.Errorf("could not find fighter on repository: %s", err)
+		}
+		ff[i] = f
+	}
+	if ff[0].ID != id || ff[1].ID != m.DuplicateID || ff[0].ID == ff[1].ID {
+		return nil, ErrFighterInvalid.X(errors.New("fighter is already merged"))
+	}
+
+	mm, err := s.checkFighterMerge(ctx, id, m.DuplicateID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := s.repo.MergeFighter(ctx, id, m.DuplicateID, mm...)
+	if err != nil {
+		if errors.Is(err, ErrFighterNotFound) {
+			return nil, ErrFighterNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not merge fighter on repository: %s", err)
+	}
+	return f, nil
+}
+
+// checkFighterMerge checks duplicate bouts can move to the fighter and returns
+// ranking messages of weight classes affected by the move and a stats refresh
+// message of the fighter when completed bouts move.
+func (s *Service) checkFighterMerge(ctx context.Context, id, duplicateID uuid.UUID) ([]Message, error) {
+	bb, err := s.repo.FighterBouts(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not find fighter bouts on repository: %s", err)
+	}
+	events := map[uuid.UUID]bool{}
+	for _, b := range bb {
+		if b.EventID != nil {
+			events[*b.EventID] = true
+		}
+	}
+
+	dupBouts, err := s.repo.FighterBouts(ctx, duplicateID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find fighter bouts on repository: %s", err)
+	}
+	var mm []Message
+	seen := map[WeightClass]bool{}
+	movesCompleted := false
+	for i, b := range dupBouts {
+		if b.Involves(id) {
+			return nil, ErrFighterInvalid.X(fmt.Errorf("fighters fought each other on bout %s", b.ID))
+		}
+		if b.EventID != nil && events[*b.EventID] {
+			return nil, ErrFighterInvalid.X(fmt.Errorf("fighters are both booked on event %s", *b.EventID))
+		}
+		movesCompleted = movesCompleted || b.Completed()
+		if seen[b.WeightClass] {
+			continue
+		}
+		if m := boutCompletedMessages(nil, &dupBouts[i]); len(m) != 0 {
+			seen[b.WeightClass] = true
+			mm = append(mm, m...)
+		}
+	}
+	if movesCompleted {
+		mm = append(mm, newMessage(TopicFighterStats, FighterStatsStale{FighterIDs: []uuid.UUID{id}}))
+	}
+	return mm, nil
+}
@@ -0,0 +1,146 @@
+package foo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+// EventByID returns an event by id with its fight card.
+func (s *Service) EventByID(ctx context.Context, sid string) (*Event, error) {
+	s.logger.InfoContext(ctx, "getting event by id", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrEventInvalid.X(err)
+	}
+
+	e, err := s.repo.Event(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrEventNotFound) {
+			return nil, ErrEventNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not find event on repository: %s", err)
+	}
+	return e, nil
+}
+
+// Events returns events without fight cards, latest first.
+func (s *Service) Events(ctx context.Context, q EventQuery) ([]Event, error) {
+	s.logger.InfoContext(ctx, "listing events", "limit", q.Limit)
+
+	q = q.setDefaults()
+	ee, err := s.repo.Events(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("could not list events on repository: %s", err)
+	}
+	if ee == nil {
+		ee = []Event{}
+	}
+	return ee, nil
+}
+
+// CreateEvent creates a new event with its fight card.
+func (s *Service) CreateEvent(ctx context.Context, e *Event) (*Event, error) {
+	s.logger.InfoContext(ctx, "creating event", "name", e.Name)
+
+	e.ID = uuid.Nil
+	mm, err := s.checkEvent(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.CreateEvent(ctx, e, mm...); err != nil {
+		return nil, fmt.Errorf("could not create event on repository: %s", err)
+	}
+	return e, nil
+}
+
+// UpdateEvent updates event details by id and replaces its fight card.
+func (s *Service) UpdateEvent(ctx context.Context, sid string, e *Event) (*Event, error) {
+	s.logger.InfoContext(ctx, "updating event", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrEventInvalid.X(err)
+	}
+	e.ID = id
+	mm, err := s.checkEvent(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.UpdateEvent(ctx, e, mm...); err != nil {
+		if errors.Is(err, ErrEventNotFound) {
+			return nil, ErrEventNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not update event on repository: %s", err)
+	}
+	return e, nil
+}
+
+// DeleteEvent deletes an event by id, its card bouts are kept without an event.
+func (s *Service) DeleteEvent(ctx context.Context, sid string) error {
+	s.logger.InfoContext(ctx, "deleting event", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return ErrEventInvalid.X(err)
+	}
+
+	if err = s.repo.DeleteEvent(ctx, id); err != nil {
+		if errors.Is(err, ErrEventNotFound) {
+			return ErrEventNotFound.X(err)
+		}
+		return fmt.Errorf("could not delete event on repository: %s", err)
+	}
+	return nil
+}
+
+// checkEvent validates event and its card scheduling rules by populating card
+// bouts. It returns bout messages of card bouts moved to the event date, bouts
+// with a result must already be on the event date.
+func (s *Service) checkEvent(ctx context.Context, e *Event) ([]Message, error) {
+	e.Name = strings.TrimSpace(e.Name)
+	e.Venue = strings.TrimSpace(e.Venue)
+	e.normalizeCard()
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	var fe xerror.ValidationError
+	var mm []Message
+	for i := range e.Card {
+		c := &e.Card[i]
+		b, err := s.repo.Bout(ctx, c.BoutID)
+		if err != nil {
+			if errors.Is(err, ErrBoutNotFound) {
+				return nil, ErrBoutNotFound.X(fmt.Errorf("bout %s not found", c.BoutID))
+			}
+			return nil, fmt.Errorf("could not find bout on repository: %s", err)
+		}
+		c.Bout = b
+		if b.Date.Equal(e.Date.Time) {
+			continue
+		}
+		if b.Completed() {
+			fe = fe.Add(fmt.Sprintf("card[%d].bout_id", i), xerror.ViolationConflict,
+				"bout with a result must not be moved to another date")
+			continue
+		}
+		moved := *b
+		moved.Date = e.Date
+		mm = append(mm, boutMessages(b, &moved)...)
+	}
+	if len(fe) != 0 {
+		return nil, ErrEventInvalid.X(fe)
+	}
+	if err := e.checkSchedule(); err != nil {
+		return nil, err
+	}
+	return mm, nil
+}
@@ -0,0 +1,70 @@
+package foo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// FollowFighter adds a fighter by id or slug to the context user follows,
+// following a followed fighter keeps the original follow.
+func (s *Service) FollowFighter(ctx context.Context, fighterSID string) (*Follow, error) {
+	s.logger.InfoContext(ctx, "following foo fighter", "fighter_id", fighterSID)
+
+	userID, err := actorUserID(ctx)
+	if err != nil {
+		return nil, ErrFollowInvalid.X(err)
+	}
+	f, err := s.FighterByID(ctx, fighterSID)
+	if err != nil {
+		return nil, err
+	}
+
+	fl := &Follow{Fighter: *f}
+	if err = s.repo.CreateFollow(ctx, userID, fl); err != nil {
+		if errors.Is(err, ErrFighterNotFound) {
+			return nil, ErrFighterNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not create follow on repository: %s", err)
+	}
+	return fl, nil
+}
+
+// UnfollowFighter removes a fighter by id or slug from the context user
+// follows, unfollowed fighter is not an error.
+func (s *Service) UnfollowFighter(ctx context.Context, fighterSID string) error {
+	s.logger.InfoContext(ctx, "unfollowing foo fighter", "fighter_id", fighterSID)
+
+	userID, err := actorUserID(ctx)
+	if err != nil {
+		return ErrFollowInvalid.X(err)
+	}
+	f, err := s.FighterByID(ctx, fighterSID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.DeleteFollow(ctx, userID, f.ID); err != nil {
+		return fmt.Errorf("could not delete follow on repository: %s", err)
+	}
+	return nil
+}
+
+// Follows returns fighters followed by the context user, latest first.
+func (s *Service) Follows(ctx context.Context) ([]Follow, error) {
+	s.logger.InfoContext(ctx, "listing foo follows")
+
+	userID, err := actorUserID(ctx)
+	if err != nil {
+		return nil, ErrFollowInvalid.X(err)
+	}
+
+	ff, err := s.repo.Follows(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find follows on repository: %s", err)
+	}
+	if ff == nil {
+		ff = []Follow{}
+	}
+	return ff, nil
+}
@@ -0,0 +1,43 @@
+package foo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// FighterHistory returns fighter change history by id or current slug from
+// latest. History of a purged fighter is kept and still returned by id.
+func (s *Service) FighterHistory(ctx context.Context, ref string) ([]FighterChange, error) {
+	s.logger.InfoContext(ctx, "listing foo fighter history", "id", ref)
+
+	id, err := uuid.Parse(ref)
+	if err != nil {
+		f, err := s.repo.FighterBySlug(ctx, strings.ToLower(ref))
+		if err != nil {
+			if errors.Is(err, ErrFighterNotFound) {
+				return nil, ErrFighterNotFound.X(err)
+			}
+			return nil, fmt.Errorf("could not find fighter on repository: %s", err)
+		}
+		id = f.ID
+	}
+
+	hh, err := s.repo.FighterHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not list fighter history on repository: %s", err)
+	}
+	if len(hh) != 0 {
+		return hh, nil
+	}
+	if _, err = s.repo.Fighter(ctx, id, true); err != nil {
+		if errors.Is(err, ErrFighterNotFound) {
+			return nil, ErrFighterNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not find fighter on repository: %s", err)
+	}
+	return []FighterChange{}, nil
+}
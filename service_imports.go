@@ -0,0 +1,134 @@
+package foo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+// CreateFighterImport stores a fighter import file and queues it for the worker.
+func (s *Service) CreateFighterImport(ctx context.Context, format FighterImportFormat, data []byte) (*FighterImport, error) {
+	s.logger.InfoContext(ctx, "creating foo fighter import", "format", format, "size", len(data))
+
+	switch format {
+	case FighterImportCSV, FighterImportJSONL:
+	default:
+		return nil, ErrFighterImportInvalid.X(xerror.NewValidationError("format", xerror.ViolationUnsupported,
+			"must be one of csv or jsonl"))
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, ErrFighterImportInvalid.X(xerror.NewValidationError("file", xerror.ViolationRequired, "must not be empty"))
+	}
+	if len(data) > MaxFighterImportSize {
+		return nil, ErrFighterImportInvalid.X(xerror.NewValidationError("file", xerror.ViolationTooLarge,
+			fmt.Sprintf("must not exceed %d bytes", MaxFighterImportSize)))
+	}
+
+	a := ActorFromContext(ctx)
+	imp := &FighterImport{
+		ID:        uuid.New(),
+		Format:    format,
+		Status:    FighterImportPending,
+		UserID:    a.UserID,
+		RequestID: a.RequestID,
+	}
+	m := newMessage(TopicFightersImport, FighterImportCreated{ImportID: imp.ID})
+	if err := s.repo.CreateFighterImport(ctx, imp, data, m); err != nil {
+		return nil, fmt.Errorf("could not create fighter import on repository: %s", err)
+	}
+	imp.Errors = []ImportRowError{}
+	return imp, nil
+}
+
+// FighterImportByID returns a fighter import progress and row errors by id.
+func (s *Service) FighterImportByID(ctx context.Context, sid string) (*FighterImport, error) {
+	s.logger.InfoContext(ctx, "getting foo fighter import by id", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrFighterImportInvalid.X(err)
+	}
+
+	imp, err := s.repo.FighterImport(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrFighterImportNotFound) {
+			return nil, ErrFighterImportNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not find fighter import on repository: %s", err)
+	}
+	if imp.Errors == nil {
+		imp.Errors = []ImportRowError{}
+	}
+	return imp, nil
+}
+
+// ProcessFighterImport validates and upserts fighter import rows in batches as
+// the import actor. Progress is saved per batch so a retried import resumes
+// from the last saved batch.
+func (s *Service) ProcessFighterImport(ctx context.Context, id uuid.UUID) error {
+	s.logger.InfoContext(ctx, "processing foo fighter import", "id", id)
+
+	imp, err := s.repo.FighterImport(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not find fighter import on repository: %s", err)
+	}
+	if imp.Status == FighterImportDone || imp.Status == FighterImportFailed {
+		return nil
+	}
+	data, err := s.repo.FighterImportData(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not find fighter import data on repository: %s", err)
+	}
+
+	rows, err := parseFighterImport(imp.Format, data)
+	if err != nil {
+		imp.Status = FighterImportFailed
+		imp.Message = err.Error()
+		return s.updateFighterImportStatus(ctx, imp)
+	}
+	imp.Status = FighterImportProcessing
+	imp.TotalRows = len(rows)
+	if err = s.updateFighterImportStatus(ctx, imp); err != nil {
+		return err
+	}
+
+	ctx = ContextWithActor(ctx, Actor{UserID: imp.UserID, RequestID: imp.RequestID})
+	for start := imp.ProcessedRows; start < len(rows); start += fighterImportBatchSize {
+		end := min(start+fighterImportBatchSize, len(rows))
+		valid, errs := validateImportRows(rows[start:end])
+		if err = s.repo.ImportFighters(ctx, id, valid, errs, end); err != nil {
+			return fmt.Errorf("could not import fighters on repository: %s", err)
+		}
+	}
+
+	imp.Status = FighterImportDone
+	return s.updateFighterImportStatus(ctx, imp)
+}
+
+// FailFighterImport marks an import that could not be processed as failed
+// with the reason, done and failed imports are left as is.
+func (s *Service) FailFighterImport(ctx context.Context, id uuid.UUID, reason string) error {
+	s.logger.InfoContext(ctx, "failing foo fighter import", "id", id, "reason", reason)
+
+	imp, err := s.repo.FighterImport(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not find fighter import on repository: %s", err)
+	}
+	if imp.Status == FighterImportDone || imp.Status == FighterImportFailed {
+		return nil
+	}
+	imp.Status = FighterImportFailed
+	imp.Message = reason
+	return s.updateFighterImportStatus(ctx, imp)
+}
+
+func (s *Service) updateFighterImportStatus(ctx context.Context, imp *FighterImport) error {
+	if err := s.repo.UpdateFighterImportStatus(ctx, imp); err != nil {
+		return fmt.Errorf("could not update fighter import on repository: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package foo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+// CreateFighterMedia uploads a media file of a fighter to the blob store and
+// queues its thumbnail generation. Content type is detected from the file and
+// kind defaults to photo or video base on it.
+func (s *Service) CreateFighterMedia(ctx context.Context, fighterSID string, kind MediaKind, filename string, r io.Reader) (*Media, error) {
+	s.logger.InfoContext(ctx, "creating foo fighter media", "fighter_id", fighterSID, "kind", kind)
+
+	f, err := s.FighterByID(ctx, fighterSID)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReaderSize(r, 512)
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("could not read media: %s", err)
+	}
+	if len(head) == 0 {
+		return nil, ErrMediaInvalid.X(xerror.NewValidationError("file", xerror.ViolationRequired, "must not be empty"))
+	}
+	contentType, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	mt, ok := mediaTypes[contentType]
+	if !ok {
+		return nil, ErrMediaInvalid.X(xerror.NewValidationError("file", xerror.ViolationUnsupported,
+			fmt.Sprintf("has unsupported media type %s", contentType)))
+	}
+	if kind == "" {
+		kind = mt.kinds[0]
+	}
+	if !slices.Contains(mt.kinds, kind) {
+		return nil, ErrMediaInvalid.X(xerror.NewValidationError("kind", xerror.ViolationUnsupported,
+			fmt.Sprintf("must not be %s for %s media", kind, contentType)))
+	}
+
+	m := &Media{
+		ID:          uuid.New(),
+		FighterID:   f.ID,
+		Kind:        kind,
+		ContentType: contentType,
+		Filename:    path.Base(filename),
+		UserID:      ActorFromContext(ctx).UserID,
+	}
+	if m.Filename == "." || m.Filename == "/" {
+		m.Filename = ""
+	}
+	m.BlobKey = mediaKey(f.ID, m.ID, "original"+mt.ext)
+
+	cr := &countingReader{r: io.LimitReader(br, MaxMediaSize+1)}
+	if err = s.blobs.Put(ctx, m.BlobKey, cr, contentType); err != nil {
+		return nil, fmt.Errorf("could not put media on blob store: %s", err)
+	}
+	m.Size = cr.n
+	if m.Size > MaxMediaSize {
+		s.deleteBlob(ctx, m.BlobKey)
+		return nil, ErrMediaInvalid.X(xerror.NewValidationError("file", xerror.ViolationTooLarge,
+			fmt.Sprintf("must not exceed %d bytes", MaxMediaSize)))
+	}
+
+	var mm []Message
+	if m.HasThumbnail() {
+		mm = append(mm, newMessage(TopicMediaThumbnail, MediaUploaded{MediaID: m.ID}))
+	}
+	if err = s.repo.CreateMedia(ctx, m, mm...); err != nil {
+		s.deleteBlob(ctx, m.BlobKey)
+		return nil, fmt.Errorf("could not create media on repository: %s", err)
+	}
+	if err = s.signMediaURLs(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FighterMedia returns media of a fighter from latest with signed urls.
+func (s *Service) FighterMedia(ctx context.Context, fighterSID string) ([]Media, error) {
+	s.logger.InfoContext(ctx, "listing foo fighter media", "fighter_id", fighterSID)
+
+	f, err := s.FighterByID(ctx, fighterSID)
+	if err != nil {
+		return nil, err
+	}
+	mm, err := s.repo.FighterMedia(ctx, f.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list fighter media on repository: %s", err)
+	}
+	if mm == nil {
+		mm = []Media{}
+	}
+	for i := range mm {
+		if err = s.signMediaURLs(ctx, &mm[i]); err != nil {
+			return nil, err
+		}
+	}
+	return mm, nil
+}
+
+// GenerateMediaThumbnail stores a thumbnail of an uploaded image media.
+// Media that cannot be thumbnailed or already has one is skipped.
+func (s *Service) GenerateMediaThumbnail(ctx context.Context, id uuid.UUID) error {
+	s.logger.InfoContext(ctx, "generating foo media thumbnail", "id", id)
+
+	m, err := s.repo.Media(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrMediaNotFound) {
+			// Media of a purged fighter, nothing to do.
+			return nil
+		}
+		return fmt.Errorf("could not find media on repository: %s", err)
+	}
+	if !m.HasThumbnail() || m.ThumbnailKey != "" {
+		return nil
+	}
+
+	rc, err := s.blobs.Get(ctx, m.BlobKey)
+	if err != nil {
+		return fmt.Errorf("could not get media on blob store: %s", err)
+	}
+	defer rc.Close()
+	thumb, err := makeThumbnail(rc, thumbnailSize)
+	if err != nil {
+		return fmt.Errorf("could not make thumbnail: %s", err)
+	}
+
+	key := mediaKey(m.FighterID, m.ID, "thumbnail.jpg")
+	if err = s.blobs.Put(ctx, key, bytes.NewReader(thumb), "image/jpeg"); err != nil {
+		return fmt.Errorf("could not put thumbnail on blob store: %s", err)
+	}
+	if err = s.repo.UpdateMediaThumbnail(ctx, m.ID, key); err != nil {
+		return fmt.Errorf("could not update media thumbnail on repository: %s", err)
+	}
+	return nil
+}
+
+// signMediaURLs sets signed download urls of media and its thumbnail.
+func (s *Service) signMediaURLs(ctx context.Context, m *Media) error {
+	exp := time.Now().Add(MediaURLTTL).UTC()
+	u, err := s.blobs.SignedURL(ctx, m.BlobKey, MediaURLTTL)
+	if err != nil {
+		return fmt.Errorf("could not sign media url: %s", err)
+	}
+	m.URL, m.URLExpiresAt = u, &exp
+	if m.ThumbnailKey == "" {
+		return nil
+	}
+	if m.ThumbnailURL, err = s.blobs.SignedURL(ctx, m.ThumbnailKey, MediaURLTTL); err != nil {
+		return fmt.Errorf("could not sign thumbnail url: %s", err)
+	}
+	return nil
+}
+
+// deleteBlob removes an orphan blob of a failed upload or a purged fighter.
+func (s *Service) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobs.Delete(ctx, key); err != nil {
+		s.logger.ErrorContext(ctx, "could not delete blob", "key", key, "err", err)
+	}
+}
+
+// countingReader counts bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,210 @@
+package foo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotifyBoutFollowers queues a notification delivery to each user following
+// either fighter of a bout. Deleted bouts are skipped.
+func (s *Service) NotifyBoutFollowers(ctx context.Context, bn BoutNotice) error {
+	s.logger.InfoContext(ctx, "notifying bout followers", "bout_id", bn.BoutID, "kind", bn.Kind)
+
+	b, err := s.repo.Bout(ctx, bn.BoutID)
+	if err != nil {
+		if errors.Is(err, ErrBoutNotFound) {
+			s.logger.InfoContext(ctx, "bout no longer exists, skipping notifications", "bout_id", bn.BoutID)
+			return nil
+		}
+		return fmt.Errorf("could not find bout on repository: %s", err)
+	}
+	red, err := s.repo.Fighter(ctx, b.RedFighterID, true)
+	if err != nil {
+		return fmt.Errorf("could not find red fighter on repository: %s", err)
+	}
+	blue, err := s.repo.Fighter(ctx, b.BlueFighterID, true)
+	if err != nil {
+		return fmt.Errorf("could not find blue fighter on repository: %s", err)
+	}
+
+	userIDs, err := s.repo.FollowerIDs(ctx, []uuid.UUID{b.RedFighterID, b.BlueFighterID})
+	if err != nil {
+		return fmt.Errorf("could not find followers on repository: %s", err)
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	mm := make([]Message, len(userIDs))
+	for i, uid := range userIDs {
+		n := newBoutNotification(uid, bn.Kind, *b, *red, *blue)
+		n.CreatedAt = now
+		mm[i] = newMessage(TopicNotificationDeliver, NotificationDelivery{Notification: n})
+	}
+	if err = s.repo.Publish(ctx, mm...); err != nil {
+		return fmt.Errorf("could not publish notification deliveries on repository: %s", err)
+	}
+	return nil
+}
+
+// DeliverNotification sends a notification through the user preferred
+// channels. Email and webhook deliveries during quiet hours are held until
+// they end and failed channels are retried with backoff up to max attempts.
+func (s *Service) DeliverNotification(ctx context.Context, d NotificationDelivery) error {
+	n := d.Notification
+	s.logger.InfoContext(ctx, "delivering notification", "id", n.ID, "user_id", n.UserID, "attempt", d.Attempt)
+
+	p, err := s.repo.NotificationPreferences(ctx, n.UserID)
+	if err != nil {
+		if !errors.Is(err, ErrNotificationNotFound) {
+			return fmt.Errorf("could not find notification preferences on repository: %s", err)
+		}
+		def := DefaultNotificationPreferences()
+		p = &def
+	}
+	channels := d.Channels
+	if len(channels) == 0 {
+		channels = p.Channels
+	}
+
+	now := time.Now()
+	var held, failed []NotificationChannel
+	var heldUntil time.Time
+	for _, c := range channels {
+		if !p.Enabled(c) {
+			continue
+		}
+		nf, ok := s.notifiers[c]
+		if !ok {
+			s.logger.WarnContext(ctx, "notification channel has no notifier", "channel", c)
+			continue
+		}
+		if c != NotificationChannelInApp && p.QuietHours != nil {
+			if end, ok := p.QuietHours.Until(now); ok {
+				held, heldUntil = append(held, c), end
+				continue
+			}
+		}
+		if err = nf.Notify(ctx, n, *p); err != nil {
+			s.logger.ErrorContext(ctx, "could not deliver notification", "id", n.ID, "channel", c, "err", err)
+			failed = append(failed, c)
+		}
+	}
+
+	var mm []Message
+	if len(held) != 0 {
+		m := newMessage(TopicNotificationDeliver, NotificationDelivery{Notification: n, Channels: held, Attempt: d.Attempt})
+		m.NotBefore = heldUntil
+		mm = append(mm, m)
+	}
+	if len(failed) != 0 {
+		if d.Attempt+1 < notificationMaxAttempts {
+			m := newMessage(TopicNotificationDeliver, NotificationDelivery{Notification: n, Channels: failed, Attempt: d.Attempt + 1})
+			m.NotBefore = now.Add(notificationRetryDelay << d.Attempt)
+			mm = append(mm, m)
+		} else {
+			s.logger.ErrorContext(ctx, "notification delivery attempts exhausted", "id", n.ID, "channels", failed)
+		}
+	}
+	if len(mm) == 0 {
+		return nil
+	}
+	if err = s.repo.Publish(ctx, mm...); err != nil {
+		return fmt.Errorf("could not publish notification deliveries on repository: %s", err)
+	}
+	return nil
+}
+
+// NotificationPreferences returns the context user notification preferences,
+// defaults when not yet set.
+func (s *Service) NotificationPreferences(ctx context.Context) (*NotificationPreferences, error) {
+	s.logger.InfoContext(ctx, "getting notification preferences")
+
+	userID, err := actorUserID(ctx)
+	if err != nil {
+		return nil, ErrNotificationInvalid.X(err)
+	}
+
+	p, err := s.repo.NotificationPreferences(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotificationNotFound) {
+			def := DefaultNotificationPreferences()
+			return &def, nil
+		}
+		return nil, fmt.Errorf("could not find notification preferences on repository: %s", err)
+	}
+	return p, nil
+}
+
+// UpdateNotificationPreferences replaces the context user notification preferences.
+func (s *Service) UpdateNotificationPreferences(ctx context.Context, p *NotificationPreferences) (*NotificationPreferences, error) {
+	s.logger.InfoContext(ctx, "updating notification preferences")
+
+	userID, err := actorUserID(ctx)
+	if err != nil {
+		return nil, ErrNotificationInvalid.X(err)
+	}
+	if p.Channels == nil {
+		p.Channels = []NotificationChannel{}
+	}
+	if err = p.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.UpdateNotificationPreferences(ctx, userID, p); err != nil {
+		return nil, fmt.Errorf("could not update notification preferences on repository: %s", err)
+	}
+	return p, nil
+}
+
+// Notifications returns the context user in-app notifications, latest first.
+func (s *Service) Notifications(ctx context.Context, limit int) ([]Notification, error) {
+	s.logger.InfoContext(ctx, "listing notifications", "limit", limit)
+
+	userID, err := actorUserID(ctx)
+	if err != nil {
+		return nil, ErrNotificationInvalid.X(err)
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	nn, err := s.repo.Notifications(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not find notifications on repository: %s", err)
+	}
+	if nn == nil {
+		nn = []Notification{}
+	}
+	return nn, nil
+}
+
+// ReadNotification marks a context user in-app notification by id as read,
+// reading a read notification keeps its original read time.
+func (s *Service) ReadNotification(ctx context.Context, sid string) error {
+	s.logger.InfoContext(ctx, "reading notification", "id", sid)
+
+	userID, err := actorUserID(ctx)
+	if err != nil {
+		return ErrNotificationInvalid.X(err)
+	}
+	id, err := parseID("id", sid)
+	if err != nil {
+		return ErrNotificationInvalid.X(err)
+	}
+
+	if err = s.repo.ReadNotification(ctx, userID, id); err != nil {
+		if errors.Is(err, ErrNotificationNotFound) {
+			return ErrNotificationNotFound.X(err)
+		}
+		return fmt.Errorf("could not read notification on repository: %s", err)
+	}
+	return nil
+}
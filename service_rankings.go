@@ -0,0 +1,73 @@
+package foo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kudarap/foo/xerror"
+)
+
+// RecomputeRankings replays all completed bouts of a weight class and replaces its
+// ratings and rating history.
+func (s *Service) RecomputeRankings(ctx context.Context, wc WeightClass) error {
+	s.logger.InfoContext(ctx, "recomputing rankings", "weight_class", wc)
+
+	if !wc.Valid() {
+		return ErrRankingInvalid.X(xerror.NewValidationError("weight_class", xerror.ViolationUnsupported, "is not a known weight class"))
+	}
+
+	bb, err := s.repo.CompletedBouts(ctx, wc)
+	if err != nil {
+		return fmt.Errorf("could not find completed bouts on repository: %s", err)
+	}
+	rr, hh := computeRatings(wc, bb)
+	if err = s.repo.ReplaceRatings(ctx, wc, rr, hh); err != nil {
+		return fmt.Errorf("could not replace ratings on repository: %s", err)
+	}
+	return nil
+}
+
+// Rankings returns weight class ratings ordered by rank.
+func (s *Service) Rankings(ctx context.Context, weightClass string, limit int) ([]Rating, error) {
+	s.logger.InfoContext(ctx, "getting rankings", "weight_class", weightClass, "limit", limit)
+
+	wc := WeightClass(strings.ToLower(weightClass))
+	if !wc.Valid() {
+		return nil, ErrRankingInvalid.X(xerror.NewValidationError("weight_class", xerror.ViolationUnsupported, "is not a known weight class"))
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	rr, err := s.repo.Ratings(ctx, wc, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not find ratings on repository: %s", err)
+	}
+	if rr == nil {
+		rr = []Rating{}
+	}
+	return rr, nil
+}
+
+// FighterRatingHistory returns fighter rating changes across weight classes, oldest first.
+func (s *Service) FighterRatingHistory(ctx context.Context, sid string) ([]RatingChange, error) {
+	s.logger.InfoContext(ctx, "getting fighter rating history", "fighter_id", sid)
+
+	f, err := s.FighterByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+
+	hh, err := s.repo.FighterRatingHistory(ctx, f.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find rating history on repository: %s", err)
+	}
+	if hh == nil {
+		hh = []RatingChange{}
+	}
+	return hh, nil
+}
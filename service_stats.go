@@ -0,0 +1,97 @@
+package foo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FighterStats returns career aggregates of a fighter by id or slug, fighters
+// without refreshed stats have zero stats.
+func (s *Service) FighterStats(ctx context.Context, ref string) (*FighterStats, error) {
+	s.logger.InfoContext(ctx, "getting fighter stats", "fighter_id", ref)
+
+	f, err := s.FighterByID(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := s.repo.FighterStats(ctx, f.ID)
+	if err != nil {
+		if !errors.Is(err, ErrFighterNotFound) {
+			return nil, fmt.Errorf("could not find fighter stats on repository: %s", err)
+		}
+		st = &FighterStats{FighterID: f.ID, MethodRates: map[BoutMethod]float64{}}
+	}
+	st.countActivity(time.Now())
+	return st, nil
+}
+
+// RefreshFighterStats recomputes stats of fighters and their opponents, whose
+// strength of schedule depends on the fighters records.
+func (s *Service) RefreshFighterStats(ctx context.Context, fighterIDs []uuid.UUID) error {
+	s.logger.InfoContext(ctx, "refreshing fighter stats", "fighter_ids", fighterIDs)
+
+	bouts := map[uuid.UUID][]Bout{}
+	ids := slices.Clone(fighterIDs)
+	for _, id := range fighterIDs {
+		bb, err := s.fighterBouts(ctx, bouts, id)
+		if err != nil {
+			return err
+		}
+		for _, b := range bb {
+			if o := b.Opponent(id); b.Completed() && !slices.Contains(ids, o) {
+				ids = append(ids, o)
+			}
+		}
+	}
+
+	var opponentIDs []uuid.UUID
+	for _, id := range ids {
+		bb, err := s.fighterBouts(ctx, bouts, id)
+		if err != nil {
+			return err
+		}
+		for _, b := range bb {
+			if o := b.Opponent(id); b.Completed() && !slices.Contains(opponentIDs, o) {
+				opponentIDs = append(opponentIDs, o)
+			}
+		}
+	}
+	records := map[uuid.UUID]Record{}
+	if len(opponentIDs) != 0 {
+		ff, err := s.repo.FightersByID(ctx, opponentIDs)
+		if err != nil {
+			return fmt.Errorf("could not find opponents on repository: %s", err)
+		}
+		for _, f := range ff {
+			records[f.ID] = f.Record
+		}
+	}
+
+	now := time.Now()
+	for _, id := range ids {
+		st := newFighterStats(id, bouts[id], records, now)
+		if err := s.repo.UpdateFighterStats(ctx, &st); err != nil {
+			return fmt.Errorf("could not update fighter stats on repository: %s", err)
+		}
+	}
+	return nil
+}
+
+// fighterBouts returns fighter bouts latest first, cached on bouts.
+func (s *Service) fighterBouts(ctx context.Context, bouts map[uuid.UUID][]Bout, id uuid.UUID) ([]Bout, error) {
+	if bb, ok := bouts[id]; ok {
+		return bb, nil
+	}
+	bb, err := s.repo.FighterBouts(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not find fighter bouts on repository: %s", err)
+	}
+	bouts[id] = bb
+	return bb, nil
+}
@@ -0,0 +1,251 @@
+package foo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+// TeamByID returns a team by id.
+func (s *Service) TeamByID(ctx context.Context, sid string) (*Team, error) {
+	s.logger.InfoContext(ctx, "getting team by id", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrTeamInvalid.X(err)
+	}
+
+	t, err := s.repo.Team(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrTeamNotFound) {
+			return nil, ErrTeamNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not find team on repository: %s", err)
+	}
+	return t, nil
+}
+
+// Teams returns teams ordered by name.
+func (s *Service) Teams(ctx context.Context, q TeamQuery) ([]Team, error) {
+	s.logger.InfoContext(ctx, "listing teams", "limit", q.Limit)
+
+	q = q.setDefaults()
+	tt, err := s.repo.Teams(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("could not list teams on repository: %s", err)
+	}
+	if tt == nil {
+		tt = []Team{}
+	}
+	return tt, nil
+}
+
+// CreateTeam creates a new team.
+func (s *Service) CreateTeam(ctx context.Context, t *Team) (*Team, error) {
+	s.logger.InfoContext(ctx, "creating team", "name", t.Name)
+
+	t.ID = uuid.Nil
+	t.normalize()
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateTeam(ctx, t); err != nil {
+		return nil, fmt.Errorf("could not create team on repository: %s", err)
+	}
+	return t, nil
+}
+
+// UpdateTeam updates team details by id.
+func (s *Service) UpdateTeam(ctx context.Context, sid string, t *Team) (*Team, error) {
+	s.logger.InfoContext(ctx, "updating team", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrTeamInvalid.X(err)
+	}
+	t.ID = id
+	t.normalize()
+	if err = t.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.UpdateTeam(ctx, t); err != nil {
+		if errors.Is(err, ErrTeamNotFound) {
+			return nil, ErrTeamNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not update team on repository: %s", err)
+	}
+	return t, nil
+}
+
+// DeleteTeam deletes a team by id along with its affiliation history.
+func (s *Service) DeleteTeam(ctx context.Context, sid string) error {
+	s.logger.InfoContext(ctx, "deleting team", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return ErrTeamInvalid.X(err)
+	}
+
+	if err = s.repo.DeleteTeam(ctx, id); err != nil {
+		if errors.Is(err, ErrTeamNotFound) {
+			return ErrTeamNotFound.X(err)
+		}
+		return fmt.Errorf("could not delete team on repository: %s", err)
+	}
+	return nil
+}
+
+// TeamMembers returns current and former fighters of a team, latest joined first.
+func (s *Service) TeamMembers(ctx context.Context, sid string) (*TeamMembers, error) {
+	s.logger.InfoContext(ctx, "listing team members", "id", sid)
+
+	t, err := s.TeamByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+
+	aa, err := s.repo.TeamAffiliations(ctx, t.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list team affiliations on repository: %s", err)
+	}
+	return newTeamMembers(aa), nil
+}
+
+// FighterAffiliations returns team history of a fighter by id or slug, latest
+// joined first.
+func (s *Service) FighterAffiliations(ctx context.Context, fighterSID string) ([]Affiliation, error) {
+	s.logger.InfoContext(ctx, "listing fighter affiliations", "fighter_id", fighterSID)
+
+	f, err := s.FighterByID(ctx, fighterSID)
+	if err != nil {
+		return nil, err
+	}
+
+	aa, err := s.repo.FighterAffiliations(ctx, f.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list fighter affiliations on repository: %s", err)
+	}
+	if aa == nil {
+		aa = []Affiliation{}
+	}
+	return aa, nil
+}
+
+// CreateAffiliation adds a team period to a fighter by id or slug history.
+func (s *Service) CreateAffiliation(ctx context.Context, fighterSID string, a *Affiliation) (*Affiliation, error) {
+	s.logger.InfoContext(ctx, "creating fighter affiliation", "fighter_id", fighterSID, "team_id", a.TeamID)
+
+	a.ID = uuid.Nil
+	if _, err := s.checkAffiliation(ctx, fighterSID, a); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateAffiliation(ctx, a); err != nil {
+		if errors.Is(err, ErrAffiliationInvalid) {
+			return nil, ErrAffiliationInvalid.X(xerror.NewValidationError("start_date", xerror.ViolationConflict,
+				"overlaps another affiliation with the team"))
+		}
+		return nil, fmt.Errorf("could not create affiliation on repository: %s", err)
+	}
+	return a, nil
+}
+
+// UpdateAffiliation updates a team period of a fighter by id or slug, usually
+// to set the end date when the fighter leaves the team.
+func (s *Service) UpdateAffiliation(ctx context.Context, fighterSID, sid string, a *Affiliation) (*Affiliation, error) {
+	s.logger.InfoContext(ctx, "updating fighter affiliation", "fighter_id", fighterSID, "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrAffiliationInvalid.X(err)
+	}
+	a.ID = id
+	prev, err := s.checkAffiliation(ctx, fighterSID, a)
+	if err != nil {
+		return nil, err
+	}
+	if prev == nil {
+		return nil, ErrAffiliationNotFound.X(fmt.Errorf("affiliation %s not found", id))
+	}
+	a.CreatedAt = prev.CreatedAt
+
+	if err = s.repo.UpdateAffiliation(ctx, a); err != nil {
+		if errors.Is(err, ErrAffiliationNotFound) {
+			return nil, ErrAffiliationNotFound.X(err)
+		}
+		if errors.Is(err, ErrAffiliationInvalid) {
+			return nil, ErrAffiliationInvalid.X(xerror.NewValidationError("start_date", xerror.ViolationConflict,
+				"overlaps another affiliation with the team"))
+		}
+		return nil, fmt.Errorf("could not update affiliation on repository: %s", err)
+	}
+	return a, nil
+}
+
+// DeleteAffiliation removes a team period from a fighter by id or slug history.
+func (s *Service) DeleteAffiliation(ctx context.Context, fighterSID, sid string) error {
+	s.logger.InfoContext(ctx, "deleting fighter affiliation", "fighter_id", fighterSID, "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return ErrAffiliationInvalid.X(err)
+	}
+	f, err := s.FighterByID(ctx, fighterSID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.DeleteAffiliation(ctx, f.ID, id); err != nil {
+		if errors.Is(err, ErrAffiliationNotFound) {
+			return ErrAffiliationNotFound.X(err)
+		}
+		return fmt.Errorf("could not delete affiliation on repository: %s", err)
+	}
+	return nil
+}
+
+// checkAffiliation validates affiliation of the fighter against the team and the
+// fighter history, populating its fighter id and team. It returns the stored
+// affiliation with the same id if any.
+func (s *Service) checkAffiliation(ctx context.Context, fighterSID string, a *Affiliation) (*Affiliation, error) {
+	f, err := s.FighterByID(ctx, fighterSID)
+	if err != nil {
+		return nil, err
+	}
+	a.FighterID = f.ID
+	a.StartDate = NewDate(a.StartDate.Time)
+	if a.EndDate != nil {
+		d := NewDate(a.EndDate.Time)
+		a.EndDate = &d
+	}
+	if err = a.Validate(); err != nil {
+		return nil, err
+	}
+
+	a.Team, err = s.repo.Team(ctx, a.TeamID)
+	if err != nil {
+		if errors.Is(err, ErrTeamNotFound) {
+			return nil, ErrTeamNotFound.X(fmt.Errorf("team %s not found", a.TeamID))
+		}
+		return nil, fmt.Errorf("could not find team on repository: %s", err)
+	}
+
+	history, err := s.repo.FighterAffiliations(ctx, f.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list fighter affiliations on repository: %s", err)
+	}
+	if err = a.checkHistory(history); err != nil {
+		return nil, err
+	}
+	for i := range history {
+		if a.ID != uuid.Nil && history[i].ID == a.ID {
+			return &history[i], nil
+		}
+	}
+	return nil, nil
+}
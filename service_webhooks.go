@@ -0,0 +1,243 @@
+package foo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateWebhook registers a webhook of the context user with a new signing secret.
+func (s *Service) CreateWebhook(ctx context.Context, w *Webhook) (*Webhook, error) {
+	s.logger.InfoContext(ctx, "creating webhook", "url", w.URL, "events", w.Events)
+
+	userID, err := actorUserID(ctx)
+	if err != nil {
+		return nil, ErrWebhookInvalid.X(err)
+	}
+	if err = w.Validate(); err != nil {
+		return nil, err
+	}
+	w.ID = uuid.New()
+	w.UserID = userID
+	w.Failures, w.DisabledAt = 0, nil
+	if w.Secret, err = newWebhookSecret(); err != nil {
+		return nil, fmt.Errorf("could not generate webhook secret: %s", err)
+	}
+
+	if err = s.repo.CreateWebhook(ctx, w); err != nil {
+		return nil, fmt.Errorf("could not create webhook on repository: %s", err)
+	}
+	return w, nil
+}
+
+// Webhooks returns registered webhooks, latest first.
+func (s *Service) Webhooks(ctx context.Context) ([]Webhook, error) {
+	s.logger.InfoContext(ctx, "listing webhooks")
+
+	ww, err := s.repo.Webhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not find webhooks on repository: %s", err)
+	}
+	if ww == nil {
+		ww = []Webhook{}
+	}
+	for i := range ww {
+		ww[i].Secret = ""
+	}
+	return ww, nil
+}
+
+// DeleteWebhook deletes a webhook by id along with its delivery log.
+func (s *Service) DeleteWebhook(ctx context.Context, sid string) error {
+	s.logger.InfoContext(ctx, "deleting webhook", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return ErrWebhookInvalid.X(err)
+	}
+
+	if err = s.repo.DeleteWebhook(ctx, id); err != nil {
+		if errors.Is(err, ErrWebhookNotFound) {
+			return ErrWebhookNotFound.X(err)
+		}
+		return fmt.Errorf("could not delete webhook on repository: %s", err)
+	}
+	return nil
+}
+
+// EnableWebhook re-enables a webhook by id disabled for failing too often.
+func (s *Service) EnableWebhook(ctx context.Context, sid string) (*Webhook, error) {
+	s.logger.InfoContext(ctx, "enabling webhook", "id", sid)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrWebhookInvalid.X(err)
+	}
+
+	w, err := s.repo.EnableWebhook(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrWebhookNotFound) {
+			return nil, ErrWebhookNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not enable webhook on repository: %s", err)
+	}
+	w.Secret = ""
+	return w, nil
+}
+
+// WebhookDeliveries returns delivery log of a webhook by id, latest first.
+func (s *Service) WebhookDeliveries(ctx context.Context, sid string, limit int) ([]WebhookDelivery, error) {
+	s.logger.InfoContext(ctx, "listing webhook deliveries", "id", sid, "limit", limit)
+
+	id, err := parseID("id", sid)
+	if err != nil {
+		return nil, ErrWebhookInvalid.X(err)
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	if _, err = s.repo.Webhook(ctx, id); err != nil {
+		if errors.Is(err, ErrWebhookNotFound) {
+			return nil, ErrWebhookNotFound.X(err)
+		}
+		return nil, fmt.Errorf("could not find webhook on repository: %s", err)
+	}
+	dd, err := s.repo.WebhookDeliveries(ctx, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not find webhook deliveries on repository: %s", err)
+	}
+	if dd == nil {
+		dd = []WebhookDelivery{}
+	}
+	return dd, nil
+}
+
+// DefaultWebhookDeliveryRetention is how long webhook deliveries are logged before purged.
+const DefaultWebhookDeliveryRetention = 30 * 24 * time.Hour
+
+// PurgeWebhookDeliveries removes webhook deliveries logged longer than
+// retention and returns the number of removed deliveries.
+func (s *Service) PurgeWebhookDeliveries(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		retention = DefaultWebhookDeliveryRetention
+	}
+	s.logger.InfoContext(ctx, "purging webhook deliveries", "retention", retention.String())
+
+	n, err := s.repo.PurgeWebhookDeliveries(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("could not purge webhook deliveries on repository: %s", err)
+	}
+	return n, nil
+}
+
+// DispatchWebhookEvent queues a delivery of an event to each enabled webhook
+// subscribed to it. Event ids are derived from the webhook and payload so
+// redelivered events keep their id.
+func (s *Service) DispatchWebhookEvent(ctx context.Context, event string, payload json.RawMessage) error {
+	s.logger.InfoContext(ctx, "dispatching webhook event", "event", event)
+
+	ww, err := s.repo.WebhooksByEvent(ctx, event)
+	if err != nil {
+		return fmt.Errorf("could not find webhooks on repository: %s", err)
+	}
+	if len(ww) == 0 {
+		return nil
+	}
+	now := time.Now()
+	mm := make([]Message, len(ww))
+	for i, w := range ww {
+		e := WebhookEvent{
+			ID:        uuid.NewSHA1(w.ID, append([]byte(event+"/"), payload...)),
+			Type:      event,
+			CreatedAt: now,
+			Data:      payload,
+		}
+		mm[i] = newMessage(TopicWebhookDeliver, WebhookDispatch{WebhookID: w.ID, Event: e})
+	}
+	if err = s.repo.Publish(ctx, mm...); err != nil {
+		return fmt.Errorf("could not publish webhook dispatches on repository: %s", err)
+	}
+	return nil
+}
+
+// DeliverWebhook posts a signed event to a webhook and logs the delivery.
+// Failed posts are retried with backoff up to max attempts unless the webhook
+// gets disabled, deleted and disabled webhooks are skipped.
+func (s *Service) DeliverWebhook(ctx context.Context, d WebhookDispatch) error {
+	s.logger.InfoContext(ctx, "delivering webhook", "webhook_id", d.WebhookID, "event_id", d.Event.ID,
+		"attempt", d.Attempt)
+
+	w, err := s.repo.Webhook(ctx, d.WebhookID)
+	if err != nil {
+		if errors.Is(err, ErrWebhookNotFound) {
+			return nil
+		}
+		return fmt.Errorf("could not find webhook on repository: %s", err)
+	}
+	if w.DisabledAt != nil {
+		s.logger.InfoContext(ctx, "webhook disabled, skipping delivery", "webhook_id", w.ID)
+		return nil
+	}
+
+	body, err := json.Marshal(d.Event)
+	if err != nil {
+		return fmt.Errorf("could not encode webhook event: %s", err)
+	}
+	dl := &WebhookDelivery{
+		ID:        uuid.New(),
+		WebhookID: w.ID,
+		EventID:   d.Event.ID,
+		Event:     d.Event.Type,
+		Attempt:   d.Attempt,
+	}
+	start := time.Now()
+	h := http.Header{}
+	h.Set("Content-Type", "application/json")
+	h.Set(WebhookHeaderEvent, d.Event.Type)
+	h.Set(WebhookHeaderDelivery, dl.ID.String())
+	h.Set(WebhookHeaderTimestamp, fmt.Sprint(start.Unix()))
+	h.Set(WebhookHeaderSignature, SignWebhook(w.Secret, start, body))
+
+	pctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	dl.StatusCode, err = s.webhooks.Post(pctx, w.URL, h, body)
+	cancel()
+	dl.DurationMS = time.Since(start).Milliseconds()
+	if err != nil {
+		dl.Error = err.Error()
+	} else if dl.StatusCode < 200 || dl.StatusCode > 299 {
+		dl.Error = fmt.Sprintf("responded with status %d", dl.StatusCode)
+	}
+
+	if w, err = s.repo.AddWebhookDelivery(ctx, dl, webhookDisableAfter); err != nil {
+		return fmt.Errorf("could not add webhook delivery on repository: %s", err)
+	}
+	if dl.Succeeded() {
+		return nil
+	}
+	if w.DisabledAt != nil {
+		s.logger.WarnContext(ctx, "webhook disabled after consecutive failures", "webhook_id", w.ID,
+			"failures", w.Failures)
+		return nil
+	}
+	if d.Attempt+1 >= webhookMaxAttempts {
+		s.logger.ErrorContext(ctx, "webhook delivery attempts exhausted", "webhook_id", w.ID, "event_id", d.Event.ID)
+		return nil
+	}
+
+	d.Attempt++
+	m := newMessage(TopicWebhookDeliver, d)
+	m.NotBefore = start.Add(webhookRetryDelay << (d.Attempt - 1))
+	if err = s.repo.Publish(ctx, m); err != nil {
+		return fmt.Errorf("could not publish webhook retry on repository: %s", err)
+	}
+	return nil
+}
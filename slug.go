@@ -0,0 +1,48 @@
+package foo
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// defaultSlug is the slug of fighters whose name has no latin letters or digits.
+const defaultSlug = "fighter"
+
+// BaseSlug returns url friendly slug of fighter name, e.g. "José Aldo" as
+// "jose-aldo". Repository suffixes a number when the slug is already taken.
+func (f Fighter) BaseSlug() string {
+	return slugify(f.FirstName + " " + f.LastName)
+}
+
+// slugLetters replaces latin letters that have no decomposed form, the same
+// as unaccent does in the slug backfill of postgres migrations.
+var slugLetters = strings.NewReplacer("ł", "l", "ø", "o", "đ", "d", "ß", "ss", "æ", "ae", "œ", "oe", "þ", "th", "ı", "i")
+
+// slugify lower cases s, removes diacritics and joins runs of letters and
+// digits with a hyphen.
+func slugify(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	s, _, _ = transform.String(t, slugLetters.Replace(strings.ToLower(s)))
+
+	var b strings.Builder
+	hyphen := false
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+			continue
+		}
+		hyphen = true
+	}
+	if b.Len() == 0 {
+		return defaultSlug
+	}
+	return b.String()
+}
@@ -0,0 +1,28 @@
+package foo_test
+
+import (
+	"testing"
+
+	"github.com/kudarap/foo"
+)
+
+func TestFighter_BaseSlug(t *testing.T) {
+	tests := []struct {
+		first, last string
+		want        string
+	}{
+		{"Dave", "Grohl", "dave-grohl"},
+		{"José", "Aldo", "jose-aldo"},
+		{" Conor ", "McGregor-O'Neil", "conor-mcgregor-o-neil"},
+		{"Jan", "Błachowicz", "jan-blachowicz"},
+		{"Хабиб", "Нурмагомедов", "fighter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			f := foo.Fighter{FirstName: tt.first, LastName: tt.last}
+			if got := f.BaseSlug(); got != tt.want {
+				t.Errorf("BaseSlug() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
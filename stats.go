@@ -0,0 +1,111 @@
+package foo
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FighterStats represents fighter career aggregates of completed bouts. They
+// are refreshed by the worker whenever a bout of the fighter or one of its
+// opponents changes.
+type FighterStats struct {
+	FighterID uuid.UUID `json:"fighter_id"`
+	// WinStreak counts consecutive wins up to the latest completed bout, no
+	// contests are skipped.
+	WinStreak int `json:"win_streak"`
+	// FinishRate is the share of wins by KO, TKO or SUB and MethodRates is the
+	// share of wins by each method.
+	FinishRate  float64                `json:"finish_rate"`
+	MethodRates map[BoutMethod]float64 `json:"method_rates"`
+	// AvgFightSeconds is the average fight time of completed bouts.
+	AvgFightSeconds int `json:"avg_fight_seconds"`
+	// BoutsLast12Months counts completed bouts dated within the last 12 months.
+	BoutsLast12Months int `json:"bouts_last_12_months"`
+	// StrengthOfSchedule is the average win rate of opponents across completed bouts.
+	StrengthOfSchedule float64 `json:"strength_of_schedule"`
+	// RecentBoutDates holds completed bout dates within 12 months of the
+	// refresh, activity is counted from them on read as time passes.
+	RecentBoutDates []Date    `json:"-"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// finishMethods lists methods that end a bout before the judges decide.
+var finishMethods = []BoutMethod{BoutMethodKO, BoutMethodTKO, BoutMethodSUB}
+
+// newFighterStats returns stats of a fighter from its bouts ordered latest
+// first and records of its opponents.
+func newFighterStats(fighterID uuid.UUID, bb []Bout, opponents map[uuid.UUID]Record, now time.Time) FighterStats {
+	st := FighterStats{FighterID: fighterID, MethodRates: map[BoutMethod]float64{}}
+	wins := map[BoutMethod]int{}
+	var totalWins, fights, fightSecs, rated int
+	var opponentRates float64
+	streakEnded := false
+	since := now.AddDate(-1, 0, 0)
+	for _, b := range bb {
+		if !b.Completed() {
+			continue
+		}
+		fb := newFighterBout(fighterID, b)
+		switch fb.Outcome {
+		case BoutResultWin:
+			totalWins++
+			wins[b.Method]++
+			if !streakEnded {
+				st.WinStreak++
+			}
+		case BoutResultNoContest:
+		default:
+			streakEnded = true
+		}
+		if b.Round > 0 {
+			fights++
+			fightSecs += (b.Round-1)*roundLengthSecs + b.TimeSeconds
+		}
+		if b.Date.After(since) {
+			st.RecentBoutDates = append(st.RecentBoutDates, b.Date)
+		}
+		if r, ok := opponents[fb.OpponentID]; ok {
+			if decided := r.Wins + r.Losses + r.Draws; decided != 0 {
+				rated++
+				opponentRates += float64(r.Wins) / float64(decided)
+			}
+		}
+	}
+
+	if totalWins != 0 {
+		var finishes int
+		for _, m := range finishMethods {
+			finishes += wins[m]
+		}
+		st.FinishRate = statsRate(float64(finishes) / float64(totalWins))
+		for m, n := range wins {
+			st.MethodRates[m] = statsRate(float64(n) / float64(totalWins))
+		}
+	}
+	if fights != 0 {
+		st.AvgFightSeconds = fightSecs / fights
+	}
+	if rated != 0 {
+		st.StrengthOfSchedule = statsRate(opponentRates / float64(rated))
+	}
+	st.countActivity(now)
+	return st
+}
+
+// countActivity counts recent bouts within 12 months of now.
+func (st *FighterStats) countActivity(now time.Time) {
+	since := now.AddDate(-1, 0, 0)
+	st.BoutsLast12Months = 0
+	for _, d := range st.RecentBoutDates {
+		if d.After(since) {
+			st.BoutsLast12Months++
+		}
+	}
+}
+
+// statsRate rounds a rate to 3 decimal places.
+func statsRate(r float64) float64 {
+	return math.Round(r*1000) / 1000
+}
@@ -0,0 +1,122 @@
+package foo_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+)
+
+func TestService_RefreshFighterStats(t *testing.T) {
+	otherID := uuid.MustParse("7e1d2c3b-4a5f-4e6d-8c7b-9a0f1e2d3c4b")
+	ago := func(months int) foo.Date { return foo.NewDate(time.Now().AddDate(0, -months, 0)) }
+	redBouts := []foo.Bout{
+		{RedFighterID: redID, BlueFighterID: blueID, Date: ago(0)},
+		{RedFighterID: redID, BlueFighterID: blueID, Date: ago(1), Result: foo.BoutResultWin,
+			Method: foo.BoutMethodKO, Round: 1, TimeSeconds: 120},
+		{RedFighterID: otherID, BlueFighterID: redID, Date: ago(6), Result: foo.BoutResultNoContest, Round: 1},
+		{RedFighterID: redID, BlueFighterID: otherID, Date: ago(7), Result: foo.BoutResultWin,
+			Method: foo.BoutMethodSUB, Round: 3, TimeSeconds: 60},
+		{RedFighterID: blueID, BlueFighterID: redID, Date: ago(24), Result: foo.BoutResultWin,
+			Method: foo.BoutMethodDEC, Round: 3, TimeSeconds: 300},
+		{RedFighterID: redID, BlueFighterID: otherID, Date: ago(36), Result: foo.BoutResultWin,
+			Method: foo.BoutMethodDEC, Round: 3, TimeSeconds: 300},
+	}
+	bouts := map[uuid.UUID][]foo.Bout{redID: redBouts}
+	for _, b := range redBouts {
+		o := b.Opponent(redID)
+		bouts[o] = append(bouts[o], b)
+	}
+	records := map[uuid.UUID]foo.Record{
+		blueID:  {Wins: 3, Losses: 1},
+		otherID: {Wins: 1, Losses: 3},
+	}
+
+	got := map[uuid.UUID]foo.FighterStats{}
+	repo := &mockFighterRepo{
+		FighterBoutsFn: func(ctx context.Context, fighterID uuid.UUID) ([]foo.Bout, error) {
+			return bouts[fighterID], nil
+		},
+		FightersByIDFn: func(ctx context.Context, ids []uuid.UUID) ([]foo.Fighter, error) {
+			var ff []foo.Fighter
+			for _, id := range ids {
+				ff = append(ff, foo.Fighter{ID: id, Record: records[id]})
+			}
+			return ff, nil
+		},
+		UpdateFighterStatsFn: func(ctx context.Context, st *foo.FighterStats) error {
+			got[st.FighterID] = *st
+			return nil
+		},
+	}
+	l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := foo.NewService(repo, nil, nil, nil, l)
+	if err := svc.RefreshFighterStats(context.Background(), []uuid.UUID{redID}); err != nil {
+		t.Fatalf("RefreshFighterStats() unexpected error %s", err)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("RefreshFighterStats() updated %d fighters, want fighter and its 2 opponents", len(got))
+	}
+	st := got[redID]
+	want := foo.FighterStats{
+		FighterID:  redID,
+		WinStreak:  2,
+		FinishRate: 0.667,
+		MethodRates: map[foo.BoutMethod]float64{
+			foo.BoutMethodKO: 0.333, foo.BoutMethodSUB: 0.333, foo.BoutMethodDEC: 0.333,
+		},
+		AvgFightSeconds:    (120 + 0 + 660 + 900 + 900) / 5,
+		BoutsLast12Months:  3,
+		StrengthOfSchedule: 0.45,
+		RecentBoutDates:    []foo.Date{ago(1), ago(6), ago(7)},
+	}
+	if !reflect.DeepEqual(st, want) {
+		t.Errorf("RefreshFighterStats() stats =\n%+v, want\n%+v", st, want)
+	}
+}
+
+func TestService_FighterStats(t *testing.T) {
+	stored := &foo.FighterStats{
+		FighterID:       redID,
+		MethodRates:     map[foo.BoutMethod]float64{},
+		RecentBoutDates: []foo.Date{foo.NewDate(time.Now().AddDate(0, -2, 0)), foo.NewDate(time.Now().AddDate(0, -13, 0))},
+	}
+	tests := []struct {
+		name     string
+		stats    *foo.FighterStats
+		wantRecs int
+	}{
+		{"refreshed", stored, 1},
+		{"not refreshed", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockFighterRepo{
+				FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+					return &foo.Fighter{ID: id}, nil
+				},
+				FighterStatsFn: func(ctx context.Context, fighterID uuid.UUID) (*foo.FighterStats, error) {
+					if tt.stats == nil {
+						return nil, foo.ErrFighterNotFound
+					}
+					return tt.stats, nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			got, err := svc.FighterStats(context.Background(), redID.String())
+			if err != nil {
+				t.Fatalf("FighterStats() unexpected error %s", err)
+			}
+			if got.FighterID != redID || got.BoutsLast12Months != tt.wantRecs || got.MethodRates == nil {
+				t.Errorf("FighterStats() = %+v, want %d bouts in the last 12 months", got, tt.wantRecs)
+			}
+		})
+	}
+}
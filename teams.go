@@ -0,0 +1,157 @@
+package foo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+var (
+	ErrTeamNotFound        = xerror.Error(xerror.CodeNotFound)
+	ErrTeamInvalid         = xerror.Error(xerror.CodeInvalid)
+	ErrAffiliationNotFound = xerror.Error(xerror.CodeNotFound)
+	ErrAffiliationInvalid  = xerror.Error(xerror.CodeInvalid)
+)
+
+// Team represents a gym or fight camp fighters train under.
+type Team struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Location  string    `json:"location"`
+	HeadCoach string    `json:"head_coach"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// normalize trims team details.
+func (t *Team) normalize() {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Location = strings.TrimSpace(t.Location)
+	t.HeadCoach = strings.TrimSpace(t.HeadCoach)
+}
+
+// Validate checks team fields and returns all field errors found.
+func (t Team) Validate() error {
+	var fe xerror.ValidationError
+	if t.Name == "" {
+		fe = fe.Add("name", xerror.ViolationRequired, "is required")
+	} else if len(t.Name) > maxNameLength {
+		fe = fe.Add("name", xerror.ViolationTooLong, fmt.Sprintf("must not exceed %d characters", maxNameLength))
+	}
+	if len(t.Location) > maxNameLength {
+		fe = fe.Add("location", xerror.ViolationTooLong, fmt.Sprintf("must not exceed %d characters", maxNameLength))
+	}
+	if len(t.HeadCoach) > maxNameLength {
+		fe = fe.Add("head_coach", xerror.ViolationTooLong, fmt.Sprintf("must not exceed %d characters", maxNameLength))
+	}
+
+	if len(fe) != 0 {
+		return ErrTeamInvalid.X(fe)
+	}
+	return nil
+}
+
+// TeamQuery represents team list options.
+type TeamQuery struct {
+	Limit int
+}
+
+func (q TeamQuery) setDefaults() TeamQuery {
+	if q.Limit <= 0 {
+		q.Limit = defaultListLimit
+	}
+	if q.Limit > maxListLimit {
+		q.Limit = maxListLimit
+	}
+	return q
+}
+
+// Affiliation represents a fighter membership period of a team, open ended
+// while the fighter is still with the team.
+type Affiliation struct {
+	ID        uuid.UUID `json:"id"`
+	FighterID uuid.UUID `json:"fighter_id"`
+	TeamID    uuid.UUID `json:"team_id"`
+	StartDate Date      `json:"start_date"`
+	EndDate   *Date     `json:"end_date"`
+	// Fighter is populated on team member lists.
+	Fighter *Fighter `json:"fighter,omitempty"`
+	// Team is populated on fighter affiliation lists.
+	Team      *Team     `json:"team,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Current reports whether the fighter is still with the team, the end date is
+// the last day with the team and may be set ahead of time.
+func (a Affiliation) Current() bool {
+	return a.EndDate == nil || !a.EndDate.Before(NewDate(time.Now()).Time)
+}
+
+// overlaps reports whether both affiliation periods share a day, end dates are
+// the last day with the team.
+func (a Affiliation) overlaps(b Affiliation) bool {
+	startsBefore := a.EndDate == nil || !a.EndDate.Before(b.StartDate.Time)
+	endsAfter := b.EndDate == nil || !b.EndDate.Before(a.StartDate.Time)
+	return startsBefore && endsAfter
+}
+
+// Validate checks affiliation fields and returns all field errors found.
+func (a Affiliation) Validate() error {
+	var fe xerror.ValidationError
+	if a.TeamID == uuid.Nil {
+		fe = fe.Add("team_id", xerror.ViolationRequired, "is required")
+	}
+	if a.StartDate.IsZero() {
+		fe = fe.Add("start_date", xerror.ViolationRequired, "is required")
+	}
+	if a.EndDate != nil && !a.StartDate.IsZero() && a.EndDate.Before(a.StartDate.Time) {
+		fe = fe.Add("end_date", xerror.ViolationOutOfRange, "must not be before start_date")
+	}
+
+	if len(fe) != 0 {
+		return ErrAffiliationInvalid.X(fe)
+	}
+	return nil
+}
+
+// checkHistory checks affiliation does not overlap another period of the fighter
+// with the same team.
+func (a Affiliation) checkHistory(history []Affiliation) error {
+	for _, h := range history {
+		if h.ID == a.ID || h.TeamID != a.TeamID || !a.overlaps(h) {
+			continue
+		}
+		return ErrAffiliationInvalid.X(xerror.NewValidationError("start_date", xerror.ViolationConflict,
+			fmt.Sprintf("overlaps affiliation %s with the team", h.ID)))
+	}
+	return nil
+}
+
+// TeamMembers represents fighters of a team split by current membership.
+type TeamMembers struct {
+	Current []Affiliation `json:"current"`
+	Former  []Affiliation `json:"former"`
+}
+
+// newTeamMembers splits team affiliations into current and former members,
+// fighters who left and rejoined are only listed as current.
+func newTeamMembers(aa []Affiliation) *TeamMembers {
+	m := &TeamMembers{Current: []Affiliation{}, Former: []Affiliation{}}
+	current := map[uuid.UUID]bool{}
+	for _, a := range aa {
+		if a.Current() {
+			current[a.FighterID] = true
+			m.Current = append(m.Current, a)
+		}
+	}
+	for _, a := range aa {
+		if !a.Current() && !current[a.FighterID] {
+			m.Former = append(m.Former, a)
+		}
+	}
+	return m
+}
@@ -0,0 +1,225 @@
+package foo_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
+)
+
+func TestService_CreateTeam(t *testing.T) {
+	repo := &mockFighterRepo{
+		CreateTeamFn: func(ctx context.Context, t *foo.Team) error {
+			t.ID = uuid.New()
+			return nil
+		},
+	}
+	l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := foo.NewService(repo, nil, nil, nil, l)
+
+	got, err := svc.CreateTeam(context.Background(), &foo.Team{Name: " American Top Team ", HeadCoach: "Mike Brown"})
+	if err != nil {
+		t.Fatalf("CreateTeam() unexpected error %s", err)
+	}
+	if got.ID == uuid.Nil || got.Name != "American Top Team" {
+		t.Errorf("CreateTeam() got = %+v", got)
+	}
+
+	var ve xerror.ValidationError
+	if _, err = svc.CreateTeam(context.Background(), &foo.Team{Name: "  "}); !errors.As(err, &ve) || ve[0].Field != "name" {
+		t.Errorf("CreateTeam() error = %v, want name validation error", err)
+	}
+}
+
+func TestService_CreateAffiliation(t *testing.T) {
+	teamID := uuid.MustParse("3b1f6c2a-4d5e-4f70-8a9b-0c1d2e3f4a5b")
+	otherTeamID := uuid.MustParse("7e8f9a0b-1c2d-4e3f-9a5b-6c7d8e9f0a1b")
+	racedTeamID := uuid.New()
+	date := func(s string) *foo.Date {
+		tt, _ := time.Parse("2006-01-02", s)
+		d := foo.NewDate(tt)
+		return &d
+	}
+	history := []foo.Affiliation{
+		{ID: uuid.New(), FighterID: redID, TeamID: teamID, StartDate: *date("2015-01-01"), EndDate: date("2018-06-30")},
+		{ID: uuid.New(), FighterID: redID, TeamID: otherTeamID, StartDate: *date("2018-07-01")},
+	}
+	tests := []struct {
+		name     string
+		aff      foo.Affiliation
+		wantCode string
+		wantErr  string
+	}{
+		{"rejoined", foo.Affiliation{TeamID: teamID, StartDate: *date("2020-01-01")}, "", ""},
+		{"other team overlap", foo.Affiliation{TeamID: uuid.New(), StartDate: *date("2019-01-01")}, "", ""},
+		{"same team overlap", foo.Affiliation{TeamID: teamID, StartDate: *date("2018-01-01"), EndDate: date("2019-01-01")}, xerror.CodeInvalid, "start_date"},
+		{"starts on end date", foo.Affiliation{TeamID: teamID, StartDate: *date("2018-06-30")}, xerror.CodeInvalid, "start_date"},
+		{"ends before start", foo.Affiliation{TeamID: teamID, StartDate: *date("2020-01-01"), EndDate: date("2019-01-01")}, xerror.CodeInvalid, "end_date"},
+		{"missing team", foo.Affiliation{StartDate: *date("2020-01-01")}, xerror.CodeInvalid, "team_id"},
+		{"unknown team", foo.Affiliation{TeamID: blueID, StartDate: *date("2020-01-01")}, xerror.CodeNotFound, ""},
+		{"concurrent overlap", foo.Affiliation{TeamID: racedTeamID, StartDate: *date("2020-01-01")}, xerror.CodeInvalid, "start_date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *foo.Affiliation
+			repo := &mockFighterRepo{
+				FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+					return &foo.Fighter{ID: id}, nil
+				},
+				TeamFn: func(ctx context.Context, id uuid.UUID) (*foo.Team, error) {
+					if id == blueID {
+						return nil, foo.ErrTeamNotFound
+					}
+					return &foo.Team{ID: id, Name: "Team"}, nil
+				},
+				FighterAffiliationsFn: func(ctx context.Context, fighterID uuid.UUID) ([]foo.Affiliation, error) {
+					return history, nil
+				},
+				CreateAffiliationFn: func(ctx context.Context, a *foo.Affiliation) error {
+					if a.TeamID == racedTeamID {
+						return foo.ErrAffiliationInvalid
+					}
+					a.ID = uuid.New()
+					created = a
+					return nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			aff := tt.aff
+			got, err := svc.CreateAffiliation(context.Background(), redID.String(), &aff)
+			var xerr xerror.XError
+			errors.As(err, &xerr)
+			if (err != nil) != (tt.wantCode != "") || xerr.Code != tt.wantCode {
+				t.Fatalf("CreateAffiliation() error = %v, wantCode %q", err, tt.wantCode)
+			}
+			var ve xerror.ValidationError
+			if tt.wantErr != "" && (!errors.As(err, &ve) || ve[0].Field != tt.wantErr) {
+				t.Fatalf("CreateAffiliation() error = %v, want %s violation", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if created == nil || got.FighterID != redID || got.Team == nil || got.Team.ID != tt.aff.TeamID {
+				t.Errorf("CreateAffiliation() got = %+v", got)
+			}
+		})
+	}
+}
+
+func TestService_UpdateAffiliation_NotFound(t *testing.T) {
+	repo := &mockFighterRepo{
+		FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+			return &foo.Fighter{ID: id}, nil
+		},
+		TeamFn: func(ctx context.Context, id uuid.UUID) (*foo.Team, error) {
+			return &foo.Team{ID: id}, nil
+		},
+		FighterAffiliationsFn: func(ctx context.Context, fighterID uuid.UUID) ([]foo.Affiliation, error) {
+			return nil, nil
+		},
+	}
+	l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := foo.NewService(repo, nil, nil, nil, l)
+
+	a := &foo.Affiliation{TeamID: uuid.New(), StartDate: foo.NewDate(time.Now())}
+	_, err := svc.UpdateAffiliation(context.Background(), redID.String(), uuid.NewString(), a)
+	var xerr xerror.XError
+	if !errors.As(err, &xerr) || xerr.Code != xerror.CodeNotFound {
+		t.Errorf("UpdateAffiliation() error = %v, want not found", err)
+	}
+}
+
+func TestService_DeleteAffiliation(t *testing.T) {
+	affID := uuid.New()
+	tests := []struct {
+		name     string
+		fighter  string
+		id       string
+		wantCode string
+	}{
+		{"by id", redID.String(), affID.String(), ""},
+		{"by slug", "dave-grohl", affID.String(), ""},
+		{"unknown fighter", "nobody", affID.String(), xerror.CodeNotFound},
+		{"unknown affiliation", redID.String(), uuid.NewString(), xerror.CodeNotFound},
+		{"malformed id", redID.String(), "1", xerror.CodeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockFighterRepo{
+				FighterFn: func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*foo.Fighter, error) {
+					return &foo.Fighter{ID: id}, nil
+				},
+				FighterBySlugFn: func(ctx context.Context, slug string) (*foo.Fighter, error) {
+					if slug != "dave-grohl" {
+						return nil, foo.ErrFighterNotFound
+					}
+					return &foo.Fighter{ID: redID}, nil
+				},
+				DeleteAffiliationFn: func(ctx context.Context, fighterID, id uuid.UUID) error {
+					if fighterID != redID || id != affID {
+						return foo.ErrAffiliationNotFound
+					}
+					return nil
+				},
+			}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			err := svc.DeleteAffiliation(context.Background(), tt.fighter, tt.id)
+			var xerr xerror.XError
+			errors.As(err, &xerr)
+			if (err != nil) != (tt.wantCode != "") || xerr.Code != tt.wantCode {
+				t.Errorf("DeleteAffiliation() error = %v, wantCode %q", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestService_TeamMembers(t *testing.T) {
+	teamID := uuid.New()
+	thirdID := uuid.New()
+	leavingID := uuid.New()
+	end := foo.NewDate(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	leaving := foo.NewDate(time.Now().AddDate(0, 1, 0))
+	// Latest joined first, red left and rejoined the team.
+	aa := []foo.Affiliation{
+		{ID: uuid.New(), FighterID: redID, TeamID: teamID},
+		{ID: uuid.New(), FighterID: blueID, TeamID: teamID},
+		{ID: uuid.New(), FighterID: leavingID, TeamID: teamID, EndDate: &leaving},
+		{ID: uuid.New(), FighterID: thirdID, TeamID: teamID, EndDate: &end},
+		{ID: uuid.New(), FighterID: redID, TeamID: teamID, EndDate: &end},
+	}
+	repo := &mockFighterRepo{
+		TeamFn: func(ctx context.Context, id uuid.UUID) (*foo.Team, error) {
+			if id != teamID {
+				return nil, foo.ErrTeamNotFound
+			}
+			return &foo.Team{ID: id}, nil
+		},
+		TeamAffiliationsFn: func(ctx context.Context, id uuid.UUID) ([]foo.Affiliation, error) {
+			return aa, nil
+		},
+	}
+	l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := foo.NewService(repo, nil, nil, nil, l)
+
+	got, err := svc.TeamMembers(context.Background(), teamID.String())
+	if err != nil {
+		t.Fatalf("TeamMembers() unexpected error %s", err)
+	}
+	want := &foo.TeamMembers{Current: aa[:3], Former: aa[3:4]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TeamMembers() got = %+v, want %+v", got, want)
+	}
+
+	if _, err = svc.TeamMembers(context.Background(), uuid.NewString()); !errors.Is(err, foo.ErrTeamNotFound) {
+		t.Errorf("TeamMembers() error = %v, want not found", err)
+	}
+}
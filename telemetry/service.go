@@ -2,6 +2,7 @@ package telemetry
 
 import (
 	"context"
+	"time"
 
 	"github.com/kudarap/foo"
 	"go.opentelemetry.io/otel"
@@ -29,6 +30,206 @@ func (s *FooService) FighterByID(ctx context.Context, id string) (*foo.Fighter,
 	return f, nil
 }
 
+func (s *FooService) Fighters(ctx context.Context, q foo.FighterQuery) (*foo.FighterPage, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.Fighters")
+	defer span.End()
+	span.SetAttributes(jsonAttribute("query", q))
+
+	p, err := s.Service.Fighters(ctx, q)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (s *FooService) SearchFighters(ctx context.Context, q string, limit int) ([]foo.FighterSearchResult, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.SearchFighters")
+	defer span.End()
+	span.SetAttributes(attribute.String("q", q), attribute.Int("limit", limit))
+
+	rr, err := s.Service.SearchFighters(ctx, q, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return rr, nil
+}
+
+func (s *FooService) ExportFighters(ctx context.Context, q foo.FighterQuery, fn func(foo.Fighter) error) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.ExportFighters")
+	defer span.End()
+	span.SetAttributes(jsonAttribute("query", q))
+
+	var n int
+	err := s.Service.ExportFighters(ctx, q, func(f foo.Fighter) error {
+		n++
+		return fn(f)
+	})
+	span.SetAttributes(attribute.Int("exported", n))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *FooService) CreateFighter(ctx context.Context, f *foo.Fighter) (*foo.Fighter, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.CreateFighter")
+	defer span.End()
+	span.SetAttributes(jsonAttribute("fighter", f))
+
+	f, err := s.Service.CreateFighter(ctx, f)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (s *FooService) UpdateFighter(ctx context.Context, id string, f *foo.Fighter) (*foo.Fighter, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.UpdateFighter")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id), jsonAttribute("fighter", f))
+
+	f, err := s.Service.UpdateFighter(ctx, id, f)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (s *FooService) DeleteFighter(ctx context.Context, id string, version int) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.DeleteFighter")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id), attribute.Int("version", version))
+
+	if err := s.Service.DeleteFighter(ctx, id, version); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *FooService) RestoreFighter(ctx context.Context, id string) (*foo.Fighter, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.RestoreFighter")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	f, err := s.Service.RestoreFighter(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (s *FooService) PurgeFighters(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.PurgeFighters")
+	defer span.End()
+	span.SetAttributes(attribute.String("retention", retention.String()))
+
+	n, err := s.Service.PurgeFighters(ctx, retention)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("purged", n))
+	return n, nil
+}
+
+func (s *FooService) FighterHistory(ctx context.Context, id string) ([]foo.FighterChange, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.FighterHistory")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	hh, err := s.Service.FighterHistory(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return hh, nil
+}
+
+func (s *FooService) FighterDuplicates(ctx context.Context, limit int) ([]foo.FighterDuplicate, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.FighterDuplicates")
+	defer span.End()
+	span.SetAttributes(attribute.Int("limit", limit))
+
+	dd, err := s.Service.FighterDuplicates(ctx, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return dd, nil
+}
+
+func (s *FooService) MergeFighter(ctx context.Context, id string, m foo.FighterMerge) (*foo.Fighter, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.MergeFighter")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id), attribute.String("duplicate_id", m.DuplicateID.String()))
+
+	f, err := s.Service.MergeFighter(ctx, id, m)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (s *FooService) FighterAsOf(ctx context.Context, id string, at time.Time) (*foo.Fighter, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.FighterAsOf")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id), attribute.String("as_of", at.Format(time.RFC3339)))
+
+	f, err := s.Service.FighterAsOf(ctx, id, at)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (s *FooService) CompareFighters(ctx context.Context, a, b string) (*foo.FighterComparison, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.CompareFighters")
+	defer span.End()
+	span.SetAttributes(attribute.String("a", a), attribute.String("b", b))
+
+	c, err := s.Service.CompareFighters(ctx, a, b)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return c, nil
+}
+
 func TraceFooService(s *foo.Service) *FooService {
 	return &FooService{s, "foo-service"}
 }
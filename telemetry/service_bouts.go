@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/kudarap/foo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (s *FooService) BoutByID(ctx context.Context, id string) (*foo.Bout, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.BoutByID")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	b, err := s.Service.BoutByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (s *FooService) FighterBouts(ctx context.Context, fighterID string) ([]foo.FighterBout, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.FighterBouts")
+	defer span.End()
+	span.SetAttributes(attribute.String("fighter_id", fighterID))
+
+	bb, err := s.Service.FighterBouts(ctx, fighterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return bb, nil
+}
+
+func (s *FooService) CreateBout(ctx context.Context, b *foo.Bout) (*foo.Bout, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.CreateBout")
+	defer span.End()
+	span.SetAttributes(jsonAttribute("bout", b))
+
+	b, err := s.Service.CreateBout(ctx, b)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (s *FooService) UpdateBout(ctx context.Context, id string, b *foo.Bout) (*foo.Bout, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.UpdateBout")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id), jsonAttribute("bout", b))
+
+	b, err := s.Service.UpdateBout(ctx, id, b)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (s *FooService) DeleteBout(ctx context.Context, id string) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.DeleteBout")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	if err := s.Service.DeleteBout(ctx, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
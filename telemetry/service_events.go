@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/kudarap/foo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (s *FooService) EventByID(ctx context.Context, id string) (*foo.Event, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.EventByID")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	e, err := s.Service.EventByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (s *FooService) Events(ctx context.Context, q foo.EventQuery) ([]foo.Event, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.Events")
+	defer span.End()
+	span.SetAttributes(jsonAttribute("query", q))
+
+	ee, err := s.Service.Events(ctx, q)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return ee, nil
+}
+
+func (s *FooService) CreateEvent(ctx context.Context, e *foo.Event) (*foo.Event, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.CreateEvent")
+	defer span.End()
+	span.SetAttributes(jsonAttribute("event", e))
+
+	e, err := s.Service.CreateEvent(ctx, e)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (s *FooService) UpdateEvent(ctx context.Context, id string, e *foo.Event) (*foo.Event, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.UpdateEvent")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id), jsonAttribute("event", e))
+
+	e, err := s.Service.UpdateEvent(ctx, id, e)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (s *FooService) DeleteEvent(ctx context.Context, id string) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.DeleteEvent")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	if err := s.Service.DeleteEvent(ctx, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
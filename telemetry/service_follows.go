@@ -0,0 +1,54 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/kudarap/foo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (s *FooService) FollowFighter(ctx context.Context, fighterID string) (*foo.Follow, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.FollowFighter")
+	defer span.End()
+	span.SetAttributes(attribute.String("fighter_id", fighterID))
+
+	f, err := s.Service.FollowFighter(ctx, fighterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (s *FooService) UnfollowFighter(ctx context.Context, fighterID string) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.UnfollowFighter")
+	defer span.End()
+	span.SetAttributes(attribute.String("fighter_id", fighterID))
+
+	if err := s.Service.UnfollowFighter(ctx, fighterID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *FooService) Follows(ctx context.Context) ([]foo.Follow, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.Follows")
+	defer span.End()
+
+	ff, err := s.Service.Follows(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(ff)))
+	return ff, nil
+}
@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (s *FooService) CreateFighterImport(ctx context.Context, format foo.FighterImportFormat, data []byte) (*foo.FighterImport, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.CreateFighterImport")
+	defer span.End()
+	span.SetAttributes(attribute.String("format", string(format)), attribute.Int("size", len(data)))
+
+	imp, err := s.Service.CreateFighterImport(ctx, format, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return imp, nil
+}
+
+func (s *FooService) FighterImportByID(ctx context.Context, id string) (*foo.FighterImport, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.FighterImportByID")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	imp, err := s.Service.FighterImportByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return imp, nil
+}
+
+func (s *FooService) ProcessFighterImport(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.ProcessFighterImport")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id.String()))
+
+	if err := s.Service.ProcessFighterImport(ctx, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *FooService) FailFighterImport(ctx context.Context, id uuid.UUID, reason string) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.FailFighterImport")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id.String()))
+
+	if err := s.Service.FailFighterImport(ctx, id, reason); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (s *FooService) CreateFighterMedia(ctx context.Context, fighterID string, kind foo.MediaKind, filename string, r io.Reader) (*foo.Media, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.CreateFighterMedia")
+	defer span.End()
+	span.SetAttributes(attribute.String("fighter_id", fighterID), attribute.String("kind", string(kind)))
+
+	m, err := s.Service.CreateFighterMedia(ctx, fighterID, kind, filename, r)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("id", m.ID.String()), attribute.Int64("size", m.Size))
+	return m, nil
+}
+
+func (s *FooService) FighterMedia(ctx context.Context, fighterID string) ([]foo.Media, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.FighterMedia")
+	defer span.End()
+	span.SetAttributes(attribute.String("fighter_id", fighterID))
+
+	mm, err := s.Service.FighterMedia(ctx, fighterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return mm, nil
+}
+
+func (s *FooService) GenerateMediaThumbnail(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.GenerateMediaThumbnail")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id.String()))
+
+	if err := s.Service.GenerateMediaThumbnail(ctx, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
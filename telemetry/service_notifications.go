@@ -0,0 +1,96 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/kudarap/foo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (s *FooService) NotifyBoutFollowers(ctx context.Context, bn foo.BoutNotice) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.NotifyBoutFollowers")
+	defer span.End()
+	span.SetAttributes(attribute.String("bout_id", bn.BoutID.String()), attribute.String("kind", string(bn.Kind)))
+
+	if err := s.Service.NotifyBoutFollowers(ctx, bn); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *FooService) DeliverNotification(ctx context.Context, d foo.NotificationDelivery) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.DeliverNotification")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", d.Notification.ID.String()), attribute.Int("attempt", d.Attempt))
+
+	if err := s.Service.DeliverNotification(ctx, d); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *FooService) NotificationPreferences(ctx context.Context) (*foo.NotificationPreferences, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.NotificationPreferences")
+	defer span.End()
+
+	p, err := s.Service.NotificationPreferences(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (s *FooService) UpdateNotificationPreferences(ctx context.Context, p *foo.NotificationPreferences) (*foo.NotificationPreferences, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.UpdateNotificationPreferences")
+	defer span.End()
+
+	p, err := s.Service.UpdateNotificationPreferences(ctx, p)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (s *FooService) Notifications(ctx context.Context, limit int) ([]foo.Notification, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.Notifications")
+	defer span.End()
+	span.SetAttributes(attribute.Int("limit", limit))
+
+	nn, err := s.Service.Notifications(ctx, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(nn)))
+	return nn, nil
+}
+
+func (s *FooService) ReadNotification(ctx context.Context, id string) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.ReadNotification")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	if err := s.Service.ReadNotification(ctx, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
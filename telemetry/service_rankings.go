@@ -0,0 +1,54 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/kudarap/foo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (s *FooService) RecomputeRankings(ctx context.Context, wc foo.WeightClass) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.RecomputeRankings")
+	defer span.End()
+	span.SetAttributes(attribute.String("weight_class", string(wc)))
+
+	if err := s.Service.RecomputeRankings(ctx, wc); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *FooService) Rankings(ctx context.Context, weightClass string, limit int) ([]foo.Rating, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.Rankings")
+	defer span.End()
+	span.SetAttributes(attribute.String("weight_class", weightClass), attribute.Int("limit", limit))
+
+	rr, err := s.Service.Rankings(ctx, weightClass, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return rr, nil
+}
+
+func (s *FooService) FighterRatingHistory(ctx context.Context, fighterID string) ([]foo.RatingChange, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.FighterRatingHistory")
+	defer span.End()
+	span.SetAttributes(attribute.String("fighter_id", fighterID))
+
+	hh, err := s.Service.FighterRatingHistory(ctx, fighterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return hh, nil
+}
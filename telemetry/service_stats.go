@@ -0,0 +1,40 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (s *FooService) FighterStats(ctx context.Context, fighterID string) (*foo.FighterStats, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.FighterStats")
+	defer span.End()
+	span.SetAttributes(attribute.String("fighter_id", fighterID))
+
+	st, err := s.Service.FighterStats(ctx, fighterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return st, nil
+}
+
+func (s *FooService) RefreshFighterStats(ctx context.Context, fighterIDs []uuid.UUID) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.RefreshFighterStats")
+	defer span.End()
+	span.SetAttributes(attribute.Int("fighters", len(fighterIDs)))
+
+	if err := s.Service.RefreshFighterStats(ctx, fighterIDs); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
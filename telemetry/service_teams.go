@@ -0,0 +1,158 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/kudarap/foo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (s *FooService) TeamByID(ctx context.Context, id string) (*foo.Team, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.TeamByID")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	t, err := s.Service.TeamByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *FooService) Teams(ctx context.Context, q foo.TeamQuery) ([]foo.Team, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.Teams")
+	defer span.End()
+	span.SetAttributes(jsonAttribute("query", q))
+
+	tt, err := s.Service.Teams(ctx, q)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return tt, nil
+}
+
+func (s *FooService) CreateTeam(ctx context.Context, t *foo.Team) (*foo.Team, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.CreateTeam")
+	defer span.End()
+	span.SetAttributes(jsonAttribute("team", t))
+
+	t, err := s.Service.CreateTeam(ctx, t)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *FooService) UpdateTeam(ctx context.Context, id string, t *foo.Team) (*foo.Team, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.UpdateTeam")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id), jsonAttribute("team", t))
+
+	t, err := s.Service.UpdateTeam(ctx, id, t)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *FooService) DeleteTeam(ctx context.Context, id string) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.DeleteTeam")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	if err := s.Service.DeleteTeam(ctx, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *FooService) TeamMembers(ctx context.Context, id string) (*foo.TeamMembers, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.TeamMembers")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	m, err := s.Service.TeamMembers(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (s *FooService) FighterAffiliations(ctx context.Context, fighterID string) ([]foo.Affiliation, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.FighterAffiliations")
+	defer span.End()
+	span.SetAttributes(attribute.String("fighter_id", fighterID))
+
+	aa, err := s.Service.FighterAffiliations(ctx, fighterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return aa, nil
+}
+
+func (s *FooService) CreateAffiliation(ctx context.Context, fighterID string, a *foo.Affiliation) (*foo.Affiliation, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.CreateAffiliation")
+	defer span.End()
+	span.SetAttributes(attribute.String("fighter_id", fighterID), jsonAttribute("affiliation", a))
+
+	a, err := s.Service.CreateAffiliation(ctx, fighterID, a)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (s *FooService) UpdateAffiliation(ctx context.Context, fighterID, id string, a *foo.Affiliation) (*foo.Affiliation, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.UpdateAffiliation")
+	defer span.End()
+	span.SetAttributes(attribute.String("fighter_id", fighterID), attribute.String("id", id), jsonAttribute("affiliation", a))
+
+	a, err := s.Service.UpdateAffiliation(ctx, fighterID, id, a)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (s *FooService) DeleteAffiliation(ctx context.Context, fighterID, id string) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.DeleteAffiliation")
+	defer span.End()
+	span.SetAttributes(attribute.String("fighter_id", fighterID), attribute.String("id", id))
+
+	if err := s.Service.DeleteAffiliation(ctx, fighterID, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/kudarap/foo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (s *FooService) CreateWebhook(ctx context.Context, w *foo.Webhook) (*foo.Webhook, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.CreateWebhook")
+	defer span.End()
+
+	w, err := s.Service.CreateWebhook(ctx, w)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("id", w.ID.String()))
+	return w, nil
+}
+
+func (s *FooService) Webhooks(ctx context.Context) ([]foo.Webhook, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.Webhooks")
+	defer span.End()
+
+	ww, err := s.Service.Webhooks(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(ww)))
+	return ww, nil
+}
+
+func (s *FooService) DeleteWebhook(ctx context.Context, id string) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.DeleteWebhook")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	if err := s.Service.DeleteWebhook(ctx, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *FooService) EnableWebhook(ctx context.Context, id string) (*foo.Webhook, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.EnableWebhook")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id))
+
+	w, err := s.Service.EnableWebhook(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (s *FooService) WebhookDeliveries(ctx context.Context, id string, limit int) ([]foo.WebhookDelivery, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.WebhookDeliveries")
+	defer span.End()
+	span.SetAttributes(attribute.String("id", id), attribute.Int("limit", limit))
+
+	dd, err := s.Service.WebhookDeliveries(ctx, id, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(dd)))
+	return dd, nil
+}
+
+func (s *FooService) PurgeWebhookDeliveries(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.PurgeWebhookDeliveries")
+	defer span.End()
+	span.SetAttributes(attribute.String("retention", retention.String()))
+
+	n, err := s.Service.PurgeWebhookDeliveries(ctx, retention)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("purged", n))
+	return n, nil
+}
+
+func (s *FooService) DispatchWebhookEvent(ctx context.Context, event string, payload json.RawMessage) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.DispatchWebhookEvent")
+	defer span.End()
+	span.SetAttributes(attribute.String("event", event))
+
+	if err := s.Service.DispatchWebhookEvent(ctx, event, payload); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *FooService) DeliverWebhook(ctx context.Context, d foo.WebhookDispatch) error {
+	ctx, span := otel.Tracer(s.tracerName).Start(ctx, "fooservice.DeliverWebhook")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("webhook_id", d.WebhookID.String()),
+		attribute.String("event_id", d.Event.ID.String()),
+		attribute.Int("attempt", d.Attempt),
+	)
+
+	if err := s.Service.DeliverWebhook(ctx, d); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
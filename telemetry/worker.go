@@ -16,6 +16,7 @@ func TraceWorker(next worker.JobHandler) worker.JobHandler {
 		defer span.End()
 		span.SetAttributes(
 			attribute.String("topic", job.Topic),
+			attribute.String("tenant", job.Tenant),
 			attribute.String("payload", string(job.Payload)),
 		)
 
@@ -0,0 +1,26 @@
+package foo
+
+import "context"
+
+// DefaultTenant is the tenant of requests without a tenant claim and of data
+// created before tenants were introduced.
+const DefaultTenant = "default"
+
+// Key to use when setting the tenant.
+type ctxKeyTenant int
+
+// tenantKey is the key that holds the tenant id in a context.
+const tenantKey ctxKeyTenant = iota
+
+// ContextWithTenant sets tenant id to context. Repository reads and writes are
+// scoped to the context tenant.
+func ContextWithTenant(parent context.Context, tenantID string) context.Context {
+	return context.WithValue(parent, tenantKey, tenantID)
+}
+
+// TenantFromContext returns tenant id from the context if one is present.
+// Contexts without a tenant are system jobs that work across tenants.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	t, ok := ctx.Value(tenantKey).(string)
+	return t, ok && t != ""
+}
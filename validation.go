@@ -0,0 +1,49 @@
+package foo
+
+import (
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+// parseID parses a uuid input field and reports a malformed one as field
+// violation instead of the raw parse error.
+func parseID(field, s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, xerror.NewValidationError(field, xerror.ViolationMalformed, "must be a uuid")
+	}
+	return id, nil
+}
+
+// httpURL reports whether s is an absolute http or https url.
+func httpURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
+
+// httpsURL reports whether s is an absolute https url.
+func httpsURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
+
+// publicHost reports whether host of url s is neither a local name nor a
+// non-public ip address. Other names are checked on connect once resolved.
+func publicHost(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return PublicIP(ip)
+	}
+	return true
+}
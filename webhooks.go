@@ -0,0 +1,160 @@
+package foo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo/xerror"
+)
+
+var (
+	ErrWebhookNotFound = xerror.Error(xerror.CodeNotFound)
+	ErrWebhookInvalid  = xerror.Error(xerror.CodeInvalid)
+)
+
+// WebhookPoster posts webhook requests and returns the response status code,
+// err is only set when no response was received.
+type WebhookPoster interface {
+	Post(ctx context.Context, url string, header http.Header, body []byte) (status int, err error)
+}
+
+// Webhook request headers. The signature is a hex encoded HMAC-SHA256 of the
+// timestamp and body joined by a dot using the webhook secret as key.
+const (
+	WebhookHeaderEvent     = "Foo-Webhook-Event"
+	WebhookHeaderDelivery  = "Foo-Webhook-Delivery"
+	WebhookHeaderTimestamp = "Foo-Webhook-Timestamp"
+	WebhookHeaderSignature = "Foo-Webhook-Signature"
+)
+
+// WebhookEvents lists event types webhooks can subscribe to.
+var WebhookEvents = []string{
+	TopicFighterCreated,
+	TopicFighterUpdated,
+	TopicFighterDeleted,
+	TopicFighterRestored,
+	TopicFighterMerged,
+}
+
+// webhook delivery settings.
+const (
+	// webhookMaxAttempts is how many times an event is posted to a failing webhook.
+	webhookMaxAttempts = 6
+	// webhookRetryDelay is the delay of first retry, doubled on each attempt.
+	webhookRetryDelay = 30 * time.Second
+	// webhookDisableAfter is how many consecutive failed posts disable a webhook.
+	webhookDisableAfter = 15
+	// webhookTimeout bounds a single post.
+	webhookTimeout = 10 * time.Second
+)
+
+// Webhook represents a client url pushed events it subscribed to.
+type Webhook struct {
+	ID     uuid.UUID `json:"id"`
+	URL    string    `json:"url"`
+	Events []string  `json:"events"`
+	// Secret signs webhook requests and is only returned on create.
+	Secret string `json:"secret,omitempty"`
+	UserID string `json:"user_id"`
+	// Failures counts consecutive failed posts and resets on success.
+	Failures int `json:"failures"`
+	// DisabledAt is set when the webhook is disabled for failing too often.
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Validate checks and normalizes webhook fields and returns all field errors found.
+func (w *Webhook) Validate() error {
+	var fe xerror.ValidationError
+	w.URL = strings.TrimSpace(w.URL)
+	if w.URL == "" {
+		fe = fe.Add("url", xerror.ViolationRequired, "is required")
+	} else if !httpsURL(w.URL) {
+		fe = fe.Add("url", xerror.ViolationMalformed, "must be an https url")
+	} else if !publicHost(w.URL) {
+		fe = fe.Add("url", xerror.ViolationUnsupported, "must not be a local or private address")
+	}
+	if len(w.Events) == 0 {
+		fe = fe.Add("events", xerror.ViolationRequired, "is required")
+	}
+	for i, e := range w.Events {
+		field := fmt.Sprintf("events[%d]", i)
+		if !slices.Contains(WebhookEvents, e) {
+			fe = fe.Add(field, xerror.ViolationUnsupported, "must be one of "+strings.Join(WebhookEvents, ", "))
+		} else if slices.Index(w.Events, e) != i {
+			fe = fe.Add(field, xerror.ViolationDuplicate, "must not be repeated")
+		}
+	}
+
+	if len(fe) != 0 {
+		return ErrWebhookInvalid.X(fe)
+	}
+	return nil
+}
+
+// PublicIP reports whether ip is a public unicast address, webhooks are not
+// posted to loopback, private, link-local and other internal addresses.
+func PublicIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
+
+// newWebhookSecret returns a random webhook signing secret.
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// SignWebhook returns the signature header value of a webhook body sent at t.
+// Receivers should recompute it and reject old timestamps to prevent replays.
+func SignWebhook(secret string, t time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(t.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookEvent represents the body of a webhook request. Its id stays the
+// same across retries so receivers can ignore duplicates.
+type WebhookEvent struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// WebhookDelivery represents an attempt to post an event to a webhook.
+type WebhookDelivery struct {
+	ID         uuid.UUID `json:"id"`
+	WebhookID  uuid.UUID `json:"webhook_id"`
+	EventID    uuid.UUID `json:"event_id"`
+	Event      string    `json:"event"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code"`
+	// Error describes why the post failed, empty on success.
+	Error      string    `json:"error"`
+	DurationMS int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Succeeded reports whether the webhook accepted the event.
+func (d WebhookDelivery) Succeeded() bool {
+	return d.Error == ""
+}
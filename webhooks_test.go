@@ -0,0 +1,205 @@
+package foo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+	"github.com/kudarap/foo/xerror"
+)
+
+func TestWebhook_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		w          foo.Webhook
+		wantFields []string
+	}{
+		{"valid", foo.Webhook{URL: " https://partner.test/hooks ", Events: []string{"fighter.updated"}}, nil},
+		{"missing", foo.Webhook{}, []string{"url", "events"}},
+		{"malformed url", foo.Webhook{URL: "partner.test/hooks", Events: []string{"fighter.created"}}, []string{"url"}},
+		{"plain http", foo.Webhook{URL: "http://partner.test/hooks", Events: []string{"fighter.created"}}, []string{"url"}},
+		{"localhost", foo.Webhook{URL: "https://api.localhost/hooks", Events: []string{"fighter.created"}}, []string{"url"}},
+		{"private ip", foo.Webhook{URL: "https://10.0.0.8/hooks", Events: []string{"fighter.created"}}, []string{"url"}},
+		{"link-local ip", foo.Webhook{URL: "https://169.254.169.254/latest", Events: []string{"fighter.created"}}, []string{"url"}},
+		{"loopback ipv6", foo.Webhook{URL: "https://[::1]:8443/hooks", Events: []string{"fighter.created"}}, []string{"url"}},
+		{"public ip", foo.Webhook{URL: "https://203.0.113.7/hooks", Events: []string{"fighter.created"}}, nil},
+		{"unknown and repeated events", foo.Webhook{
+			URL:    "https://partner.test",
+			Events: []string{"fighter.updated", "bout.completed", "fighter.updated"},
+		}, []string{"events[1]", "events[2]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.w.Validate()
+			var got []string
+			var ve xerror.ValidationError
+			if errors.As(err, &ve) {
+				for _, v := range ve {
+					got = append(got, v.Field)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	got := foo.SignWebhook("whsec_test", time.Unix(1700000000, 0), []byte(`{"a":1}`))
+	want := "sha256=38877139021993b830af32feea6e18a8da83eb2f6e49ee50bd9e4cf4ca4d3789"
+	if got != want {
+		t.Errorf("SignWebhook() = %s, want %s", got, want)
+	}
+}
+
+func TestService_CreateWebhook(t *testing.T) {
+	repo := &mockFighterRepo{
+		CreateWebhookFn: func(ctx context.Context, w *foo.Webhook) error { return nil },
+	}
+	l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := foo.NewService(repo, nil, nil, nil, l)
+	w := foo.Webhook{URL: "https://partner.test/hooks", Events: []string{"fighter.updated"}}
+
+	var xerr xerror.XError
+	if _, err := svc.CreateWebhook(context.Background(), &w); !errors.As(err, &xerr) || xerr.Code != xerror.CodeInvalid {
+		t.Fatalf("CreateWebhook() anonymous error = %v, want invalid", err)
+	}
+	ctx := foo.ContextWithActor(context.Background(), foo.Actor{UserID: "u1"})
+	got, err := svc.CreateWebhook(ctx, &w)
+	if err != nil {
+		t.Fatalf("CreateWebhook() unexpected error %s", err)
+	}
+	if got.ID == uuid.Nil || got.UserID != "u1" || !strings.HasPrefix(got.Secret, "whsec_") {
+		t.Errorf("CreateWebhook() = %+v, want id, user and secret set", got)
+	}
+}
+
+func TestService_PurgeWebhookDeliveries(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention time.Duration
+		want      time.Duration
+	}{
+		{"default retention", 0, foo.DefaultWebhookDeliveryRetention},
+		{"configured retention", 24 * time.Hour, 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before time.Time
+			repo := &mockFighterRepo{
+				PurgeWebhookDeliveriesFn: func(ctx context.Context, b time.Time) (int64, error) {
+					before = b
+					return 4, nil
+				}}
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, nil, l)
+			n, err := svc.PurgeWebhookDeliveries(context.Background(), tt.retention)
+			if err != nil || n != 4 {
+				t.Fatalf("PurgeWebhookDeliveries() = %d, %v, want 4", n, err)
+			}
+			if got := time.Since(before); got < tt.want || got > tt.want+time.Minute {
+				t.Errorf("PurgeWebhookDeliveries() purged before %v ago, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_DeliverWebhook(t *testing.T) {
+	hookID := uuid.New()
+	disabledAt := time.Now()
+	tests := []struct {
+		name        string
+		disabled    bool
+		attempt     int
+		status      int
+		postErr     error
+		nowDisabled bool
+		wantPosted  bool
+		wantRetry   bool
+	}{
+		{"delivered", false, 0, http.StatusOK, nil, false, true, false},
+		{"server error", false, 0, http.StatusBadGateway, nil, false, true, true},
+		{"unreachable", false, 2, 0, errors.New("connection refused"), false, true, true},
+		{"attempts exhausted", false, 5, http.StatusInternalServerError, nil, false, true, false},
+		{"disabled by failure", false, 0, http.StatusInternalServerError, nil, true, true, false},
+		{"disabled", true, 0, http.StatusOK, nil, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &foo.Webhook{ID: hookID, URL: "https://partner.test/hooks", Secret: "whsec_test"}
+			if tt.disabled {
+				w.DisabledAt = &disabledAt
+			}
+			var gotDelivery *foo.WebhookDelivery
+			var gotRetry *foo.WebhookDispatch
+			repo := &mockFighterRepo{
+				WebhookFn: func(ctx context.Context, id uuid.UUID) (*foo.Webhook, error) {
+					return w, nil
+				},
+				AddWebhookDeliveryFn: func(ctx context.Context, d *foo.WebhookDelivery, disableAfter int) (*foo.Webhook, error) {
+					gotDelivery = d
+					u := *w
+					if tt.nowDisabled {
+						u.DisabledAt = &disabledAt
+					}
+					return &u, nil
+				},
+				PublishFn: func(ctx context.Context, mm ...foo.Message) error {
+					var d foo.WebhookDispatch
+					json.Unmarshal(mm[0].Payload, &d)
+					if !mm[0].NotBefore.After(time.Now()) {
+						t.Errorf("retry not before = %v, want a delay", mm[0].NotBefore)
+					}
+					gotRetry = &d
+					return nil
+				},
+			}
+			var posted bool
+			poster := webhookPosterFunc(func(ctx context.Context, url string, h http.Header, body []byte) (int, error) {
+				posted = true
+				ts, _ := strconv.ParseInt(h.Get(foo.WebhookHeaderTimestamp), 10, 64)
+				if h.Get(foo.WebhookHeaderSignature) != foo.SignWebhook(w.Secret, time.Unix(ts, 0), body) {
+					t.Errorf("Post() signature does not match body and timestamp")
+				}
+				return tt.status, tt.postErr
+			})
+			l := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			svc := foo.NewService(repo, nil, nil, poster, l)
+
+			event := foo.WebhookEvent{ID: uuid.New(), Type: foo.TopicFighterUpdated, Data: json.RawMessage(`{}`)}
+			d := foo.WebhookDispatch{WebhookID: hookID, Event: event, Attempt: tt.attempt}
+			if err := svc.DeliverWebhook(context.Background(), d); err != nil {
+				t.Fatalf("DeliverWebhook() unexpected error %s", err)
+			}
+			if posted != tt.wantPosted {
+				t.Fatalf("DeliverWebhook() posted = %v, want %v", posted, tt.wantPosted)
+			}
+			if posted && (gotDelivery == nil || gotDelivery.Succeeded() != (tt.status == http.StatusOK)) {
+				t.Errorf("DeliverWebhook() delivery log = %+v", gotDelivery)
+			}
+			if (gotRetry != nil) != tt.wantRetry {
+				t.Fatalf("DeliverWebhook() retried = %v, want %v", gotRetry != nil, tt.wantRetry)
+			}
+			if gotRetry != nil && (gotRetry.Attempt != tt.attempt+1 || gotRetry.Event.ID != event.ID) {
+				t.Errorf("DeliverWebhook() retry = %+v, want same event on next attempt", gotRetry)
+			}
+		})
+	}
+}
+
+type webhookPosterFunc func(ctx context.Context, url string, h http.Header, body []byte) (int, error)
+
+func (f webhookPosterFunc) Post(ctx context.Context, url string, h http.Header, body []byte) (int, error) {
+	return f(ctx, url, h, body)
+}
@@ -2,16 +2,37 @@ package worker
 
 import (
 	"context"
-	"log/slog"
-	"math/rand"
+	"encoding/json"
+	"fmt"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
 )
 
-func FakeFighterConsumer(l *slog.Logger) JobHandler {
+type fighterPurger interface {
+	PurgeFighters(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// FighterPurger permanently removes fighters deleted longer than retention.
+func FighterPurger(s fighterPurger, retention time.Duration) JobHandler {
+	return func(ctx context.Context, j Job) error {
+		_, err := s.PurgeFighters(ctx, retention)
+		return err
+	}
+}
+
+type fighterStatsRefresher interface {
+	RefreshFighterStats(ctx context.Context, fighterIDs []uuid.UUID) error
+}
+
+// FighterStatsRefresher refreshes stats of fighters whose completed bouts changed.
+func FighterStatsRefresher(s fighterStatsRefresher) JobHandler {
 	return func(ctx context.Context, j Job) error {
-		l.Info("fake-fighter consumer received", "job", string(j.Payload))
-		// emulate process latency
-		time.Sleep(time.Second*2 + time.Millisecond*time.Duration(rand.Int63n(3000)))
-		return nil
+		var m foo.FighterStatsStale
+		if err := json.Unmarshal(j.Payload, &m); err != nil {
+			return fmt.Errorf("could not decode payload: %s", err)
+		}
+		return s.RefreshFighterStats(ctx, m.FighterIDs)
 	}
 }
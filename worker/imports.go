@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+)
+
+type importService interface {
+	ProcessFighterImport(ctx context.Context, id uuid.UUID) error
+	FailFighterImport(ctx context.Context, id uuid.UUID, reason string) error
+}
+
+// FighterImporter validates and upserts rows of a queued fighter import, the
+// import is marked failed when its last attempt fails.
+func FighterImporter(s importService) JobHandler {
+	return func(ctx context.Context, j Job) error {
+		var m foo.FighterImportCreated
+		if err := json.Unmarshal(j.Payload, &m); err != nil {
+			return fmt.Errorf("could not decode payload: %s", err)
+		}
+		err := s.ProcessFighterImport(ctx, m.ImportID)
+		if err != nil && j.LastAttempt {
+			if ferr := s.FailFighterImport(ctx, m.ImportID, err.Error()); ferr != nil {
+				return fmt.Errorf("could not fail import: %s: %s", ferr, err)
+			}
+		}
+		return err
+	}
+}
@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/kudarap/foo"
+)
+
+type thumbnailer interface {
+	GenerateMediaThumbnail(ctx context.Context, id uuid.UUID) error
+}
+
+// MediaThumbnailer generates thumbnails of uploaded media.
+func MediaThumbnailer(s thumbnailer) JobHandler {
+	return func(ctx context.Context, j Job) error {
+		var m foo.MediaUploaded
+		if err := json.Unmarshal(j.Payload, &m); err != nil {
+			return fmt.Errorf("could not decode payload: %s", err)
+		}
+		return s.GenerateMediaThumbnail(ctx, m.MediaID)
+	}
+}
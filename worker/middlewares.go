@@ -10,7 +10,8 @@ func LoggingMiddleware(logger *slog.Logger) func(next JobHandler) JobHandler {
 	return func(next JobHandler) JobHandler {
 		return func(ctx context.Context, job Job) error {
 			start := time.Now()
-			logger.InfoContext(ctx, "job received", "topic", job.Topic, "payload", string(job.Payload))
+			logger.InfoContext(ctx, "job received", "topic", job.Topic, "tenant", job.Tenant,
+				"payload", string(job.Payload))
 
 			if err := next(ctx, job); err != nil {
 				logger.ErrorContext(ctx, "job handler", "err", err, "topic", job.Topic)
@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kudarap/foo"
+)
+
+type notificationService interface {
+	NotifyBoutFollowers(ctx context.Context, bn foo.BoutNotice) error
+	DeliverNotification(ctx context.Context, d foo.NotificationDelivery) error
+}
+
+// NotificationFanout queues a notification to each follower of fighters on a
+// scheduled or completed bout.
+func NotificationFanout(s notificationService) JobHandler {
+	return func(ctx context.Context, j Job) error {
+		var m foo.BoutNotice
+		if err := json.Unmarshal(j.Payload, &m); err != nil {
+			return fmt.Errorf("could not decode payload: %s", err)
+		}
+		return s.NotifyBoutFollowers(ctx, m)
+	}
+}
+
+// NotificationDeliverer delivers a notification through the user preferred channels.
+func NotificationDeliverer(s notificationService) JobHandler {
+	return func(ctx context.Context, j Job) error {
+		var m foo.NotificationDelivery
+		if err := json.Unmarshal(j.Payload, &m); err != nil {
+			return fmt.Errorf("could not decode payload: %s", err)
+		}
+		return s.DeliverNotification(ctx, m)
+	}
+}
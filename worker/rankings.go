@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kudarap/foo"
+)
+
+type rankingService interface {
+	RecomputeRankings(ctx context.Context, wc foo.WeightClass) error
+}
+
+// RankingsConsumer recomputes weight class rankings when a bout is completed.
+func RankingsConsumer(s rankingService) JobHandler {
+	return func(ctx context.Context, j Job) error {
+		var m foo.BoutCompleted
+		if err := json.Unmarshal(j.Payload, &m); err != nil {
+			return fmt.Errorf("could not decode payload: %s", err)
+		}
+		return s.RecomputeRankings(ctx, m.WeightClass)
+	}
+}
@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// relay settings.
+const (
+	defaultRelayInterval = time.Second
+	relayBatchSize       = 100
+)
+
+// outboxRelayer moves messages written to the outbox to worker topics.
+type outboxRelayer interface {
+	RelayOutbox(ctx context.Context, limit int) (int, error)
+}
+
+// Relay periodically publishes outbox messages to worker topics.
+type Relay struct {
+	outbox   outboxRelayer
+	interval time.Duration
+	quit     chan struct{}
+	done     chan struct{}
+	logger   *slog.Logger
+}
+
+// NewRelay create new instance of outbox relay.
+func NewRelay(outbox outboxRelayer, interval time.Duration, logger *slog.Logger) *Relay {
+	if interval == 0 {
+		interval = defaultRelayInterval
+	}
+	return &Relay{
+		outbox:   outbox,
+		interval: interval,
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+		logger:   logger.With("pkg", "relay"),
+	}
+}
+
+// Run starts relaying outbox messages on every interval until stopped.
+func (r *Relay) Run() error {
+	go func() {
+		defer close(r.done)
+		t := time.NewTicker(r.interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-r.quit:
+				return
+			case <-t.C:
+				r.relay()
+			}
+		}
+	}()
+
+	r.logger.Info("relay running", "interval", r.interval.String())
+	return nil
+}
+
+// relay drains outbox in batches.
+func (r *Relay) relay() {
+	ctx := context.Background()
+	for {
+		n, err := r.outbox.RelayOutbox(ctx, relayBatchSize)
+		if err != nil {
+			r.logger.Error("could not relay outbox", "err", err)
+			return
+		}
+		if n > 0 {
+			r.logger.Debug("outbox relayed", "messages", n)
+		}
+		if n < relayBatchSize {
+			return
+		}
+	}
+}
+
+// Stop stops relaying and waits for the current relay to finish.
+func (r *Relay) Stop() error {
+	r.logger.Info("stopping relay...")
+	close(r.quit)
+	<-r.done
+	return nil
+}
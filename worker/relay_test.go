@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+type mockOutbox struct {
+	batches []int
+	err     error
+	limits  []int
+}
+
+func (m *mockOutbox) RelayOutbox(ctx context.Context, limit int) (int, error) {
+	m.limits = append(m.limits, limit)
+	if m.err != nil {
+		return 0, m.err
+	}
+	n := m.batches[0]
+	m.batches = m.batches[1:]
+	return n, nil
+}
+
+func TestRelay_relay(t *testing.T) {
+	tests := []struct {
+		name      string
+		outbox    *mockOutbox
+		wantCalls int
+	}{
+		{"empty", &mockOutbox{batches: []int{0}}, 1},
+		{"partial batch", &mockOutbox{batches: []int{30}}, 1},
+		{"drains full batches", &mockOutbox{batches: []int{relayBatchSize, relayBatchSize, 30}}, 3},
+		{"stops on error", &mockOutbox{err: errors.New("connection failed")}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRelay(tt.outbox, 0, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			r.relay()
+			if len(tt.outbox.limits) != tt.wantCalls {
+				t.Fatalf("relay() relayed %d batches, want %d", len(tt.outbox.limits), tt.wantCalls)
+			}
+			for _, l := range tt.outbox.limits {
+				if l != relayBatchSize {
+					t.Errorf("relay() batch limit = %d, want %d", l, relayBatchSize)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kudarap/foo"
+)
+
+type webhookService interface {
+	DispatchWebhookEvent(ctx context.Context, event string, payload json.RawMessage) error
+	DeliverWebhook(ctx context.Context, d foo.WebhookDispatch) error
+}
+
+// WebhookDispatcher queues delivery of a domain event to its subscribed
+// webhooks, the job topic is the event type.
+func WebhookDispatcher(s webhookService) JobHandler {
+	return func(ctx context.Context, j Job) error {
+		if !json.Valid(j.Payload) {
+			return fmt.Errorf("could not decode payload: invalid json")
+		}
+		return s.DispatchWebhookEvent(ctx, j.Topic, j.Payload)
+	}
+}
+
+// WebhookDeliverer posts a signed event to a webhook.
+func WebhookDeliverer(s webhookService) JobHandler {
+	return func(ctx context.Context, j Job) error {
+		var m foo.WebhookDispatch
+		if err := json.Unmarshal(j.Payload, &m); err != nil {
+			return fmt.Errorf("could not decode payload: %s", err)
+		}
+		return s.DeliverWebhook(ctx, m)
+	}
+}
+
+type webhookDeliveryPurger interface {
+	PurgeWebhookDeliveries(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// WebhookDeliveryPurger removes webhook deliveries logged longer than retention.
+func WebhookDeliveryPurger(s webhookDeliveryPurger, retention time.Duration) JobHandler {
+	return func(ctx context.Context, j Job) error {
+		_, err := s.PurgeWebhookDeliveries(ctx, retention)
+		return err
+	}
+}
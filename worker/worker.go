@@ -3,6 +3,9 @@ package worker
 import (
 	"context"
 	"log/slog"
+	"time"
+
+	"github.com/kudarap/foo"
 )
 
 const defaultJobQueueSize = 10
@@ -11,7 +14,11 @@ const defaultJobQueueSize = 10
 type Job struct {
 	Topic   string
 	Payload []byte
-	Done    func() error
+	// Tenant is the tenant the job runs for, empty for system jobs.
+	Tenant string
+	// LastAttempt is set when a failed job will not be retried.
+	LastAttempt bool
+	Done        func() error
 }
 
 // JobHandler represents worker handler functions
@@ -27,6 +34,7 @@ type Worker struct {
 	done        chan error
 	router      map[string]JobHandler
 	middlewares []MiddlewareFunc
+	schedules   []schedule
 	listener    jobListener
 	logger      *slog.Logger
 }
@@ -68,6 +76,12 @@ func (w *Worker) Run() error {
 		return err
 	}
 
+	stopSchedules := make(chan struct{})
+	for _, sc := range w.schedules {
+		w.logger.Info("register schedule", "topic", sc.topic, "interval", sc.interval.String())
+		go w.runSchedule(sc, stopSchedules)
+	}
+
 	// Process job received from the job listener.
 	go func() {
 		ctx, cancel := context.WithCancel(context.Background())
@@ -78,6 +92,7 @@ func (w *Worker) Run() error {
 			// processing job.
 			case <-w.quit:
 				w.logger.Info("worker quiting...")
+				close(stopSchedules)
 				stopListen <- struct{}{}
 				w.done <- nil
 				return
@@ -94,7 +109,11 @@ func (w *Worker) Run() error {
 					handle = m(handle)
 				}
 
-				if err := handle(ctx, job); err != nil {
+				jctx := ctx
+				if job.Tenant != "" {
+					jctx = foo.ContextWithTenant(ctx, job.Tenant)
+				}
+				if err := handle(jctx, job); err != nil {
 					continue
 				}
 				if err := job.Done(); err != nil {
@@ -121,6 +140,37 @@ func (w *Worker) HandleFunc(topic string, f JobHandler) {
 	w.router[topic] = f
 }
 
+// schedule represents a job periodically enqueued by the worker itself.
+type schedule struct {
+	topic    string
+	interval time.Duration
+}
+
+// Schedule registers a job on topic enqueued every interval, the topic should
+// also be routed with HandleFunc.
+func (w *Worker) Schedule(topic string, interval time.Duration) {
+	w.schedules = append(w.schedules, schedule{topic, interval})
+}
+
+// runSchedule enqueues scheduled job on every interval until stopped.
+func (w *Worker) runSchedule(sc schedule, stop <-chan struct{}) {
+	t := time.NewTicker(sc.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			job := Job{Topic: sc.topic, Payload: []byte("{}"), Done: func() error { return nil }}
+			select {
+			case w.queue <- job:
+			case <-stop:
+				return
+			}
+		}
+	}
+}
+
 // Use registers middlewares for job handlers.
 func (w *Worker) Use(mm ...MiddlewareFunc) {
 	for _, m := range mm {
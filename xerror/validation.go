@@ -0,0 +1,43 @@
+package xerror
+
+import "strings"
+
+// Common violation codes shared across services.
+const (
+	ViolationRequired    = "required"
+	ViolationMalformed   = "malformed"
+	ViolationTooLong     = "too_long"
+	ViolationTooLarge    = "too_large"
+	ViolationOutOfRange  = "out_of_range"
+	ViolationUnsupported = "unsupported"
+	ViolationDuplicate   = "duplicate"
+	ViolationConflict    = "conflict"
+)
+
+// Violation represents a validation failure of an input field.
+type Violation struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError represents all validation failures of an input.
+type ValidationError []Violation
+
+// NewValidationError returns validation error of a single field violation.
+func NewValidationError(field, code, message string) ValidationError {
+	return ValidationError{{Field: field, Code: code, Message: message}}
+}
+
+func (e ValidationError) Error() string {
+	ss := make([]string, len(e))
+	for i, v := range e {
+		ss[i] = v.Field + " " + v.Message
+	}
+	return strings.Join(ss, ", ")
+}
+
+// Add appends a field violation and returns the extended error.
+func (e ValidationError) Add(field, code, message string) ValidationError {
+	return append(e, Violation{Field: field, Code: code, Message: message})
+}
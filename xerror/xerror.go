@@ -2,6 +2,13 @@ package xerror
 
 import "fmt"
 
+// Common error codes shared across services.
+const (
+	CodeNotFound = "not_found"
+	CodeInvalid  = "invalid"
+	CodeConflict = "conflict"
+)
+
 type Error string
 
 func (e Error) Error() string { return string(e) }
@@ -20,3 +27,6 @@ func NewXError(err error, code string) XError {
 }
 
 func (e XError) Error() string { return fmt.Sprintf("%s: %s", e.Code, e.Err) }
+
+// Unwrap returns the underlying error.
+func (e XError) Unwrap() error { return e.Err }